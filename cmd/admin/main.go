@@ -0,0 +1,50 @@
+// Команда admin выдает роль администратора: создает аккаунт или добавляет роль существующему.
+// Пароль читается из переменной ADMIN_PASSWORD или первой строкой из stdin, чтобы он
+// не попадал в историю команд:
+//
+//	echo "$PASSWORD" | go run ./cmd/admin -email admin@buhpro.kz
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"BruhPro/internal/config"
+	"BruhPro/internal/repository"
+	"BruhPro/internal/usecase"
+
+	"github.com/sirupsen/logrus"
+)
+
+func main() {
+	email := flag.String("email", "", "email администратора")
+	flag.Parse()
+
+	logger := logrus.New()
+	if *email == "" {
+		logger.Fatal("Usage: admin -email <email> (password from ADMIN_PASSWORD or stdin)")
+	}
+
+	password := os.Getenv("ADMIN_PASSWORD")
+	if password == "" {
+		fmt.Fprint(os.Stderr, "Password: ")
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			logger.Fatalf("Failed to read password: %v", err)
+		}
+		password = strings.TrimRight(line, "\r\n")
+	}
+
+	cfg := config.LoadConfig()
+	database := config.Connect(cfg.DBURL)
+
+	admins := usecase.NewAdminUsecase(repository.NewAccountRepository(database), logger)
+	account, err := admins.Provision(strings.ToLower(strings.TrimSpace(*email)), password)
+	if err != nil {
+		logger.Fatalf("Failed to provision admin: %v", err)
+	}
+	fmt.Printf("Admin role granted to %s (%s)\n", account.Email, account.ID)
+}
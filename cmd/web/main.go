@@ -1,13 +1,19 @@
 package main
 
 import (
-	"BuhPro+/internal/config"
-	"BuhPro+/internal/delivery/gin/middleware"
-	"BuhPro+/internal/delivery/gin/routes"
-	"BuhPro+/internal/delivery/http/handlers"
-	"BuhPro+/internal/repository"
-	"BuhPro+/internal/usecase"
-	"BuhPro+/internal/utils"
+	"time"
+
+	"BruhPro/internal/config"
+	"BruhPro/internal/delivery/gin/middleware"
+	"BruhPro/internal/delivery/gin/routes"
+	"BruhPro/internal/delivery/http/handlers"
+	"BruhPro/internal/ledger"
+	"BruhPro/internal/mailer"
+	"BruhPro/internal/payments"
+	"BruhPro/internal/ratelimit"
+	"BruhPro/internal/repository"
+	"BruhPro/internal/usecase"
+	"BruhPro/internal/utils"
 
 	"github.com/gin-gonic/gin"
 )
@@ -25,62 +31,143 @@ func main() {
 	database := config.Connect(cfg.DBURL)
 
 	// 4. Инициализация репозиториев
-	userRepo := repository.NewUserRepository(database)
+	accountRepo := repository.NewAccountRepository(database)
+	sessionRepo := repository.NewSessionRepository(database)
+	revokedTokenRepo := repository.NewRevokedTokenRepository(database)
+	passwordResetRepo := repository.NewPasswordResetRepository(database)
+	emailVerificationRepo := repository.NewEmailVerificationRepository(database)
+	emailChangeRepo := repository.NewEmailChangeRepository(database)
+	mfaRepo := repository.NewMFARepository(database)
 	customerRepo := repository.NewCustomerRepository(database)
 	coachRepo := repository.NewCoachRepository(database)
 	executorRepo := repository.NewExecutorRepository(database)
+	specializationRepo := repository.NewSpecializationRepository(database)
+	orderRepo := repository.NewOrderRepository(database)
+	responseRepo := repository.NewResponseRepository(database)
+	ratingRepo := repository.NewRatingRepository(database)
+	courseRepo := repository.NewCourseRepository(database)
+	enrollmentRepo := repository.NewEnrollmentRepository(database)
+	paymentRepo := repository.NewPaymentRepository(database)
+	milestoneRepo := repository.NewMilestoneRepository(database)
+
+	// Журнал двойной записи для движения денег через платформу
+	journal := ledger.New(ledger.NewGormStore(database))
+
+	// Почта: SMTP в продакшене, файлы в MAIL_DIR для разработки и тестов
+	var mail mailer.Mailer
+	if cfg.Mailer == "smtp" {
+		mail = mailer.NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.MailFrom)
+	} else {
+		mail = mailer.NewFileMailer(cfg.MailDir, cfg.MailFrom, appLogger)
+	}
+
+	// Платежный провайдер: пока доступен только локальный, без реальных списаний
+	var fakePayments *payments.FakeProvider
+	var paymentProvider payments.Provider
+	switch cfg.PaymentProvider {
+	case "", "fake":
+		fakePayments = payments.NewFakeProvider(cfg.PaymentWebhookSecret)
+		paymentProvider = fakePayments
+	default:
+		appLogger.Fatalf("Unknown payment provider: %s", cfg.PaymentProvider)
+	}
+
+	// Ключи подписи JWT
+	jwtManager, err := utils.LoadJWTManager(cfg.JWTKeysDir, cfg.JWTSigningKeyID, cfg.JWTIssuer, cfg.JWTAudience)
+	if err != nil {
+		appLogger.Fatalf("Failed to load JWT keys: %v", err)
+	}
 
-	// Пустые репозитории для будущих функций
-	// orderRepo := repository.NewOrderRepository(database)
-	// responseRepo := repository.NewResponseRepository(database)
-	// ratingRepo := repository.NewRatingRepository(database)
-	// courseRepo := repository.NewCourseRepository(database)
-	// paymentRepo := repository.NewPaymentRepository(database)
+	// Счетчики лимитов и блокировок хранятся в памяти инстанса; для нескольких инстансов
+	// нужен общий ratelimit.Store (например, поверх Redis)
+	rateLimitStore := ratelimit.NewMemoryStore()
+	rateLimitStore.StartCleanup(time.Minute)
 
 	// 5. Инициализация UseCase (бизнес-логика)
-	authUsecase := usecase.NewAuthUsecase(userRepo, cfg.JWTSecret, serviceLogger)
-	customerUsecase := usecase.NewCustomerUsecase(customerRepo, cfg.JWTSecret, serviceLogger)
-	coachUsecase := usecase.NewCoachUsecase(coachRepo, cfg.JWTSecret, serviceLogger)
-	executorUsecase := usecase.NewExecutorUsecase(executorRepo, cfg.JWTSecret, serviceLogger)
-
-	// Пустые UseCase для будущих функций
-	// orderUsecase := usecase.NewOrderUsecase(orderRepo, serviceLogger)
-	// responseUsecase := usecase.NewResponseUsecase(responseRepo, serviceLogger)
-	// ratingUsecase := usecase.NewRatingUsecase(ratingRepo, serviceLogger)
-	// courseUsecase := usecase.NewCourseUsecase(courseRepo, serviceLogger)
-	// paymentUsecase := usecase.NewPaymentUsecase(paymentRepo, serviceLogger)
+	tokenDenylist := usecase.NewTokenDenylist(revokedTokenRepo, serviceLogger)
+	tokenDenylist.StartSync(time.Minute)
+
+	verificationUsecase := usecase.NewEmailVerificationUsecase(accountRepo, emailVerificationRepo, mail, cfg.AppBaseURL, serviceLogger)
+	authUsecase := usecase.NewAuthUsecase(accountRepo, sessionRepo, mfaRepo, tokenDenylist, verificationUsecase, jwtManager, serviceLogger)
+	specializationUsecase := usecase.NewSpecializationUsecase(specializationRepo, serviceLogger)
+	customerUsecase := usecase.NewCustomerUsecase(customerRepo, authUsecase, serviceLogger)
+	coachUsecase := usecase.NewCoachUsecase(coachRepo, authUsecase, specializationUsecase, serviceLogger)
+	executorUsecase := usecase.NewExecutorUsecase(executorRepo, authUsecase, specializationUsecase, serviceLogger)
+	passwordUsecase := usecase.NewPasswordUsecase(accountRepo, passwordResetRepo, authUsecase, mail, cfg.AppBaseURL, serviceLogger)
+	emailChangeUsecase := usecase.NewEmailChangeUsecase(accountRepo, emailChangeRepo, authUsecase, mail, cfg.AppBaseURL, serviceLogger)
+	// Ошибки кода на втором шаге входа считаются по аккаунту, а не по IP
+	mfaLockout := ratelimit.NewLockout(rateLimitStore, 5, time.Minute, 24*time.Hour, 24*time.Hour)
+	mfaUsecase := usecase.NewMFAUsecase(mfaRepo, authUsecase, mfaLockout, serviceLogger)
+
+	orderUsecase := usecase.NewOrderUsecase(orderRepo, verificationUsecase, specializationUsecase, serviceLogger)
+	orderUsecase.OnTransition(usecase.NewOrderNotifier(accountRepo, mail, serviceLogger).Notify)
+	responseUsecase := usecase.NewResponseUsecase(responseRepo, orderRepo, executorRepo, orderUsecase, verificationUsecase, serviceLogger)
+	ratingUsecase := usecase.NewRatingUsecase(ratingRepo, orderRepo, courseRepo, enrollmentRepo, serviceLogger)
+	courseUsecase := usecase.NewCourseUsecase(courseRepo, enrollmentRepo, specializationUsecase, serviceLogger)
+	paymentUsecase := usecase.NewPaymentUsecase(paymentRepo, courseRepo, enrollmentRepo, paymentProvider, journal, serviceLogger)
+	escrowUsecase := usecase.NewEscrowUsecase(milestoneRepo, orderRepo, paymentUsecase, journal,
+		cfg.EscrowCommissionBps, time.Duration(cfg.EscrowAutoReleaseDays)*24*time.Hour, serviceLogger)
+	paymentUsecase.OnStatusChange(escrowUsecase.HandlePayment)
+	orderUsecase.OnTransition(escrowUsecase.HandleOrderTransition)
+	escrowUsecase.StartAutoRelease(time.Hour)
+	ledgerUsecase := usecase.NewLedgerUsecase(journal, paymentProvider, serviceLogger)
 
 	// 6. Инициализация HTTP-обработчиков
 	authHandler := handlers.NewAuthHandler(authUsecase, handlerLogger)
+	jwksHandler := handlers.NewJWKSHandler(jwtManager)
+	accountHandlers := routes.AccountHandlers{
+		Session:      handlers.NewSessionHandler(authUsecase, handlerLogger),
+		Password:     handlers.NewPasswordHandler(passwordUsecase, handlerLogger),
+		Verification: handlers.NewVerificationHandler(verificationUsecase, handlerLogger),
+		EmailChange:  handlers.NewEmailChangeHandler(emailChangeUsecase, handlerLogger),
+		MFA:          handlers.NewMFAHandler(mfaUsecase, handlerLogger),
+	}
 	customerHandler := handlers.NewCustomerHandler(customerUsecase, handlerLogger)
 	coachHandler := handlers.NewCoachHandler(coachUsecase, handlerLogger)
 	executorHandler := handlers.NewExecutorHandler(executorUsecase, handlerLogger)
-
-	// Пустые обработчики для будущих функций
-	// orderHandler := handlers.NewOrderHandler(/* dependencies */)
-	// responseHandler := handlers.NewResponseHandler(/* dependencies */)
-	// ratingHandler := handlers.NewRatingHandler(/* dependencies */)
-	// courseHandler := handlers.NewCourseHandler(/* dependencies */)
-	// paymentHandler := handlers.NewPaymentHandler(/* dependencies */)
+	specializationHandler := handlers.NewSpecializationHandler(specializationUsecase, handlerLogger)
+	orderHandler := handlers.NewOrderHandler(orderUsecase, handlerLogger)
+	responseHandler := handlers.NewResponseHandler(responseUsecase, handlerLogger)
+	ratingHandler := handlers.NewRatingHandler(ratingUsecase, handlerLogger)
+	courseHandler := handlers.NewCourseHandler(courseUsecase, handlerLogger)
+	paymentHandler := handlers.NewPaymentHandler(paymentUsecase, handlerLogger)
+	escrowHandler := handlers.NewEscrowHandler(escrowUsecase, handlerLogger)
+	ledgerHandler := handlers.NewLedgerHandler(ledgerUsecase, handlerLogger)
 
 	// 7. Инициализация Gin роутера
 	r := gin.Default()
 
-	// 8. Инициализация общего JWT Middleware
-	authMiddleware := middleware.JWTAuth(cfg.JWTSecret)
+	// 8. Инициализация middleware: JWT, 2FA и защита от перебора
+	loginLimiter := ratelimit.NewLimiter(rateLimitStore, "login_ip", 20, time.Minute)
+	requestLimiter := ratelimit.NewLimiter(rateLimitStore, "request_ip", 10, time.Minute)
+	loginLockout := ratelimit.NewLockout(rateLimitStore, 5, 30*time.Second, time.Hour, 24*time.Hour)
+
+	mw := routes.Middlewares{
+		Auth:      middleware.JWTAuth(jwtManager, tokenDenylist),
+		MFA:       middleware.RequireMFA(cfg.MFAEnforcedRoles...),
+		Login:     middleware.LoginProtection(loginLimiter, loginLockout, appLogger),
+		RateLimit: middleware.RateLimit(requestLimiter, appLogger),
+	}
 
 	// 9. Настройка маршрутов
-	routes.AuthRoutes(r, authHandler, authMiddleware)
-	routes.CustomerAuthRoutes(r, customerHandler, authMiddleware)
-	routes.CoachAuthRoutes(r, coachHandler, authMiddleware)
-	routes.ExecutorAuthRoutes(r, executorHandler, authMiddleware)
-
-	// Пустые маршруты для будущих функций
-	// routes.OrderRoutes(r, orderHandler, authMiddleware)
-	// routes.ResponseRoutes(r, responseHandler, authMiddleware)
-	// routes.RatingRoutes(r, ratingHandler, authMiddleware)
-	// routes.CourseRoutes(r, courseHandler, authMiddleware)
-	// routes.PaymentRoutes(r, paymentHandler, authMiddleware)
+	routes.WellKnownRoutes(r, jwksHandler)
+	routes.AuthRoutes(r, authHandler, accountHandlers, mw)
+	routes.CustomerAuthRoutes(r, customerHandler, accountHandlers, mw)
+	routes.CoachAuthRoutes(r, coachHandler, accountHandlers, mw)
+	routes.ExecutorAuthRoutes(r, executorHandler, accountHandlers, mw)
+	routes.ExecutorRoutes(r, executorHandler)
+	routes.CoachRoutes(r, coachHandler)
+	routes.SpecializationRoutes(r, specializationHandler, mw)
+	routes.OrderRoutes(r, orderHandler, mw)
+	routes.ResponseRoutes(r, responseHandler, mw)
+	routes.RatingRoutes(r, ratingHandler, mw)
+	routes.CourseRoutes(r, courseHandler, mw)
+	routes.PaymentRoutes(r, paymentHandler, mw)
+	routes.EscrowRoutes(r, escrowHandler, mw)
+	routes.LedgerRoutes(r, ledgerHandler, mw)
+	if fakePayments != nil {
+		routes.FakeCheckoutRoutes(r, handlers.NewFakeCheckoutHandler(fakePayments, paymentUsecase, handlerLogger))
+	}
 
 	// 10. Запуск сервера
 	if err := r.Run(":" + cfg.Port); err != nil {
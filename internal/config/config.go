@@ -3,17 +3,46 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
 	DBURL          string
-	JWTSecret      string
 	Port           string
 	AppLogFile     string
 	ServiceLogFile string
 	HandlerLogFile string
+
+	// JWT: ключи *.pem из JWT_KEYS_DIR (имя файла — kid), подпись ключом JWT_SIGNING_KEY_ID
+	JWTKeysDir      string
+	JWTSigningKeyID string
+	JWTIssuer       string
+	JWTAudience     string
+
+	// Почта: MAILER=smtp отправляет письма через SMTP, иначе они сохраняются в MAIL_DIR
+	Mailer       string
+	MailDir      string
+	MailFrom     string
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	AppBaseURL   string // адрес фронтенда для ссылок в письмах
+
+	// Платежи: PAYMENT_PROVIDER=fake (по умолчанию) — локальный провайдер для разработки;
+	// PAYMENT_WEBHOOK_SECRET — ключ подписи уведомлений провайдера
+	PaymentProvider      string
+	PaymentWebhookSecret string
+
+	// Эскроу: комиссия платформы в базисных пунктах (1000 = 10%) и срок автоприемки этапа в днях
+	EscrowCommissionBps   int64
+	EscrowAutoReleaseDays int
+
+	// Роли, которым без входа со вторым фактором доступны только подключение 2FA и сессии
+	MFAEnforcedRoles []string
 }
 
 func LoadConfig() *Config {
@@ -27,10 +56,51 @@ func LoadConfig() *Config {
 
 	return &Config{
 		DBURL:          os.Getenv("DB_URL"),
-		JWTSecret:      os.Getenv("JWT_SECRET"),
 		Port:           os.Getenv("PORT"),
 		AppLogFile:     os.Getenv("APP_LOG_FILE"),
 		ServiceLogFile: os.Getenv("SERVICE_LOG_FILE"),
 		HandlerLogFile: os.Getenv("HANDLER_LOG_FILE"),
+
+		JWTKeysDir:      os.Getenv("JWT_KEYS_DIR"),
+		JWTSigningKeyID: os.Getenv("JWT_SIGNING_KEY_ID"),
+		JWTIssuer:       os.Getenv("JWT_ISSUER"),
+		JWTAudience:     os.Getenv("JWT_AUDIENCE"),
+
+		Mailer:       os.Getenv("MAILER"),
+		MailDir:      os.Getenv("MAIL_DIR"),
+		MailFrom:     os.Getenv("MAIL_FROM"),
+		SMTPHost:     os.Getenv("SMTP_HOST"),
+		SMTPPort:     os.Getenv("SMTP_PORT"),
+		SMTPUsername: os.Getenv("SMTP_USERNAME"),
+		SMTPPassword: os.Getenv("SMTP_PASSWORD"),
+		AppBaseURL:   os.Getenv("APP_BASE_URL"),
+
+		PaymentProvider:      os.Getenv("PAYMENT_PROVIDER"),
+		PaymentWebhookSecret: os.Getenv("PAYMENT_WEBHOOK_SECRET"),
+
+		EscrowCommissionBps:   int64(intOrDefault(os.Getenv("ESCROW_COMMISSION_BPS"), 1000)),
+		EscrowAutoReleaseDays: intOrDefault(os.Getenv("ESCROW_AUTO_RELEASE_DAYS"), 7),
+
+		MFAEnforcedRoles: splitList(os.Getenv("MFA_ENFORCED_ROLES")),
+	}
+}
+
+// splitList разбирает список значений через запятую, пропуская пустые.
+func splitList(value string) []string {
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// intOrDefault разбирает целое число; пустое или некорректное значение заменяется на def.
+func intOrDefault(value string, def int) int {
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return def
 	}
+	return n
 }
@@ -3,7 +3,8 @@ package config
 import (
 	"log"
 
-	"BuhPro+/internal/domain" // Обновите импорт на buhpro/internal/domain
+	"BruhPro/internal/domain" // Обновите импорт на buhpro/internal/domain
+	"BruhPro/internal/ledger"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -16,11 +17,37 @@ func Connect(url string) *gorm.DB {
 	}
 
 	err = db.AutoMigrate(
-		&domain.User{},
+		&domain.Account{},
+		&domain.AccountRole{},
+		&domain.Session{},
 		&domain.RefreshToken{},
+		&domain.RevokedToken{},
+		&domain.PasswordResetToken{},
+		&domain.EmailVerificationToken{},
+		&domain.EmailChange{},
+		&domain.AccountMFA{},
+		&domain.MFARecoveryCode{},
+		&domain.Specialization{},
 		&domain.Customer{},
 		&domain.Coach{},
 		&domain.Executor{},
+		&domain.ProfileChange{},
+		&domain.Order{},
+		&domain.OrderStatusChange{},
+		&domain.Response{},
+		&domain.Rating{},
+		&domain.RatingSummary{},
+		&domain.Course{},
+		&domain.CourseModule{},
+		&domain.Lesson{},
+		&domain.LessonAttachment{},
+		&domain.CourseEnrollment{},
+		&domain.LessonProgress{},
+		&domain.Payment{},
+		&domain.PaymentEvent{},
+		&domain.Milestone{},
+		&ledger.Entry{},
+		&ledger.Line{},
 	)
 	if err != nil {
 		log.Fatalf("Failed to migrate database: %v", err) // [cite: 4]
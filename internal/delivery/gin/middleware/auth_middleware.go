@@ -4,13 +4,20 @@ import (
 	"net/http"
 	"strings"
 
-	responses "BuhPro+/internal/delivery/http/response" // Для стандартизированного ответа на ошибку
+	responses "BruhPro/internal/delivery/http/response" // Для стандартизированного ответа на ошибку
+	"BruhPro/internal/utils"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
 )
 
-func JWTAuth(secret string) gin.HandlerFunc {
+// RevocationChecker проверяет denylist отозванных токенов (по jti) и сессий (по sid).
+type RevocationChecker interface {
+	IsRevoked(tokenIDs ...string) bool
+}
+
+// JWTAuth пропускает запрос только с действующим access token: подпись проверяется ключом
+// из заголовка kid, алгоритм должен совпадать с алгоритмом ключа, iss и aud — с настройками BuhPro.
+func JWTAuth(tokens *utils.JWTManager, revoked RevocationChecker) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
@@ -20,22 +27,75 @@ func JWTAuth(secret string) gin.HandlerFunc {
 
 		tokenStr := strings.TrimPrefix(authHeader, "Bearer ")
 
-		token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
-			return []byte(secret), nil
-		})
-		if err != nil || !token.Valid {
+		claims, err := tokens.ParseAccessToken(tokenStr)
+		if err != nil {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, responses.ErrorResponse{Error: "Invalid token"})
 			return
 		}
 
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok || claims["user_id"] == nil {
+		userID, ok := claims["user_id"].(string)
+		if !ok || userID == "" {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, responses.ErrorResponse{Error: "Invalid claims"})
 			return
 		}
 
-		// Сохраняем user_id в контекст запроса
-		c.Set("user_id", claims["user_id"].(string))
+		// Токены без роли (выпущенные до появления claim "role") больше не принимаются
+		role, ok := claims["role"].(string)
+		if !ok || role == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, responses.ErrorResponse{Error: "Invalid claims"})
+			return
+		}
+
+		jti, _ := claims["jti"].(string)
+		sessionID, _ := claims["sid"].(string)
+		if jti == "" || sessionID == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, responses.ErrorResponse{Error: "Invalid claims"})
+			return
+		}
+
+		if revoked.IsRevoked(jti, sessionID) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, responses.ErrorResponse{Error: "Token has been revoked"})
+			return
+		}
+
+		// Сохраняем user_id, role и сессию в контекст запроса
+		c.Set("user_id", userID)
+		c.Set("role", role)
+		c.Set("session_id", sessionID)
+		c.Set("jti", jti)
+		c.Set("mfa", claims["mfa"] == true)
+		c.Next()
+	}
+}
+
+// RequireRole пропускает запрос дальше, только если роль из токена входит в список разрешенных.
+// Должен подключаться после JWTAuth.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := c.GetString("role")
+		for _, allowed := range roles {
+			if role == allowed {
+				c.Next()
+				return
+			}
+		}
+
+		c.AbortWithStatusJSON(http.StatusForbidden, responses.ErrorResponse{Error: "Access denied for this role"})
+	}
+}
+
+// RequireMFA требует, чтобы сессия была открыта со вторым фактором, если роль токена
+// входит в enforcedRoles. Для остальных ролей двухфакторная аутентификация остается опциональной.
+// Маршруты подключения 2FA должны оставаться доступными без этого middleware.
+func RequireMFA(enforcedRoles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := c.GetString("role")
+		for _, enforced := range enforcedRoles {
+			if role == enforced && !c.GetBool("mfa") {
+				c.AbortWithStatusJSON(http.StatusForbidden, responses.ErrorResponse{Error: "Two-factor authentication required"})
+				return
+			}
+		}
 		c.Next()
 	}
 }
@@ -0,0 +1,142 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	responses "BruhPro/internal/delivery/http/response"
+	"BruhPro/internal/ratelimit"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// maxLoginBodySize ограничивает тело запроса входа, которое middleware читает в память.
+const maxLoginBodySize = 4 << 10
+
+// RateLimit ограничивает число запросов с одного IP. При превышении лимита
+// возвращает 429 с заголовком Retry-After.
+func RateLimit(limiter *ratelimit.Limiter, logger *logrus.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !allowIP(c, limiter, logger) {
+			return
+		}
+		c.Next()
+	}
+}
+
+// LoginProtection защищает эндпоинты входа от перебора паролей: ограничивает запросы
+// с одного IP и блокирует аккаунт (по email из тела запроса) после серии неудачных попыток.
+// Проверки выполняются до обработчика, поэтому заблокированный вход не доходит до bcrypt.
+// Неудачной попыткой считается ответ 401, успешной — 200 с выданными токенами
+// (обработчик ставит tokens_issued). Ответ с MFA-челленджем вход не завершает
+// и счетчик не сбрасывает.
+func LoginProtection(limiter *ratelimit.Limiter, lockout *ratelimit.Lockout, logger *logrus.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !allowIP(c, limiter, logger) {
+			return
+		}
+
+		account, err := loginEmail(c)
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, responses.ErrorResponse{Error: "request body is too large"})
+			return
+		}
+		if account == "" {
+			c.Next()
+			return
+		}
+
+		audit := logger.WithFields(logrus.Fields{
+			"audit":   true,
+			"ip":      c.ClientIP(),
+			"account": account,
+			"path":    c.FullPath(),
+		})
+
+		retryAfter, err := lockout.Check(account)
+		if err != nil {
+			audit.WithError(err).Error("Failed to check account lockout")
+		}
+		if retryAfter > 0 {
+			audit.Warn("Login attempt for locked account rejected")
+			tooManyRequests(c, retryAfter, "account is temporarily locked due to too many failed login attempts")
+			return
+		}
+
+		c.Next()
+
+		switch c.Writer.Status() {
+		case http.StatusOK:
+			if !c.GetBool("tokens_issued") {
+				return
+			}
+			if err := lockout.Success(account); err != nil {
+				audit.WithError(err).Error("Failed to reset login failures")
+			}
+		case http.StatusUnauthorized:
+			lockedFor, failures, err := lockout.Fail(account)
+			if err != nil {
+				audit.WithError(err).Error("Failed to record login failure")
+				return
+			}
+			entry := audit.WithField("failures", failures)
+			if lockedFor > 0 {
+				entry.WithField("locked_for", lockedFor.String()).Warn("Account locked after failed login attempts")
+			} else {
+				entry.Info("Failed login attempt")
+			}
+		}
+	}
+}
+
+// allowIP учитывает запрос в лимите IP; при превышении отправляет 429 и возвращает false.
+// Ошибка хранилища не блокирует вход: лимит пропускается с записью в лог.
+func allowIP(c *gin.Context, limiter *ratelimit.Limiter, logger *logrus.Logger) bool {
+	allowed, retryAfter, err := limiter.Allow(c.ClientIP())
+	if err != nil {
+		logger.WithError(err).Error("Rate limiter store failed")
+		return true
+	}
+	if !allowed {
+		logger.WithFields(logrus.Fields{
+			"audit": true,
+			"ip":    c.ClientIP(),
+			"path":  c.FullPath(),
+		}).Warn("Rate limit exceeded")
+		tooManyRequests(c, retryAfter, "too many requests, please try again later")
+		return false
+	}
+	return true
+}
+
+// loginEmail достает email из JSON-тела, не мешая обработчику прочитать тело повторно.
+// Тело длиннее maxLoginBodySize не читается целиком: возвращается *http.MaxBytesError.
+func loginEmail(c *gin.Context) (string, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxLoginBodySize))
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+
+	var req struct {
+		Email string `json:"email"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return "", nil
+	}
+	return strings.ToLower(strings.TrimSpace(req.Email)), nil
+}
+
+func tooManyRequests(c *gin.Context, retryAfter time.Duration, message string) {
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	c.AbortWithStatusJSON(http.StatusTooManyRequests, responses.ErrorResponse{Error: message})
+}
@@ -1,48 +1,127 @@
 package routes
 
 import (
-	"BuhPro+/internal/delivery/http/handlers"
+	"BruhPro/internal/delivery/gin/middleware"
+	"BruhPro/internal/delivery/http/handlers"
+	"BruhPro/internal/domain"
 
 	"github.com/gin-gonic/gin"
 )
 
+// AccountHandlers — обработчики, общие для всех ролей: сессии, пароль, подтверждение и смена email, 2FA.
+type AccountHandlers struct {
+	Session      *handlers.SessionHandler
+	Password     *handlers.PasswordHandler
+	Verification *handlers.VerificationHandler
+	EmailChange  *handlers.EmailChangeHandler
+	MFA          *handlers.MFAHandler
+}
+
+// Middlewares — middleware, общие для маршрутов всех ролей.
+type Middlewares struct {
+	Auth      gin.HandlerFunc // проверка access token
+	MFA       gin.HandlerFunc // обязательная 2FA для ролей из MFA_ENFORCED_ROLES
+	Login     gin.HandlerFunc // лимит по IP и блокировка аккаунта при переборе пароля
+	RateLimit gin.HandlerFunc // лимит по IP для второго шага входа и писем
+}
+
+// WellKnownRoutes публикует ключи проверки JWT для других сервисов.
+func WellKnownRoutes(router *gin.Engine, jwksHandler *handlers.JWKSHandler) {
+	router.GET("/.well-known/jwks.json", jwksHandler.JWKS)
+}
+
 // AuthRoutes настраивает маршруты для аутентификации обычного пользователя.
-func AuthRoutes(router *gin.Engine, authHandler *handlers.AuthHandler, authMiddleware gin.HandlerFunc) {
+// /me доступен с токеном любой роли и возвращает аккаунт со списком ролей.
+// mw.MFA закрывает данные аккаунта, если для роли 2FA обязательна, а вход был без нее;
+// подключить 2FA, выйти и управлять сессиями можно и без второго фактора.
+func AuthRoutes(router *gin.Engine, authHandler *handlers.AuthHandler, account AccountHandlers, mw Middlewares) {
 	router.POST("/register", authHandler.Register)
-	router.POST("/login", authHandler.Login)
+	router.POST("/login", mw.Login, authHandler.Login)
 	router.POST("/refresh", authHandler.Refresh)
-	router.GET("/me", authMiddleware, authHandler.GetProfile)
+
+	protected := router.Group("", mw.Auth)
+	{
+		protected.GET("/me", mw.MFA, authHandler.GetProfile)
+	}
+
+	accountRoutes(&router.RouterGroup, protected, account, mw, domain.RoleUser)
 }
 
 // CustomerAuthRoutes настраивает маршруты для аутентификации клиента.
-func CustomerAuthRoutes(router *gin.Engine, customerHandler *handlers.CustomerHandler, authMiddleware gin.HandlerFunc) {
+func CustomerAuthRoutes(router *gin.Engine, customerHandler *handlers.CustomerHandler, account AccountHandlers, mw Middlewares) {
 	customerGroup := router.Group("/customer")
 	{
 		customerGroup.POST("/register", customerHandler.RegisterCustomer)
-		customerGroup.POST("/login", customerHandler.LoginCustomer)
+		customerGroup.POST("/login", mw.Login, customerHandler.LoginCustomer)
 		customerGroup.POST("/refresh", customerHandler.RefreshCustomer)
-		customerGroup.GET("/me", authMiddleware, customerHandler.GetCustomerProfile)
 	}
+
+	protected := customerGroup.Group("", mw.Auth, middleware.RequireRole(domain.RoleCustomer))
+	{
+		protected.GET("/me", mw.MFA, customerHandler.GetCustomerProfile)
+		protected.PATCH("/me", mw.MFA, customerHandler.UpdateCustomerProfile)
+		protected.GET("/me/history", mw.MFA, customerHandler.GetCustomerProfileHistory)
+	}
+
+	accountRoutes(customerGroup, protected, account, mw, domain.RoleCustomer)
 }
 
 // CoachAuthRoutes настраивает маршруты для аутентификации коуча.
-func CoachAuthRoutes(router *gin.Engine, coachHandler *handlers.CoachHandler, authMiddleware gin.HandlerFunc) {
+func CoachAuthRoutes(router *gin.Engine, coachHandler *handlers.CoachHandler, account AccountHandlers, mw Middlewares) {
 	coachGroup := router.Group("/coach")
 	{
 		coachGroup.POST("/register", coachHandler.RegisterCoach)
-		coachGroup.POST("/login", coachHandler.LoginCoach)
+		coachGroup.POST("/login", mw.Login, coachHandler.LoginCoach)
 		coachGroup.POST("/refresh", coachHandler.RefreshCoach)
-		coachGroup.GET("/me", authMiddleware, coachHandler.GetCoachProfile)
 	}
+
+	protected := coachGroup.Group("", mw.Auth, middleware.RequireRole(domain.RoleCoach))
+	{
+		protected.GET("/me", mw.MFA, coachHandler.GetCoachProfile)
+		protected.PATCH("/me", mw.MFA, coachHandler.UpdateCoachProfile)
+		protected.GET("/me/history", mw.MFA, coachHandler.GetCoachProfileHistory)
+	}
+
+	accountRoutes(coachGroup, protected, account, mw, domain.RoleCoach)
 }
 
 // ExecutorAuthRoutes настраивает маршруты для аутентификации исполнителя.
-func ExecutorAuthRoutes(router *gin.Engine, executorHandler *handlers.ExecutorHandler, authMiddleware gin.HandlerFunc) {
+func ExecutorAuthRoutes(router *gin.Engine, executorHandler *handlers.ExecutorHandler, account AccountHandlers, mw Middlewares) {
 	executorGroup := router.Group("/executor")
 	{
 		executorGroup.POST("/register", executorHandler.RegisterExecutor)
-		executorGroup.POST("/login", executorHandler.LoginExecutor)
+		executorGroup.POST("/login", mw.Login, executorHandler.LoginExecutor)
 		executorGroup.POST("/refresh", executorHandler.RefreshExecutor)
-		executorGroup.GET("/me", authMiddleware, executorHandler.GetExecutorProfile)
 	}
+
+	protected := executorGroup.Group("", mw.Auth, middleware.RequireRole(domain.RoleExecutor))
+	{
+		protected.GET("/me", mw.MFA, executorHandler.GetExecutorProfile)
+		protected.PATCH("/me", mw.MFA, executorHandler.UpdateExecutorProfile)
+		protected.GET("/me/history", mw.MFA, executorHandler.GetExecutorProfileHistory)
+	}
+
+	accountRoutes(executorGroup, protected, account, mw, domain.RoleExecutor)
+}
+
+// accountRoutes добавляет маршруты, общие для всех ролей: восстановление пароля,
+// подтверждение email, ссылки из писем о смене email и второй шаг входа — в публичную группу,
+// выход, управление сессиями, смену пароля и email и подключение 2FA — в защищенную.
+func accountRoutes(public, protected *gin.RouterGroup, account AccountHandlers, mw Middlewares, role string) {
+	public.POST("/login/mfa", mw.RateLimit, account.MFA.CompleteLogin)
+	public.POST("/password/forgot", mw.RateLimit, account.Password.ForgotPassword(role))
+	public.POST("/password/reset", account.Password.ResetPassword(role))
+	public.POST("/email/verify", account.Verification.VerifyEmail)
+	public.POST("/email/change/confirm", account.EmailChange.ConfirmChange)
+	public.POST("/email/change/revert", account.EmailChange.RevertChange)
+
+	protected.POST("/email/verify/resend", mw.RateLimit, account.Verification.ResendVerification)
+	protected.POST("/logout", account.Session.Logout)
+	protected.GET("/sessions", account.Session.ListSessions)
+	protected.DELETE("/sessions/:id", account.Session.RevokeSession)
+	protected.POST("/me/password", mw.MFA, mw.RateLimit, account.Password.ChangePassword)
+	protected.POST("/me/email", mw.MFA, mw.RateLimit, account.EmailChange.RequestChange)
+	protected.POST("/mfa/enroll", mw.RateLimit, account.MFA.Enroll)
+	protected.POST("/mfa/enable", mw.RateLimit, account.MFA.Enable)
+	protected.POST("/mfa/disable", mw.RateLimit, account.MFA.Disable)
 }
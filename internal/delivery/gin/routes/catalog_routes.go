@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"BruhPro/internal/delivery/http/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExecutorRoutes настраивает публичный каталог исполнителей. Профиль самого исполнителя — в ExecutorAuthRoutes.
+func ExecutorRoutes(router *gin.Engine, executorHandler *handlers.ExecutorHandler) {
+	router.GET("/executors", executorHandler.SearchExecutors)
+}
+
+// CoachRoutes настраивает публичный каталог коучей. Профиль самого коуча — в CoachAuthRoutes.
+func CoachRoutes(router *gin.Engine, coachHandler *handlers.CoachHandler) {
+	router.GET("/coaches", coachHandler.SearchCoaches)
+}
@@ -0,0 +1,42 @@
+package routes
+
+import (
+	"BruhPro/internal/delivery/gin/middleware"
+	"BruhPro/internal/delivery/http/handlers"
+	"BruhPro/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CourseRoutes настраивает маршруты курсов. Каталог и карточки опубликованных курсов
+// публичны, курсы ведет коуч, записываются на них клиенты и исполнители.
+// Уроки доступны коучу курса и записавшимся с активной записью — это проверяет CourseUsecase.
+func CourseRoutes(router *gin.Engine, courseHandler *handlers.CourseHandler, mw Middlewares) {
+	router.GET("/courses", courseHandler.ListCourses)
+	router.GET("/courses/:id", courseHandler.GetCourse)
+
+	protected := router.Group("", mw.Auth, mw.MFA)
+	{
+		protected.GET("/courses/:id/lessons/:lesson_id", courseHandler.GetLesson)
+	}
+
+	coach := protected.Group("", middleware.RequireRole(domain.RoleCoach))
+	{
+		coach.GET("/courses/my", courseHandler.ListMyCourses)
+		coach.GET("/courses/my/:id", courseHandler.GetMyCourse)
+		coach.POST("/courses", courseHandler.CreateCourse)
+		coach.PUT("/courses/:id", courseHandler.UpdateCourse)
+		coach.POST("/courses/:id/publish", courseHandler.PublishCourse)
+		coach.POST("/courses/:id/unpublish", courseHandler.UnpublishCourse)
+		coach.POST("/courses/:id/modules", courseHandler.AddModule)
+		coach.POST("/courses/:id/modules/:module_id/lessons", courseHandler.AddLesson)
+	}
+
+	learner := protected.Group("", middleware.RequireRole(domain.RoleCustomer, domain.RoleExecutor))
+	{
+		learner.POST("/courses/:id/enroll", courseHandler.Enroll)
+		learner.GET("/enrollments/my", courseHandler.ListMyEnrollments)
+		learner.POST("/courses/:id/lessons/:lesson_id/complete", courseHandler.CompleteLesson)
+		learner.GET("/courses/:id/progress", courseHandler.GetProgress)
+	}
+}
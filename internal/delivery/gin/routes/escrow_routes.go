@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"BruhPro/internal/delivery/gin/middleware"
+	"BruhPro/internal/delivery/http/handlers"
+	"BruhPro/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EscrowRoutes настраивает маршруты этапов заказа. Этапы видят участники заказа;
+// создает, оплачивает и принимает этапы клиент, сдает — назначенный исполнитель.
+func EscrowRoutes(router *gin.Engine, escrowHandler *handlers.EscrowHandler, mw Middlewares) {
+	orders := router.Group("/orders/:id/milestones", mw.Auth, mw.MFA)
+	{
+		orders.GET("", escrowHandler.ListMilestones)
+	}
+
+	customer := orders.Group("", middleware.RequireRole(domain.RoleCustomer))
+	{
+		customer.POST("", escrowHandler.AddMilestone)
+		customer.POST("/:milestone_id/fund", escrowHandler.FundMilestone)
+		customer.POST("/:milestone_id/approve", escrowHandler.ApproveMilestone)
+		customer.POST("/:milestone_id/request-changes", escrowHandler.RequestMilestoneChanges)
+	}
+
+	executor := orders.Group("", middleware.RequireRole(domain.RoleExecutor))
+	{
+		executor.POST("/:milestone_id/submit", escrowHandler.SubmitMilestone)
+	}
+}
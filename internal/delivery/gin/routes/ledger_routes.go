@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"BruhPro/internal/delivery/gin/middleware"
+	"BruhPro/internal/delivery/http/handlers"
+	"BruhPro/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LedgerRoutes настраивает административные отчеты по журналу.
+// Код счета передается целиком, например /admin/ledger/accounts/escrow:<order_id>/balance.
+func LedgerRoutes(router *gin.Engine, ledgerHandler *handlers.LedgerHandler, mw Middlewares) {
+	admin := router.Group("/admin/ledger", mw.Auth, mw.MFA, middleware.RequireRole(domain.RoleAdmin))
+	{
+		admin.GET("/accounts/:account/balance", ledgerHandler.GetBalance)
+		admin.GET("/accounts/:account/entries", ledgerHandler.GetStatement)
+		admin.GET("/reconciliation", ledgerHandler.Reconcile)
+	}
+}
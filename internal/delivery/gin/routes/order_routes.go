@@ -0,0 +1,35 @@
+package routes
+
+import (
+	"BruhPro/internal/delivery/gin/middleware"
+	"BruhPro/internal/delivery/http/handlers"
+	"BruhPro/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OrderRoutes настраивает маршруты заказов. Смотреть опубликованные заказы может любой
+// авторизованный пользователь, создавать и редактировать — только клиент свои заказы.
+// Статус меняют участники заказа; какие переходы кому доступны, решает OrderUsecase.
+func OrderRoutes(router *gin.Engine, orderHandler *handlers.OrderHandler, mw Middlewares) {
+	orders := router.Group("/orders", mw.Auth, mw.MFA)
+	{
+		orders.GET("", orderHandler.ListOrders)
+		orders.GET("/:id", orderHandler.GetOrder)
+		orders.GET("/:id/history", orderHandler.GetOrderHistory)
+		orders.POST("/:id/transitions", orderHandler.TransitionOrder)
+	}
+
+	executor := orders.Group("", middleware.RequireRole(domain.RoleExecutor))
+	{
+		executor.GET("/assigned", orderHandler.ListAssignedOrders)
+	}
+
+	customer := orders.Group("", middleware.RequireRole(domain.RoleCustomer))
+	{
+		customer.GET("/my", orderHandler.ListMyOrders)
+		customer.POST("", orderHandler.CreateOrder)
+		customer.PUT("/:id", orderHandler.UpdateOrder)
+		customer.DELETE("/:id", orderHandler.DeleteOrder)
+	}
+}
@@ -0,0 +1,38 @@
+package routes
+
+import (
+	"BruhPro/internal/delivery/gin/middleware"
+	"BruhPro/internal/delivery/http/handlers"
+	"BruhPro/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PaymentRoutes настраивает маршруты платежей. Вебхук провайдера публичный —
+// его подлинность проверяется подписью. Курс оплачивает записавшийся клиент или
+// исполнитель, возврат оформляет администратор. Этапы заказа оплачиваются в EscrowRoutes.
+func PaymentRoutes(router *gin.Engine, paymentHandler *handlers.PaymentHandler, mw Middlewares) {
+	router.POST("/payments/webhook", paymentHandler.Webhook)
+
+	protected := router.Group("", mw.Auth, mw.MFA)
+	{
+		protected.GET("/payments/my", paymentHandler.ListMyPayments)
+		protected.GET("/payments/:id", paymentHandler.GetPayment)
+	}
+
+	learner := protected.Group("", middleware.RequireRole(domain.RoleCustomer, domain.RoleExecutor))
+	{
+		learner.POST("/courses/:id/payments", paymentHandler.PayCourse)
+	}
+
+	admin := protected.Group("", middleware.RequireRole(domain.RoleAdmin))
+	{
+		admin.POST("/payments/:id/refund", paymentHandler.RefundPayment)
+	}
+}
+
+// FakeCheckoutRoutes подключает страницу оплаты локального провайдера.
+// Регистрируется только при PAYMENT_PROVIDER=fake.
+func FakeCheckoutRoutes(router *gin.Engine, checkoutHandler *handlers.FakeCheckoutHandler) {
+	router.POST("/payments/fake/:charge_id", checkoutHandler.Checkout)
+}
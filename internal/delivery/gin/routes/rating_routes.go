@@ -0,0 +1,33 @@
+package routes
+
+import (
+	"BruhPro/internal/delivery/gin/middleware"
+	"BruhPro/internal/delivery/http/handlers"
+	"BruhPro/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RatingRoutes настраивает маршруты оценок. Отзывы об исполнителях и коучах публичны.
+// Клиент оценивает свой завершенный заказ, клиент или исполнитель — курс, на котором учится;
+// отвечает на отзыв оцененный исполнитель или коуч.
+func RatingRoutes(router *gin.Engine, ratingHandler *handlers.RatingHandler, mw Middlewares) {
+	router.GET("/executors/:id/ratings", ratingHandler.ListExecutorRatings)
+	router.GET("/coaches/:id/ratings", ratingHandler.ListCoachRatings)
+
+	customer := router.Group("", mw.Auth, mw.MFA, middleware.RequireRole(domain.RoleCustomer))
+	{
+		customer.POST("/orders/:id/rating", ratingHandler.RateOrder)
+	}
+
+	author := router.Group("", mw.Auth, mw.MFA, middleware.RequireRole(domain.RoleCustomer, domain.RoleExecutor))
+	{
+		author.POST("/courses/:id/rating", ratingHandler.RateCourse)
+		author.PUT("/ratings/:id", ratingHandler.UpdateRating)
+	}
+
+	target := router.Group("", mw.Auth, mw.MFA, middleware.RequireRole(domain.RoleExecutor, domain.RoleCoach))
+	{
+		target.POST("/ratings/:id/reply", ratingHandler.ReplyToRating)
+	}
+}
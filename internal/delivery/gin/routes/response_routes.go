@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"BruhPro/internal/delivery/gin/middleware"
+	"BruhPro/internal/delivery/http/handlers"
+	"BruhPro/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ResponseRoutes настраивает маршруты откликов: исполнитель откликается на заказ,
+// клиент просматривает отклики на свой заказ и выбирает исполнителя.
+func ResponseRoutes(router *gin.Engine, responseHandler *handlers.ResponseHandler, mw Middlewares) {
+	executor := router.Group("", mw.Auth, mw.MFA, middleware.RequireRole(domain.RoleExecutor))
+	{
+		executor.POST("/orders/:id/responses", responseHandler.CreateResponse)
+		executor.GET("/responses/my", responseHandler.ListMyResponses)
+	}
+
+	customer := router.Group("", mw.Auth, mw.MFA, middleware.RequireRole(domain.RoleCustomer))
+	{
+		customer.GET("/orders/:id/responses", responseHandler.ListOrderResponses)
+		customer.POST("/responses/:id/shortlist", responseHandler.ShortlistResponse)
+		customer.POST("/responses/:id/reject", responseHandler.RejectResponse)
+		customer.POST("/responses/:id/accept", responseHandler.AcceptResponse)
+	}
+}
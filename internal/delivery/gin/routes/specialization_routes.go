@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"BruhPro/internal/delivery/gin/middleware"
+	"BruhPro/internal/delivery/http/handlers"
+	"BruhPro/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SpecializationRoutes настраивает справочник специализаций: чтение публично, изменение — только администратору.
+func SpecializationRoutes(router *gin.Engine, specializationHandler *handlers.SpecializationHandler, mw Middlewares) {
+	router.GET("/specializations", specializationHandler.ListSpecializations)
+
+	admin := router.Group("/admin/specializations", mw.Auth, mw.MFA, middleware.RequireRole(domain.RoleAdmin))
+	{
+		admin.GET("", specializationHandler.ListAllSpecializations)
+		admin.POST("", specializationHandler.CreateSpecialization)
+		admin.PUT("/:code", specializationHandler.UpdateSpecialization)
+		admin.DELETE("/:code", specializationHandler.DeleteSpecialization)
+	}
+}
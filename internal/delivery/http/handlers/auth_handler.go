@@ -3,10 +3,11 @@ package handlers
 import (
 	"net/http"
 
-	"BuhPro+/internal/delivery/http/requests"           // Обновлен импорт
-	responses "BuhPro+/internal/delivery/http/response" // Обновлен импорт
-	"BuhPro+/internal/usecase"                          // Обновлен импорт
-	"BuhPro+/internal/utils"                            // Обновлен импорт
+	"BruhPro/internal/delivery/http/requests"           // Обновлен импорт
+	responses "BruhPro/internal/delivery/http/response" // Обновлен импорт
+	"BruhPro/internal/domain"
+	"BruhPro/internal/usecase" // Обновлен импорт
+	"BruhPro/internal/utils"   // Обновлен импорт
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -22,7 +23,7 @@ type AuthHandler struct {
 func NewAuthHandler(u *usecase.AuthUsecase, logger *logrus.Logger) *AuthHandler {
 	return &AuthHandler{
 		usecase:  u,
-		validate: validator.New(),
+		validate: utils.NewValidator(),
 		logger:   logger,
 	}
 }
@@ -58,7 +59,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 }
 
 func (h *AuthHandler) Login(c *gin.Context) {
-	var req requests.AuthRequest
+	var req requests.LoginRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.logger.WithError(err).Error("Invalid request format")
@@ -73,7 +74,12 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	accessToken, refreshToken, err := h.usecase.Login(req.Email, req.Password)
+	role := req.Role
+	if role == "" {
+		role = domain.RoleUser
+	}
+
+	tokens, err := h.usecase.Login(req.Email, req.Password, role, clientInfo(c))
 	if err != nil {
 		h.logger.WithError(err).Warn("Login failed")
 		c.JSON(http.StatusUnauthorized, responses.ErrorResponse{Error: err.Error()})
@@ -81,10 +87,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	}
 
 	h.logger.Info("User logged in successfully")
-	c.JSON(http.StatusOK, responses.LoginResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-	})
+	writeLoginResponse(c, tokens)
 }
 
 func (h *AuthHandler) Refresh(c *gin.Context) {
@@ -103,7 +106,7 @@ func (h *AuthHandler) Refresh(c *gin.Context) {
 		return
 	}
 
-	accessToken, err := h.usecase.RefreshToken(req.RefreshToken)
+	tokens, err := h.usecase.Refresh(req.RefreshToken, clientInfo(c))
 	if err != nil {
 		h.logger.WithError(err).Warn("Token refresh failed")
 		c.JSON(http.StatusUnauthorized, responses.ErrorResponse{Error: err.Error()})
@@ -112,7 +115,8 @@ func (h *AuthHandler) Refresh(c *gin.Context) {
 
 	h.logger.Info("Token refreshed successfully")
 	c.JSON(http.StatusOK, responses.TokenRefreshResponse{
-		AccessToken: accessToken,
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
 	})
 }
 
@@ -124,7 +128,7 @@ func (h *AuthHandler) GetProfile(c *gin.Context) {
 		return
 	}
 
-	user, err := h.usecase.GetUserByID(userID.(string))
+	account, roles, err := h.usecase.GetAccountByID(userID.(string))
 	if err != nil {
 		h.logger.WithError(err).Warn("User not found")
 		c.JSON(http.StatusNotFound, responses.ErrorResponse{Error: "User not found"})
@@ -133,7 +137,9 @@ func (h *AuthHandler) GetProfile(c *gin.Context) {
 
 	h.logger.Info("User profile retrieved successfully")
 	c.JSON(http.StatusOK, responses.UserProfileResponse{
-		ID:    user.ID,
-		Email: user.Email,
+		ID:            account.ID,
+		Email:         account.Email,
+		EmailVerified: account.EmailVerifiedAt != nil,
+		Roles:         roles,
 	})
 }
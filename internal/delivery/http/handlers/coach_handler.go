@@ -3,11 +3,12 @@ package handlers
 import (
 	"net/http"
 
-	"BuhPro+/internal/delivery/http/requests"
-	responses "BuhPro+/internal/delivery/http/response"
-	"BuhPro+/internal/domain"
-	"BuhPro+/internal/usecase"
-	"BuhPro+/internal/utils"
+	"BruhPro/internal/delivery/http/requests"
+	responses "BruhPro/internal/delivery/http/response"
+	"BruhPro/internal/domain"
+	"BruhPro/internal/repository"
+	"BruhPro/internal/usecase"
+	"BruhPro/internal/utils"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -23,7 +24,7 @@ type CoachHandler struct {
 func NewCoachHandler(u *usecase.CoachUsecase, logger *logrus.Logger) *CoachHandler {
 	return &CoachHandler{
 		usecase:  u,
-		validate: validator.New(),
+		validate: utils.NewValidator(),
 		logger:   logger,
 	}
 }
@@ -45,23 +46,21 @@ func (h *CoachHandler) RegisterCoach(c *gin.Context) {
 	}
 
 	coach := &domain.Coach{
-		Email:                  req.Email,
-		PasswordHash:           req.Password, // Пароль будет хеширован в usecase
 		Name:                   req.Name,
 		Surname:                req.Surname,
 		PhoneNumber:            req.PhoneNumber,
 		ExpCoach:               req.ExpCoach,
-		Specializations:        req.Specializations,
+		Specializations:        specializationsFromCodes(req.Specializations),
 		EducationCertificates:  req.EducationCertificates,
 		AchievementsExperience: req.AchievementsExperience,
 		Methodology:            req.Methodology,
 		AboutCoach:             req.AboutCoach,
 	}
 
-	err := h.usecase.RegisterCoach(coach)
+	err := h.usecase.RegisterCoach(req.Email, req.Password, coach)
 	if err != nil {
 		h.logger.WithError(err).Warn("Coach registration failed")
-		c.JSON(http.StatusConflict, responses.ErrorResponse{Error: err.Error()})
+		c.JSON(registrationErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
 		return
 	}
 
@@ -88,7 +87,7 @@ func (h *CoachHandler) LoginCoach(c *gin.Context) {
 		return
 	}
 
-	accessToken, refreshToken, err := h.usecase.LoginCoach(req.Email, req.Password)
+	tokens, err := h.usecase.LoginCoach(req.Email, req.Password, clientInfo(c))
 	if err != nil {
 		h.logger.WithError(err).Warn("Coach login failed")
 		c.JSON(http.StatusUnauthorized, responses.ErrorResponse{Error: err.Error()})
@@ -96,10 +95,7 @@ func (h *CoachHandler) LoginCoach(c *gin.Context) {
 	}
 
 	h.logger.Info("Coach logged in successfully")
-	c.JSON(http.StatusOK, responses.LoginResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-	})
+	writeLoginResponse(c, tokens)
 }
 
 func (h *CoachHandler) RefreshCoach(c *gin.Context) {
@@ -118,7 +114,7 @@ func (h *CoachHandler) RefreshCoach(c *gin.Context) {
 		return
 	}
 
-	accessToken, err := h.usecase.RefreshCoachToken(req.RefreshToken)
+	tokens, err := h.usecase.RefreshCoachToken(req.RefreshToken, clientInfo(c))
 	if err != nil {
 		h.logger.WithError(err).Warn("Coach token refresh failed")
 		c.JSON(http.StatusUnauthorized, responses.ErrorResponse{Error: err.Error()})
@@ -127,7 +123,8 @@ func (h *CoachHandler) RefreshCoach(c *gin.Context) {
 
 	h.logger.Info("Coach token refreshed successfully")
 	c.JSON(http.StatusOK, responses.TokenRefreshResponse{
-		AccessToken: accessToken,
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
 	})
 }
 
@@ -147,17 +144,134 @@ func (h *CoachHandler) GetCoachProfile(c *gin.Context) {
 	}
 
 	h.logger.Info("Coach profile retrieved successfully")
-	c.JSON(http.StatusOK, responses.CoachProfileResponse{
+	c.JSON(http.StatusOK, coachProfileResponse(coach))
+}
+
+// UpdateCoachProfile частично изменяет профиль коуча.
+func (h *CoachHandler) UpdateCoachProfile(c *gin.Context) {
+	var req requests.UpdateCoachProfileRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.WithError(err).Error("Invalid request format for coach profile update")
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "invalid request format"})
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		h.logger.WithError(err).Warn("Validation failed for coach profile update")
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "validation failed", Details: utils.CustomValidationErrors(validationErrors)})
+		return
+	}
+
+	coach, err := h.usecase.UpdateCoachProfile(c.GetString("user_id"), req.Version, usecase.CoachPatch{
+		Name:                   req.Name,
+		Surname:                req.Surname,
+		PhoneNumber:            req.PhoneNumber,
+		ExpCoach:               req.ExpCoach,
+		Specializations:        req.Specializations,
+		EducationCertificates:  req.EducationCertificates,
+		AchievementsExperience: req.AchievementsExperience,
+		Methodology:            req.Methodology,
+		AboutCoach:             req.AboutCoach,
+	})
+	if err != nil {
+		h.logger.WithError(err).Warn("Coach profile update failed")
+		c.JSON(profileErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	h.logger.Info("Coach profile updated successfully")
+	c.JSON(http.StatusOK, coachProfileResponse(coach))
+}
+
+// GetCoachProfileHistory возвращает историю изменений профиля коуча.
+func (h *CoachHandler) GetCoachProfileHistory(c *gin.Context) {
+	var query requests.PageQuery
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.logger.WithError(err).Error("Invalid query for coach profile history")
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "invalid request format"})
+		return
+	}
+
+	changes, err := h.usecase.ListCoachProfileChanges(c.GetString("user_id"), query.Limit, query.Offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, responses.ErrorResponse{Error: "failed to list profile changes"})
+		return
+	}
+	c.JSON(http.StatusOK, profileChangeResponses(changes))
+}
+
+func coachProfileResponse(coach *domain.Coach) responses.CoachProfileResponse {
+	return responses.CoachProfileResponse{
 		ID:                     coach.ID,
 		Name:                   coach.Name,
 		Surname:                coach.Surname,
-		PhoneNumber:            coach.PhoneNumber,
+		PhoneNumber:            coach.PhoneNumber.String(),
+		PhoneFormatted:         coach.PhoneNumber.Format(),
 		Email:                  coach.Email,
+		EmailVerified:          coach.EmailVerifiedAt != nil,
 		ExpCoach:               coach.ExpCoach,
-		Specializations:        coach.Specializations,
+		Specializations:        specializationResponses(coach.Specializations),
 		EducationCertificates:  coach.EducationCertificates,
 		AchievementsExperience: coach.AchievementsExperience,
 		Methodology:            coach.Methodology,
 		AboutCoach:             coach.AboutCoach,
-	})
+		Version:                coach.Version,
+		UpdatedAt:              coach.UpdatedAt,
+		Rating:                 ratingSummaryResponse(coach.Rating),
+	}
+}
+
+// SearchCoaches возвращает страницу публичного поиска коучей.
+func (h *CoachHandler) SearchCoaches(c *gin.Context) {
+	var query requests.SearchCoachesQuery
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.logger.WithError(err).Error("Invalid query for coach search")
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "invalid request format"})
+		return
+	}
+
+	if err := h.validate.Struct(query); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		h.logger.WithError(err).Warn("Validation failed for coach search")
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "validation failed", Details: utils.CustomValidationErrors(validationErrors)})
+		return
+	}
+
+	coaches, next, err := h.usecase.SearchCoaches(repository.CoachFilter{
+		Query:          query.Q,
+		Specialization: query.Specialization,
+		Sort:           query.Sort,
+		Limit:          query.Limit,
+	}, query.Cursor)
+	if err != nil {
+		c.JSON(searchErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	items := make([]responses.CoachPublicResponse, 0, len(coaches))
+	for i := range coaches {
+		coach := &coaches[i]
+		item := responses.CoachPublicResponse{
+			ID:                     coach.ID,
+			Name:                   coach.Name,
+			Surname:                coach.Surname,
+			ExpCoach:               coach.ExpCoach,
+			Specializations:        specializationResponses(coach.Specializations),
+			EducationCertificates:  coach.EducationCertificates,
+			AchievementsExperience: coach.AchievementsExperience,
+			Methodology:            coach.Methodology,
+			AboutCoach:             coach.AboutCoach,
+			CreatedAt:              coach.CreatedAt,
+			Rating:                 ratingSummaryResponse(coach.Rating),
+		}
+		if query.Q != "" {
+			item.Search = searchMatchResponse(coach.SearchRank, coach.SearchSnippet)
+		}
+		items = append(items, item)
+	}
+	c.JSON(http.StatusOK, responses.CoachSearchResponse{Items: items, NextCursor: next})
 }
@@ -0,0 +1,392 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"BruhPro/internal/delivery/http/requests"
+	responses "BruhPro/internal/delivery/http/response"
+	"BruhPro/internal/domain"
+	"BruhPro/internal/repository"
+	"BruhPro/internal/usecase"
+	"BruhPro/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+// CourseHandler обслуживает курсы коучей, запись на них и прогресс.
+type CourseHandler struct {
+	usecase  *usecase.CourseUsecase
+	validate *validator.Validate
+	logger   *logrus.Logger
+}
+
+func NewCourseHandler(u *usecase.CourseUsecase, logger *logrus.Logger) *CourseHandler {
+	return &CourseHandler{
+		usecase:  u,
+		validate: utils.NewValidator(),
+		logger:   logger,
+	}
+}
+
+func (h *CourseHandler) CreateCourse(c *gin.Context) {
+	var req requests.CourseRequest
+	if !h.bind(c, &req, "course creation") {
+		return
+	}
+
+	course := courseFromRequest(req)
+	if err := h.usecase.CreateCourse(c.GetString("user_id"), course); err != nil {
+		h.logger.WithError(err).Warn("Course creation failed")
+		c.JSON(courseErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	h.logger.Info("Course created successfully")
+	c.JSON(http.StatusCreated, courseResponse(course))
+}
+
+func (h *CourseHandler) UpdateCourse(c *gin.Context) {
+	var req requests.CourseRequest
+	if !h.bind(c, &req, "course update") {
+		return
+	}
+
+	course, err := h.usecase.UpdateCourse(c.GetString("user_id"), c.Param("id"), courseFromRequest(req))
+	if err != nil {
+		h.logger.WithError(err).Warn("Course update failed")
+		c.JSON(courseErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	h.logger.Info("Course updated successfully")
+	c.JSON(http.StatusOK, courseResponse(course))
+}
+
+func (h *CourseHandler) PublishCourse(c *gin.Context) {
+	course, err := h.usecase.Publish(c.GetString("user_id"), c.Param("id"))
+	if err != nil {
+		h.logger.WithError(err).Warn("Course publication failed")
+		c.JSON(courseErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, courseResponse(course))
+}
+
+func (h *CourseHandler) UnpublishCourse(c *gin.Context) {
+	course, err := h.usecase.Unpublish(c.GetString("user_id"), c.Param("id"))
+	if err != nil {
+		h.logger.WithError(err).Warn("Course unpublication failed")
+		c.JSON(courseErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, courseResponse(course))
+}
+
+func (h *CourseHandler) AddModule(c *gin.Context) {
+	var req requests.CourseModuleRequest
+	if !h.bind(c, &req, "course module creation") {
+		return
+	}
+
+	module := &domain.CourseModule{Title: req.Title, Position: req.Position}
+	if err := h.usecase.AddModule(c.GetString("user_id"), c.Param("id"), module); err != nil {
+		h.logger.WithError(err).Warn("Course module creation failed")
+		c.JSON(courseErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, moduleResponse(module))
+}
+
+func (h *CourseHandler) AddLesson(c *gin.Context) {
+	var req requests.LessonRequest
+	if !h.bind(c, &req, "lesson creation") {
+		return
+	}
+
+	lesson := &domain.Lesson{
+		Title:    req.Title,
+		Content:  req.Content,
+		VideoURL: req.VideoURL,
+		Position: req.Position,
+	}
+	for _, a := range req.Attachments {
+		lesson.Attachments = append(lesson.Attachments, domain.LessonAttachment{FileName: a.FileName, URL: a.URL})
+	}
+
+	if err := h.usecase.AddLesson(c.GetString("user_id"), c.Param("id"), c.Param("module_id"), lesson); err != nil {
+		h.logger.WithError(err).Warn("Lesson creation failed")
+		c.JSON(courseErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, lessonResponse(lesson))
+}
+
+// ListCourses возвращает каталог опубликованных курсов.
+func (h *CourseHandler) ListCourses(c *gin.Context) {
+	filter, ok := h.bindListQuery(c)
+	if !ok {
+		return
+	}
+
+	courses, err := h.usecase.ListCatalog(filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, responses.ErrorResponse{Error: "failed to list courses"})
+		return
+	}
+
+	c.JSON(http.StatusOK, courseResponses(courses))
+}
+
+// ListMyCourses возвращает курсы текущего коуча, включая черновики.
+func (h *CourseHandler) ListMyCourses(c *gin.Context) {
+	filter, ok := h.bindListQuery(c)
+	if !ok {
+		return
+	}
+
+	courses, err := h.usecase.ListByCoach(c.GetString("user_id"), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, responses.ErrorResponse{Error: "failed to list courses"})
+		return
+	}
+
+	c.JSON(http.StatusOK, courseResponses(courses))
+}
+
+// GetCourse возвращает карточку опубликованного курса с программой.
+func (h *CourseHandler) GetCourse(c *gin.Context) {
+	course, err := h.usecase.GetCourse("", c.Param("id"))
+	if err != nil {
+		c.JSON(courseErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, courseResponse(course))
+}
+
+// GetMyCourse возвращает курс текущего коуча, в том числе черновик.
+func (h *CourseHandler) GetMyCourse(c *gin.Context) {
+	course, err := h.usecase.GetCourse(c.GetString("user_id"), c.Param("id"))
+	if err != nil {
+		c.JSON(courseErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, courseResponse(course))
+}
+
+func (h *CourseHandler) GetLesson(c *gin.Context) {
+	lesson, err := h.usecase.GetLesson(c.GetString("user_id"), c.Param("id"), c.Param("lesson_id"))
+	if err != nil {
+		h.logger.WithError(err).Warn("Lesson not available")
+		c.JSON(courseErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, lessonResponse(lesson))
+}
+
+func (h *CourseHandler) Enroll(c *gin.Context) {
+	enrollment, err := h.usecase.Enroll(c.GetString("user_id"), c.GetString("role"), c.Param("id"))
+	if err != nil {
+		h.logger.WithError(err).Warn("Course enrollment failed")
+		c.JSON(courseErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	h.logger.Info("Enrolled in course successfully")
+	c.JSON(http.StatusCreated, enrollmentResponse(enrollment))
+}
+
+func (h *CourseHandler) ListMyEnrollments(c *gin.Context) {
+	enrollments, err := h.usecase.ListEnrollments(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, responses.ErrorResponse{Error: "failed to list enrollments"})
+		return
+	}
+
+	result := make([]responses.EnrollmentResponse, 0, len(enrollments))
+	for i := range enrollments {
+		result = append(result, enrollmentResponse(&enrollments[i]))
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *CourseHandler) CompleteLesson(c *gin.Context) {
+	if err := h.usecase.CompleteLesson(c.GetString("user_id"), c.Param("id"), c.Param("lesson_id")); err != nil {
+		h.logger.WithError(err).Warn("Lesson completion failed")
+		c.JSON(courseErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.AuthSuccessResponse{
+		Status:  "success",
+		Message: "lesson marked as completed",
+	})
+}
+
+func (h *CourseHandler) GetProgress(c *gin.Context) {
+	progress, err := h.usecase.Progress(c.GetString("user_id"), c.Param("id"))
+	if err != nil {
+		c.JSON(courseErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	completed := make([]string, 0, len(progress.CompletedLessons))
+	for _, p := range progress.CompletedLessons {
+		completed = append(completed, p.LessonID)
+	}
+
+	percent := 0
+	if progress.TotalLessons > 0 {
+		percent = len(completed) * 100 / progress.TotalLessons
+	}
+
+	c.JSON(http.StatusOK, responses.CourseProgressResponse{
+		Enrollment:       enrollmentResponse(progress.Enrollment),
+		CompletedLessons: completed,
+		TotalLessons:     progress.TotalLessons,
+		Percent:          percent,
+	})
+}
+
+// bind разбирает и валидирует тело запроса; при ошибке ответ уже отправлен.
+func (h *CourseHandler) bind(c *gin.Context, req interface{}, action string) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		h.logger.WithError(err).Errorf("Invalid request format for %s", action)
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "invalid request format"})
+		return false
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		h.logger.WithError(err).Warnf("Validation failed for %s", action)
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "validation failed", Details: utils.CustomValidationErrors(validationErrors)})
+		return false
+	}
+	return true
+}
+
+func (h *CourseHandler) bindListQuery(c *gin.Context) (repository.CourseFilter, bool) {
+	var query requests.ListCoursesQuery
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.logger.WithError(err).Error("Invalid query for course list")
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "invalid request format"})
+		return repository.CourseFilter{}, false
+	}
+
+	if err := h.validate.Struct(query); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		h.logger.WithError(err).Warn("Validation failed for course list")
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "validation failed", Details: utils.CustomValidationErrors(validationErrors)})
+		return repository.CourseFilter{}, false
+	}
+
+	return repository.CourseFilter{
+		CoachID:        query.CoachID,
+		Specialization: query.Specialization,
+		Limit:          query.Limit,
+		Offset:         query.Offset,
+	}, true
+}
+
+func courseErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, usecase.ErrCourseNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, usecase.ErrCourseForbidden), errors.Is(err, usecase.ErrNotEnrolled):
+		return http.StatusForbidden
+	case errors.Is(err, usecase.ErrCourseNotPaid):
+		return http.StatusPaymentRequired
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+func courseFromRequest(req requests.CourseRequest) *domain.Course {
+	return &domain.Course{
+		Title:          req.Title,
+		Description:    req.Description,
+		Specialization: req.Specialization,
+		Price:          req.Price,
+	}
+}
+
+func courseResponse(course *domain.Course) responses.CourseResponse {
+	resp := responses.CourseResponse{
+		ID:             course.ID,
+		CoachID:        course.CoachID,
+		Title:          course.Title,
+		Description:    course.Description,
+		Specialization: course.Specialization,
+		Price:          course.Price,
+		Status:         course.Status,
+		PublishedAt:    course.PublishedAt,
+		CreatedAt:      course.CreatedAt,
+		UpdatedAt:      course.UpdatedAt,
+	}
+	for i := range course.Modules {
+		resp.Modules = append(resp.Modules, moduleResponse(&course.Modules[i]))
+	}
+	return resp
+}
+
+func courseResponses(courses []domain.Course) []responses.CourseResponse {
+	result := make([]responses.CourseResponse, 0, len(courses))
+	for i := range courses {
+		result = append(result, courseResponse(&courses[i]))
+	}
+	return result
+}
+
+func moduleResponse(module *domain.CourseModule) responses.CourseModuleResponse {
+	resp := responses.CourseModuleResponse{
+		ID:       module.ID,
+		Title:    module.Title,
+		Position: module.Position,
+		Lessons:  make([]responses.LessonSummaryResponse, 0, len(module.Lessons)),
+	}
+	for _, lesson := range module.Lessons {
+		resp.Lessons = append(resp.Lessons, responses.LessonSummaryResponse{
+			ID:       lesson.ID,
+			Title:    lesson.Title,
+			Position: lesson.Position,
+			HasVideo: lesson.VideoURL != "",
+		})
+	}
+	return resp
+}
+
+func lessonResponse(lesson *domain.Lesson) responses.LessonResponse {
+	resp := responses.LessonResponse{
+		ID:          lesson.ID,
+		ModuleID:    lesson.ModuleID,
+		CourseID:    lesson.CourseID,
+		Title:       lesson.Title,
+		Content:     lesson.Content,
+		VideoURL:    lesson.VideoURL,
+		Position:    lesson.Position,
+		Attachments: make([]responses.LessonAttachmentResponse, 0, len(lesson.Attachments)),
+	}
+	for _, a := range lesson.Attachments {
+		resp.Attachments = append(resp.Attachments, responses.LessonAttachmentResponse{FileName: a.FileName, URL: a.URL})
+	}
+	return resp
+}
+
+func enrollmentResponse(enrollment *domain.CourseEnrollment) responses.EnrollmentResponse {
+	return responses.EnrollmentResponse{
+		ID:        enrollment.ID,
+		CourseID:  enrollment.CourseID,
+		Status:    enrollment.Status,
+		CreatedAt: enrollment.CreatedAt,
+	}
+}
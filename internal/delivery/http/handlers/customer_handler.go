@@ -3,11 +3,11 @@ package handlers
 import (
 	"net/http"
 
-	"BuhPro+/internal/delivery/http/requests"
-	responses "BuhPro+/internal/delivery/http/response"
-	"BuhPro+/internal/domain" // Добавлено для создания полной модели
-	"BuhPro+/internal/usecase"
-	"BuhPro+/internal/utils"
+	"BruhPro/internal/delivery/http/requests"
+	responses "BruhPro/internal/delivery/http/response"
+	"BruhPro/internal/domain" // Добавлено для создания полной модели
+	"BruhPro/internal/usecase"
+	"BruhPro/internal/utils"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -23,7 +23,7 @@ type CustomerHandler struct {
 func NewCustomerHandler(u *usecase.CustomerUsecase, logger *logrus.Logger) *CustomerHandler {
 	return &CustomerHandler{
 		usecase:  u,
-		validate: validator.New(),
+		validate: utils.NewValidator(),
 		logger:   logger,
 	}
 }
@@ -46,8 +46,6 @@ func (h *CustomerHandler) RegisterCustomer(c *gin.Context) {
 
 	// Здесь мы передаем все поля в usecase
 	customer := &domain.Customer{
-		Email:           req.Email,
-		PasswordHash:    req.Password, // Пароль будет хеширован в usecase
 		ClientType:      req.ClientType,
 		CompanyName:     req.CompanyName,
 		IIN:             req.IIN,
@@ -58,7 +56,7 @@ func (h *CustomerHandler) RegisterCustomer(c *gin.Context) {
 		WorkDescription: req.WorkDescription,
 	}
 
-	err := h.usecase.RegisterCustomer(customer) // Передаем полную модель Customer
+	err := h.usecase.RegisterCustomer(req.Email, req.Password, customer) // Пароль будет хеширован в usecase
 	if err != nil {
 		h.logger.WithError(err).Warn("Customer registration failed")
 		c.JSON(http.StatusConflict, responses.ErrorResponse{Error: err.Error()})
@@ -88,7 +86,7 @@ func (h *CustomerHandler) LoginCustomer(c *gin.Context) {
 		return
 	}
 
-	accessToken, refreshToken, err := h.usecase.LoginCustomer(req.Email, req.Password)
+	tokens, err := h.usecase.LoginCustomer(req.Email, req.Password, clientInfo(c))
 	if err != nil {
 		h.logger.WithError(err).Warn("Customer login failed")
 		c.JSON(http.StatusUnauthorized, responses.ErrorResponse{Error: err.Error()})
@@ -96,10 +94,7 @@ func (h *CustomerHandler) LoginCustomer(c *gin.Context) {
 	}
 
 	h.logger.Info("Customer logged in successfully")
-	c.JSON(http.StatusOK, responses.LoginResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-	})
+	writeLoginResponse(c, tokens)
 }
 
 func (h *CustomerHandler) RefreshCustomer(c *gin.Context) {
@@ -118,7 +113,7 @@ func (h *CustomerHandler) RefreshCustomer(c *gin.Context) {
 		return
 	}
 
-	accessToken, err := h.usecase.RefreshCustomerToken(req.RefreshToken)
+	tokens, err := h.usecase.RefreshCustomerToken(req.RefreshToken, clientInfo(c))
 	if err != nil {
 		h.logger.WithError(err).Warn("Customer token refresh failed")
 		c.JSON(http.StatusUnauthorized, responses.ErrorResponse{Error: err.Error()})
@@ -127,7 +122,8 @@ func (h *CustomerHandler) RefreshCustomer(c *gin.Context) {
 
 	h.logger.Info("Customer token refreshed successfully")
 	c.JSON(http.StatusOK, responses.TokenRefreshResponse{
-		AccessToken: accessToken,
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
 	})
 }
 
@@ -147,16 +143,77 @@ func (h *CustomerHandler) GetCustomerProfile(c *gin.Context) {
 	}
 
 	h.logger.Info("Customer profile retrieved successfully")
-	c.JSON(http.StatusOK, responses.CustomerProfileResponse{
+	c.JSON(http.StatusOK, customerProfileResponse(customer))
+}
+
+// UpdateCustomerProfile частично изменяет профиль клиента.
+func (h *CustomerHandler) UpdateCustomerProfile(c *gin.Context) {
+	var req requests.UpdateCustomerProfileRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.WithError(err).Error("Invalid request format for customer profile update")
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "invalid request format"})
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		h.logger.WithError(err).Warn("Validation failed for customer profile update")
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "validation failed", Details: utils.CustomValidationErrors(validationErrors)})
+		return
+	}
+
+	customer, err := h.usecase.UpdateCustomerProfile(c.GetString("user_id"), req.Version, usecase.CustomerPatch{
+		CompanyName:     req.CompanyName,
+		Name:            req.Name,
+		JobPosition:     req.JobPosition,
+		PhoneNumber:     req.PhoneNumber,
+		Address:         req.Address,
+		WorkDescription: req.WorkDescription,
+	})
+	if err != nil {
+		h.logger.WithError(err).Warn("Customer profile update failed")
+		c.JSON(profileErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	h.logger.Info("Customer profile updated successfully")
+	c.JSON(http.StatusOK, customerProfileResponse(customer))
+}
+
+// GetCustomerProfileHistory возвращает историю изменений профиля клиента.
+func (h *CustomerHandler) GetCustomerProfileHistory(c *gin.Context) {
+	var query requests.PageQuery
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.logger.WithError(err).Error("Invalid query for customer profile history")
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "invalid request format"})
+		return
+	}
+
+	changes, err := h.usecase.ListCustomerProfileChanges(c.GetString("user_id"), query.Limit, query.Offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, responses.ErrorResponse{Error: "failed to list profile changes"})
+		return
+	}
+	c.JSON(http.StatusOK, profileChangeResponses(changes))
+}
+
+func customerProfileResponse(customer *domain.Customer) responses.CustomerProfileResponse {
+	return responses.CustomerProfileResponse{
 		ID:              customer.ID,
 		ClientType:      customer.ClientType,
 		CompanyName:     customer.CompanyName,
-		IIN:             customer.IIN,
+		IIN:             customer.IIN.String(),
 		Name:            customer.Name,
 		JobPosition:     customer.JobPosition,
-		PhoneNumber:     customer.PhoneNumber,
+		PhoneNumber:     customer.PhoneNumber.String(),
+		PhoneFormatted:  customer.PhoneNumber.Format(),
 		Email:           customer.Email,
+		EmailVerified:   customer.EmailVerifiedAt != nil,
 		Address:         customer.Address,
 		WorkDescription: customer.WorkDescription,
-	})
+		Version:         customer.Version,
+		UpdatedAt:       customer.UpdatedAt,
+	}
 }
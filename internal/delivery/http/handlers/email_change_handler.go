@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"BruhPro/internal/delivery/http/requests"
+	responses "BruhPro/internal/delivery/http/response"
+	"BruhPro/internal/repository"
+	"BruhPro/internal/usecase"
+	"BruhPro/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+// EmailChangeHandler обслуживает смену email для всех ролей.
+type EmailChangeHandler struct {
+	usecase  *usecase.EmailChangeUsecase
+	validate *validator.Validate
+	logger   *logrus.Logger
+}
+
+func NewEmailChangeHandler(u *usecase.EmailChangeUsecase, logger *logrus.Logger) *EmailChangeHandler {
+	return &EmailChangeHandler{
+		usecase:  u,
+		validate: utils.NewValidator(),
+		logger:   logger,
+	}
+}
+
+// RequestChange отправляет ссылку подтверждения на новый email текущего аккаунта.
+func (h *EmailChangeHandler) RequestChange(c *gin.Context) {
+	var req requests.ChangeEmailRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.WithError(err).Error("Invalid request format for email change")
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "invalid request format"})
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		h.logger.WithError(err).Warn("Validation failed for email change")
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "validation failed", Details: utils.CustomValidationErrors(validationErrors)})
+		return
+	}
+
+	if err := h.usecase.RequestChange(c.GetString("user_id"), c.GetString("role"), req.Password, req.Email); err != nil {
+		h.logger.WithError(err).Warn("Email change request failed")
+		c.JSON(emailChangeErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, responses.AuthSuccessResponse{
+		Status:  "success",
+		Message: "confirmation link has been sent to the new email",
+	})
+}
+
+// ConfirmChange меняет email по токену из письма на новый адрес.
+func (h *EmailChangeHandler) ConfirmChange(c *gin.Context) {
+	token, ok := h.bindToken(c, "email change confirmation")
+	if !ok {
+		return
+	}
+
+	if err := h.usecase.ConfirmChange(token); err != nil {
+		h.logger.WithError(err).Warn("Email change confirmation failed")
+		c.JSON(emailChangeErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	h.logger.Info("Email changed successfully")
+	c.JSON(http.StatusOK, responses.AuthSuccessResponse{
+		Status:  "success",
+		Message: "email changed successfully",
+	})
+}
+
+// RevertChange возвращает прежний email по токену из уведомления на старый адрес.
+func (h *EmailChangeHandler) RevertChange(c *gin.Context) {
+	token, ok := h.bindToken(c, "email change revert")
+	if !ok {
+		return
+	}
+
+	if err := h.usecase.RevertChange(token); err != nil {
+		h.logger.WithError(err).Warn("Email change revert failed")
+		c.JSON(emailChangeErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	h.logger.Info("Email change reverted")
+	c.JSON(http.StatusOK, responses.AuthSuccessResponse{
+		Status:  "success",
+		Message: "previous email restored, all sessions have been signed out; please reset your password",
+	})
+}
+
+// bindToken разбирает токен из тела запроса; при ошибке ответ уже отправлен.
+func (h *EmailChangeHandler) bindToken(c *gin.Context, action string) (string, bool) {
+	var req requests.EmailChangeTokenRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.WithError(err).Error("Invalid request format for " + action)
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "invalid request format"})
+		return "", false
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		h.logger.WithError(err).Warn("Validation failed for " + action)
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "validation failed", Details: utils.CustomValidationErrors(validationErrors)})
+		return "", false
+	}
+	return req.Token, true
+}
+
+func emailChangeErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, usecase.ErrWrongCurrentPassword):
+		return http.StatusForbidden
+	case errors.Is(err, repository.ErrEmailInUse):
+		return http.StatusConflict
+	case errors.Is(err, repository.ErrEmailChangeInvalid), errors.Is(err, usecase.ErrEmailNotChanged):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"BruhPro/internal/delivery/http/requests"
+	responses "BruhPro/internal/delivery/http/response"
+	"BruhPro/internal/domain"
+	"BruhPro/internal/usecase"
+	"BruhPro/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+// EscrowHandler обслуживает этапы заказа: оплату на эскроу, сдачу и приемку.
+type EscrowHandler struct {
+	usecase  *usecase.EscrowUsecase
+	validate *validator.Validate
+	logger   *logrus.Logger
+}
+
+func NewEscrowHandler(u *usecase.EscrowUsecase, logger *logrus.Logger) *EscrowHandler {
+	return &EscrowHandler{
+		usecase:  u,
+		validate: utils.NewValidator(),
+		logger:   logger,
+	}
+}
+
+func (h *EscrowHandler) AddMilestone(c *gin.Context) {
+	var req requests.MilestoneRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.WithError(err).Error("Invalid request format for milestone creation")
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "invalid request format"})
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		h.logger.WithError(err).Warn("Validation failed for milestone creation")
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "validation failed", Details: utils.CustomValidationErrors(validationErrors)})
+		return
+	}
+
+	milestone := &domain.Milestone{Title: req.Title, Amount: req.Amount, Position: req.Position}
+	if err := h.usecase.AddMilestone(c.GetString("user_id"), c.Param("id"), milestone); err != nil {
+		h.logger.WithError(err).Warn("Milestone creation failed")
+		c.JSON(escrowErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, milestoneResponse(milestone))
+}
+
+func (h *EscrowHandler) ListMilestones(c *gin.Context) {
+	milestones, err := h.usecase.ListMilestones(c.GetString("user_id"), c.GetString("role"), c.Param("id"))
+	if err != nil {
+		c.JSON(escrowErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	result := make([]responses.MilestoneResponse, 0, len(milestones))
+	for i := range milestones {
+		result = append(result, milestoneResponse(&milestones[i]))
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// FundMilestone начинает оплату этапа; в ответе — платеж со ссылкой на страницу оплаты.
+func (h *EscrowHandler) FundMilestone(c *gin.Context) {
+	payment, err := h.usecase.Fund(c.GetString("user_id"), c.Param("id"), c.Param("milestone_id"))
+	if err != nil {
+		h.logger.WithError(err).Warn("Milestone funding failed")
+		c.JSON(escrowErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, paymentResponse(payment))
+}
+
+func (h *EscrowHandler) SubmitMilestone(c *gin.Context) {
+	milestone, err := h.usecase.Submit(c.GetString("user_id"), c.Param("id"), c.Param("milestone_id"))
+	if err != nil {
+		h.logger.WithError(err).Warn("Milestone submission failed")
+		c.JSON(escrowErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, milestoneResponse(milestone))
+}
+
+func (h *EscrowHandler) ApproveMilestone(c *gin.Context) {
+	milestone, err := h.usecase.Approve(c.GetString("user_id"), c.Param("id"), c.Param("milestone_id"))
+	if err != nil {
+		h.logger.WithError(err).Warn("Milestone approval failed")
+		c.JSON(escrowErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, milestoneResponse(milestone))
+}
+
+func (h *EscrowHandler) RequestMilestoneChanges(c *gin.Context) {
+	milestone, err := h.usecase.RequestChanges(c.GetString("user_id"), c.Param("id"), c.Param("milestone_id"))
+	if err != nil {
+		h.logger.WithError(err).Warn("Milestone change request failed")
+		c.JSON(escrowErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, milestoneResponse(milestone))
+}
+
+func escrowErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidMilestoneOperation):
+		return http.StatusConflict
+	default:
+		return paymentErrorStatus(err)
+	}
+}
+
+func milestoneResponse(milestone *domain.Milestone) responses.MilestoneResponse {
+	return responses.MilestoneResponse{
+		ID:            milestone.ID,
+		OrderID:       milestone.OrderID,
+		Title:         milestone.Title,
+		Amount:        milestone.Amount,
+		Position:      milestone.Position,
+		Status:        milestone.Status,
+		PaymentID:     milestone.PaymentID,
+		Commission:    milestone.Commission,
+		Payout:        milestone.Payout,
+		SubmittedAt:   milestone.SubmittedAt,
+		AutoReleaseAt: milestone.AutoReleaseAt,
+		ReleasedAt:    milestone.ReleasedAt,
+		ReleasedBy:    milestone.ReleasedBy,
+		CreatedAt:     milestone.CreatedAt,
+	}
+}
@@ -1,13 +1,16 @@
 package handlers
 
 import (
+	"errors"
+	"math"
 	"net/http"
 
-	"BuhPro+/internal/delivery/http/requests"
-	responses "BuhPro+/internal/delivery/http/response"
-	"BuhPro+/internal/domain"
-	"BuhPro+/internal/usecase"
-	"BuhPro+/internal/utils"
+	"BruhPro/internal/delivery/http/requests"
+	responses "BruhPro/internal/delivery/http/response"
+	"BruhPro/internal/domain"
+	"BruhPro/internal/repository"
+	"BruhPro/internal/usecase"
+	"BruhPro/internal/utils"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -23,7 +26,7 @@ type ExecutorHandler struct {
 func NewExecutorHandler(u *usecase.ExecutorUsecase, logger *logrus.Logger) *ExecutorHandler {
 	return &ExecutorHandler{
 		usecase:  u,
-		validate: validator.New(),
+		validate: utils.NewValidator(),
 		logger:   logger,
 	}
 }
@@ -45,8 +48,6 @@ func (h *ExecutorHandler) RegisterExecutor(c *gin.Context) {
 	}
 
 	executor := &domain.Executor{
-		Email:           req.Email,
-		PasswordHash:    req.Password, // Пароль будет хеширован в usecase
 		Name:            req.Name,
 		Surname:         req.Surname,
 		Patronymic:      req.Patronymic,
@@ -54,17 +55,17 @@ func (h *ExecutorHandler) RegisterExecutor(c *gin.Context) {
 		PhoneNumber:     req.PhoneNumber,
 		City:            req.City,
 		ExpWork:         req.ExpWork,
-		Specializations: req.Specializations,
+		Specializations: specializationsFromCodes(req.Specializations),
 		Education:       req.Education,
 		WorkFormat:      req.WorkFormat,
 		HourlyRate:      req.HourlyRate,
 		AboutExecutor:   req.AboutExecutor,
 	}
 
-	err := h.usecase.RegisterExecutor(executor)
+	err := h.usecase.RegisterExecutor(req.Email, req.Password, executor)
 	if err != nil {
 		h.logger.WithError(err).Warn("Executor registration failed")
-		c.JSON(http.StatusConflict, responses.ErrorResponse{Error: err.Error()})
+		c.JSON(registrationErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
 		return
 	}
 
@@ -91,7 +92,7 @@ func (h *ExecutorHandler) LoginExecutor(c *gin.Context) {
 		return
 	}
 
-	accessToken, refreshToken, err := h.usecase.LoginExecutor(req.Email, req.Password)
+	tokens, err := h.usecase.LoginExecutor(req.Email, req.Password, clientInfo(c))
 	if err != nil {
 		h.logger.WithError(err).Warn("Executor login failed")
 		c.JSON(http.StatusUnauthorized, responses.ErrorResponse{Error: err.Error()})
@@ -99,10 +100,7 @@ func (h *ExecutorHandler) LoginExecutor(c *gin.Context) {
 	}
 
 	h.logger.Info("Executor logged in successfully")
-	c.JSON(http.StatusOK, responses.LoginResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-	})
+	writeLoginResponse(c, tokens)
 }
 
 func (h *ExecutorHandler) RefreshExecutor(c *gin.Context) {
@@ -121,7 +119,7 @@ func (h *ExecutorHandler) RefreshExecutor(c *gin.Context) {
 		return
 	}
 
-	accessToken, err := h.usecase.RefreshExecutorToken(req.RefreshToken)
+	tokens, err := h.usecase.RefreshExecutorToken(req.RefreshToken, clientInfo(c))
 	if err != nil {
 		h.logger.WithError(err).Warn("Executor token refresh failed")
 		c.JSON(http.StatusUnauthorized, responses.ErrorResponse{Error: err.Error()})
@@ -130,7 +128,8 @@ func (h *ExecutorHandler) RefreshExecutor(c *gin.Context) {
 
 	h.logger.Info("Executor token refreshed successfully")
 	c.JSON(http.StatusOK, responses.TokenRefreshResponse{
-		AccessToken: accessToken,
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
 	})
 }
 
@@ -150,20 +149,176 @@ func (h *ExecutorHandler) GetExecutorProfile(c *gin.Context) {
 	}
 
 	h.logger.Info("Executor profile retrieved successfully")
-	c.JSON(http.StatusOK, responses.ExecutorProfileResponse{
+	c.JSON(http.StatusOK, executorProfileResponse(executor))
+}
+
+// UpdateExecutorProfile частично изменяет профиль исполнителя.
+func (h *ExecutorHandler) UpdateExecutorProfile(c *gin.Context) {
+	var req requests.UpdateExecutorProfileRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.WithError(err).Error("Invalid request format for executor profile update")
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "invalid request format"})
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		h.logger.WithError(err).Warn("Validation failed for executor profile update")
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "validation failed", Details: utils.CustomValidationErrors(validationErrors)})
+		return
+	}
+
+	executor, err := h.usecase.UpdateExecutorProfile(c.GetString("user_id"), req.Version, usecase.ExecutorPatch{
+		Name:            req.Name,
+		Surname:         req.Surname,
+		Patronymic:      req.Patronymic,
+		PhoneNumber:     req.PhoneNumber,
+		City:            req.City,
+		ExpWork:         req.ExpWork,
+		Specializations: req.Specializations,
+		Education:       req.Education,
+		WorkFormat:      req.WorkFormat,
+		HourlyRate:      req.HourlyRate,
+		AboutExecutor:   req.AboutExecutor,
+	})
+	if err != nil {
+		h.logger.WithError(err).Warn("Executor profile update failed")
+		c.JSON(profileErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	h.logger.Info("Executor profile updated successfully")
+	c.JSON(http.StatusOK, executorProfileResponse(executor))
+}
+
+// GetExecutorProfileHistory возвращает историю изменений профиля исполнителя.
+func (h *ExecutorHandler) GetExecutorProfileHistory(c *gin.Context) {
+	var query requests.PageQuery
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.logger.WithError(err).Error("Invalid query for executor profile history")
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "invalid request format"})
+		return
+	}
+
+	changes, err := h.usecase.ListExecutorProfileChanges(c.GetString("user_id"), query.Limit, query.Offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, responses.ErrorResponse{Error: "failed to list profile changes"})
+		return
+	}
+	c.JSON(http.StatusOK, profileChangeResponses(changes))
+}
+
+func executorProfileResponse(executor *domain.Executor) responses.ExecutorProfileResponse {
+	var birthDate string
+	if date, err := executor.IIN.BirthDate(); err == nil {
+		birthDate = date.Format("2006-01-02")
+	}
+
+	return responses.ExecutorProfileResponse{
 		ID:              executor.ID,
 		Name:            executor.Name,
 		Surname:         executor.Surname,
 		Patronymic:      executor.Patronymic,
-		IIN:             executor.IIN,
-		PhoneNumber:     executor.PhoneNumber,
+		IIN:             executor.IIN.String(),
+		BirthDate:       birthDate,
+		Gender:          executor.IIN.Gender(),
+		PhoneNumber:     executor.PhoneNumber.String(),
+		PhoneFormatted:  executor.PhoneNumber.Format(),
 		Email:           executor.Email,
+		EmailVerified:   executor.EmailVerifiedAt != nil,
 		City:            executor.City,
 		ExpWork:         executor.ExpWork,
-		Specializations: executor.Specializations,
+		Specializations: specializationResponses(executor.Specializations),
 		Education:       executor.Education,
 		WorkFormat:      executor.WorkFormat,
 		HourlyRate:      executor.HourlyRate,
 		AboutExecutor:   executor.AboutExecutor,
-	})
+		Version:         executor.Version,
+		UpdatedAt:       executor.UpdatedAt,
+		Rating:          ratingSummaryResponse(executor.Rating),
+	}
+}
+
+// SearchExecutors возвращает страницу публичного поиска исполнителей.
+func (h *ExecutorHandler) SearchExecutors(c *gin.Context) {
+	var query requests.SearchExecutorsQuery
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.logger.WithError(err).Error("Invalid query for executor search")
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "invalid request format"})
+		return
+	}
+
+	if err := h.validate.Struct(query); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		h.logger.WithError(err).Warn("Validation failed for executor search")
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "validation failed", Details: utils.CustomValidationErrors(validationErrors)})
+		return
+	}
+
+	filter := repository.ExecutorFilter{
+		Query:          query.Q,
+		Specialization: query.Specialization,
+		City:           query.City,
+		WorkFormat:     query.WorkFormat,
+		MinRate:        query.MinRate,
+		MaxRate:        query.MaxRate,
+		Sort:           query.Sort,
+		Limit:          query.Limit,
+	}
+	if bucket, ok := domain.FindExperienceBucket(query.Experience); ok {
+		filter.MinYears = bucket.MinYears
+		filter.MaxYears = bucket.MaxYears
+	}
+
+	executors, next, err := h.usecase.SearchExecutors(filter, query.Cursor)
+	if err != nil {
+		c.JSON(searchErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	items := make([]responses.ExecutorPublicResponse, 0, len(executors))
+	for i := range executors {
+		item := executorPublicResponse(&executors[i])
+		if query.Q != "" {
+			item.Search = searchMatchResponse(executors[i].SearchRank, executors[i].SearchSnippet)
+		}
+		items = append(items, item)
+	}
+	c.JSON(http.StatusOK, responses.ExecutorSearchResponse{Items: items, NextCursor: next})
+}
+
+func executorPublicResponse(executor *domain.Executor) responses.ExecutorPublicResponse {
+	return responses.ExecutorPublicResponse{
+		ID:              executor.ID,
+		Name:            executor.Name,
+		Surname:         executor.Surname,
+		Patronymic:      executor.Patronymic,
+		City:            executor.City,
+		ExpWork:         executor.ExpWork,
+		Specializations: specializationResponses(executor.Specializations),
+		Education:       executor.Education,
+		WorkFormat:      executor.WorkFormat,
+		HourlyRate:      executor.HourlyRate,
+		AboutExecutor:   executor.AboutExecutor,
+		CreatedAt:       executor.CreatedAt,
+		Rating:          ratingSummaryResponse(executor.Rating),
+	}
+}
+
+// searchMatchResponse округляет релевантность, как средние в ratingSummaryResponse.
+func searchMatchResponse(rank float64, snippet string) *responses.SearchMatchResponse {
+	return &responses.SearchMatchResponse{Rank: math.Round(rank*10000) / 10000, Snippet: snippet}
+}
+
+// searchErrorStatus сопоставляет ошибки поиска профилей с HTTP-статусами.
+func searchErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidCursor), errors.Is(err, usecase.ErrInvalidSearchFilter):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
 }
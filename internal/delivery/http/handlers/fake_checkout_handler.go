@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"net/http"
+
+	"BruhPro/internal/delivery/http/requests"
+	responses "BruhPro/internal/delivery/http/response"
+	"BruhPro/internal/payments"
+	"BruhPro/internal/usecase"
+	"BruhPro/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+// FakeCheckoutHandler заменяет страницу оплаты при локальном провайдере:
+// имитирует действие плательщика и доставляет подписанное уведомление
+// тем же путем, что и настоящий вебхук.
+type FakeCheckoutHandler struct {
+	provider *payments.FakeProvider
+	usecase  *usecase.PaymentUsecase
+	validate *validator.Validate
+	logger   *logrus.Logger
+}
+
+func NewFakeCheckoutHandler(provider *payments.FakeProvider, u *usecase.PaymentUsecase, logger *logrus.Logger) *FakeCheckoutHandler {
+	return &FakeCheckoutHandler{
+		provider: provider,
+		usecase:  u,
+		validate: utils.NewValidator(),
+		logger:   logger,
+	}
+}
+
+func (h *FakeCheckoutHandler) Checkout(c *gin.Context) {
+	var req requests.FakeCheckoutRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.WithError(err).Error("Invalid request format for fake checkout")
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "invalid request format"})
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "validation failed", Details: utils.CustomValidationErrors(validationErrors)})
+		return
+	}
+
+	payload, signature, err := h.provider.Simulate(c.Param("charge_id"), req.Status, req.Reason)
+	if err != nil {
+		c.JSON(paymentErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if err := h.usecase.HandleWebhook(payload, signature); err != nil {
+		c.JSON(paymentErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	h.logger.WithField("charge_id", c.Param("charge_id")).Info("Fake checkout completed")
+	c.JSON(http.StatusOK, responses.AuthSuccessResponse{
+		Status:  "success",
+		Message: "payment " + req.Status,
+	})
+}
@@ -0,0 +1,24 @@
+package handlers
+
+import (
+	"net/http"
+
+	"BruhPro/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JWKSHandler публикует публичные ключи подписи токенов.
+type JWKSHandler struct {
+	tokens *utils.JWTManager
+}
+
+func NewJWKSHandler(tokens *utils.JWTManager) *JWKSHandler {
+	return &JWKSHandler{tokens: tokens}
+}
+
+// JWKS отдает набор ключей. Кеш короткий, чтобы новый ключ после ротации быстро стал виден.
+func (h *JWKSHandler) JWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.tokens.JWKS())
+}
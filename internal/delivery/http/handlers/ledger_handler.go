@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"BruhPro/internal/delivery/http/requests"
+	responses "BruhPro/internal/delivery/http/response"
+	"BruhPro/internal/ledger"
+	"BruhPro/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// LedgerHandler обслуживает административные отчеты по журналу.
+type LedgerHandler struct {
+	usecase *usecase.LedgerUsecase
+	logger  *logrus.Logger
+}
+
+func NewLedgerHandler(u *usecase.LedgerUsecase, logger *logrus.Logger) *LedgerHandler {
+	return &LedgerHandler{
+		usecase: u,
+		logger:  logger,
+	}
+}
+
+func (h *LedgerHandler) GetBalance(c *gin.Context) {
+	var query requests.LedgerBalanceQuery
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "invalid request format"})
+		return
+	}
+	if query.At.IsZero() {
+		query.At = time.Now()
+	}
+
+	account := c.Param("account")
+	balance, err := h.usecase.Balance(account, query.At)
+	if err != nil {
+		h.logger.WithError(err).Warn("Failed to get ledger balance")
+		c.JSON(ledgerErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.LedgerBalanceResponse{
+		Account: account,
+		Type:    ledger.AccountType(account),
+		Balance: balance,
+		At:      query.At,
+	})
+}
+
+func (h *LedgerHandler) GetStatement(c *gin.Context) {
+	from, to, ok := h.bindPeriod(c)
+	if !ok {
+		return
+	}
+
+	entries, err := h.usecase.Statement(c.Param("account"), from, to)
+	if err != nil {
+		h.logger.WithError(err).Warn("Failed to get ledger statement")
+		c.JSON(ledgerErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	result := make([]responses.LedgerEntryResponse, 0, len(entries))
+	for _, entry := range entries {
+		lines := make([]responses.LedgerLineResponse, 0, len(entry.Lines))
+		for _, line := range entry.Lines {
+			lines = append(lines, responses.LedgerLineResponse{Account: line.Account, Debit: line.Debit, Credit: line.Credit})
+		}
+		result = append(result, responses.LedgerEntryResponse{
+			ID:          entry.ID,
+			Kind:        entry.Kind,
+			Reference:   entry.Reference,
+			Description: entry.Description,
+			OccurredAt:  entry.OccurredAt,
+			Lines:       lines,
+		})
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *LedgerHandler) Reconcile(c *gin.Context) {
+	from, to, ok := h.bindPeriod(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.usecase.Reconcile(from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, responses.ErrorResponse{Error: "failed to reconcile ledger"})
+		return
+	}
+
+	discrepancies := make([]responses.DiscrepancyResponse, 0, len(report.Discrepancies))
+	for _, d := range report.Discrepancies {
+		discrepancies = append(discrepancies, responses.DiscrepancyResponse{
+			PaymentID:   d.Reference,
+			ChargeID:    d.ChargeID,
+			ProviderNet: d.ProviderNet,
+			LedgerNet:   d.LedgerNet,
+		})
+	}
+	c.JSON(http.StatusOK, responses.ReconciliationResponse{
+		From:          report.From,
+		To:            report.To,
+		ProviderNet:   report.ProviderNet,
+		LedgerNet:     report.LedgerNet,
+		Matched:       report.Matched,
+		Discrepancies: discrepancies,
+	})
+}
+
+// bindPeriod разбирает период отчета; при ошибке ответ уже отправлен.
+func (h *LedgerHandler) bindPeriod(c *gin.Context) (time.Time, time.Time, bool) {
+	var query requests.LedgerPeriodQuery
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "invalid request format"})
+		return time.Time{}, time.Time{}, false
+	}
+	if query.To.IsZero() {
+		query.To = time.Now()
+	}
+	if query.From.IsZero() {
+		query.From = query.To.Add(-24 * time.Hour)
+	}
+	if !query.From.Before(query.To) {
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "from must be before to"})
+		return time.Time{}, time.Time{}, false
+	}
+	return query.From, query.To, true
+}
+
+func ledgerErrorStatus(err error) int {
+	if errors.Is(err, ledger.ErrUnknownAccount) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
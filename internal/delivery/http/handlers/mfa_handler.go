@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"BruhPro/internal/delivery/http/requests"
+	responses "BruhPro/internal/delivery/http/response"
+	"BruhPro/internal/usecase"
+	"BruhPro/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+// MFAHandler обслуживает подключение TOTP и второй шаг входа для всех ролей.
+type MFAHandler struct {
+	usecase  *usecase.MFAUsecase
+	validate *validator.Validate
+	logger   *logrus.Logger
+}
+
+func NewMFAHandler(u *usecase.MFAUsecase, logger *logrus.Logger) *MFAHandler {
+	return &MFAHandler{
+		usecase:  u,
+		validate: utils.NewValidator(),
+		logger:   logger,
+	}
+}
+
+func (h *MFAHandler) Enroll(c *gin.Context) {
+	userID := c.GetString("user_id")
+
+	enrollment, err := h.usecase.Enroll(userID)
+	if err != nil {
+		h.logger.WithError(err).Warn("MFA enrollment failed")
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	h.logger.Info("MFA enrollment started")
+	c.JSON(http.StatusOK, responses.MFAEnrollResponse{
+		Secret:          enrollment.Secret,
+		ProvisioningURI: enrollment.ProvisioningURI,
+	})
+}
+
+func (h *MFAHandler) Enable(c *gin.Context) {
+	var req requests.MFACodeRequest
+	if !h.bind(c, &req, "MFA enable") {
+		return
+	}
+
+	codes, err := h.usecase.Enable(c.GetString("user_id"), req.Code)
+	if err != nil {
+		h.logger.WithError(err).Warn("MFA enable failed")
+		c.JSON(mfaErrorStatus(err, http.StatusBadRequest), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	h.logger.Info("MFA enabled successfully")
+	c.JSON(http.StatusOK, responses.RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+func (h *MFAHandler) Disable(c *gin.Context) {
+	var req requests.MFACodeRequest
+	if !h.bind(c, &req, "MFA disable") {
+		return
+	}
+
+	if err := h.usecase.Disable(c.GetString("user_id"), req.Code); err != nil {
+		h.logger.WithError(err).Warn("MFA disable failed")
+		c.JSON(mfaErrorStatus(err, http.StatusBadRequest), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	h.logger.Info("MFA disabled successfully")
+	c.JSON(http.StatusOK, responses.AuthSuccessResponse{
+		Status:  "success",
+		Message: "two-factor authentication disabled",
+	})
+}
+
+func (h *MFAHandler) CompleteLogin(c *gin.Context) {
+	var req requests.MFALoginRequest
+	if !h.bind(c, &req, "MFA login") {
+		return
+	}
+
+	tokens, err := h.usecase.CompleteLogin(req.MFAToken, req.Code, clientInfo(c))
+	if err != nil {
+		h.logger.WithError(err).Warn("MFA login failed")
+		c.JSON(mfaErrorStatus(err, http.StatusUnauthorized), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	h.logger.Info("Logged in with MFA successfully")
+	writeLoginResponse(c, tokens)
+}
+
+// mfaErrorStatus возвращает 429 для заблокированного после ошибок кода аккаунта, иначе fallback.
+func mfaErrorStatus(err error, fallback int) int {
+	if errors.Is(err, usecase.ErrMFALocked) {
+		return http.StatusTooManyRequests
+	}
+	return fallback
+}
+
+// bind разбирает и валидирует тело запроса; при ошибке ответ уже отправлен.
+func (h *MFAHandler) bind(c *gin.Context, req interface{}, action string) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		h.logger.WithError(err).Errorf("Invalid request format for %s", action)
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "invalid request format"})
+		return false
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		h.logger.WithError(err).Warnf("Validation failed for %s", action)
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "validation failed", Details: utils.CustomValidationErrors(validationErrors)})
+		return false
+	}
+	return true
+}
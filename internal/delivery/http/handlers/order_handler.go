@@ -0,0 +1,286 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"BruhPro/internal/delivery/http/requests"
+	responses "BruhPro/internal/delivery/http/response"
+	"BruhPro/internal/domain"
+	"BruhPro/internal/repository"
+	"BruhPro/internal/usecase"
+	"BruhPro/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+type OrderHandler struct {
+	usecase  *usecase.OrderUsecase
+	validate *validator.Validate
+	logger   *logrus.Logger
+}
+
+func NewOrderHandler(u *usecase.OrderUsecase, logger *logrus.Logger) *OrderHandler {
+	return &OrderHandler{
+		usecase:  u,
+		validate: utils.NewValidator(),
+		logger:   logger,
+	}
+}
+
+func (h *OrderHandler) CreateOrder(c *gin.Context) {
+	order, publish, ok := h.bindOrder(c, "order creation")
+	if !ok {
+		return
+	}
+
+	if err := h.usecase.Create(c.GetString("user_id"), order, publish); err != nil {
+		h.logger.WithError(err).Warn("Order creation failed")
+		c.JSON(orderErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	h.logger.Info("Order created successfully")
+	c.JSON(http.StatusCreated, orderResponse(order))
+}
+
+// ListOrders возвращает опубликованные заказы для исполнителей.
+func (h *OrderHandler) ListOrders(c *gin.Context) {
+	filter, ok := h.bindListQuery(c)
+	if !ok {
+		return
+	}
+
+	orders, err := h.usecase.ListPublished(filter)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to list orders")
+		c.JSON(http.StatusInternalServerError, responses.ErrorResponse{Error: "failed to list orders"})
+		return
+	}
+
+	c.JSON(http.StatusOK, orderResponses(orders))
+}
+
+// ListMyOrders возвращает заказы текущего клиента во всех статусах.
+func (h *OrderHandler) ListMyOrders(c *gin.Context) {
+	filter, ok := h.bindListQuery(c)
+	if !ok {
+		return
+	}
+
+	orders, err := h.usecase.ListByCustomer(c.GetString("user_id"), filter)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to list customer orders")
+		c.JSON(http.StatusInternalServerError, responses.ErrorResponse{Error: "failed to list orders"})
+		return
+	}
+
+	c.JSON(http.StatusOK, orderResponses(orders))
+}
+
+// ListAssignedOrders возвращает заказы, в которых текущий исполнитель назначен.
+func (h *OrderHandler) ListAssignedOrders(c *gin.Context) {
+	filter, ok := h.bindListQuery(c)
+	if !ok {
+		return
+	}
+
+	orders, err := h.usecase.ListByExecutor(c.GetString("user_id"), filter)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to list executor orders")
+		c.JSON(http.StatusInternalServerError, responses.ErrorResponse{Error: "failed to list orders"})
+		return
+	}
+
+	c.JSON(http.StatusOK, orderResponses(orders))
+}
+
+func (h *OrderHandler) GetOrder(c *gin.Context) {
+	order, err := h.usecase.GetByID(c.GetString("user_id"), c.GetString("role"), c.Param("id"))
+	if err != nil {
+		h.logger.WithError(err).Warn("Order not found")
+		c.JSON(orderErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, orderResponse(order))
+}
+
+func (h *OrderHandler) UpdateOrder(c *gin.Context) {
+	changes, _, ok := h.bindOrder(c, "order update")
+	if !ok {
+		return
+	}
+
+	order, err := h.usecase.Update(c.GetString("user_id"), c.Param("id"), changes)
+	if err != nil {
+		h.logger.WithError(err).Warn("Order update failed")
+		c.JSON(orderErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	h.logger.Info("Order updated successfully")
+	c.JSON(http.StatusOK, orderResponse(order))
+}
+
+func (h *OrderHandler) DeleteOrder(c *gin.Context) {
+	if err := h.usecase.Delete(c.GetString("user_id"), c.Param("id")); err != nil {
+		h.logger.WithError(err).Warn("Order deletion failed")
+		c.JSON(orderErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	h.logger.Info("Order deleted successfully")
+	c.JSON(http.StatusOK, responses.AuthSuccessResponse{
+		Status:  "success",
+		Message: "order deleted successfully",
+	})
+}
+
+// TransitionOrder меняет статус заказа от имени текущего пользователя.
+func (h *OrderHandler) TransitionOrder(c *gin.Context) {
+	var req requests.OrderTransitionRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.WithError(err).Error("Invalid request format for order transition")
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "invalid request format"})
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		h.logger.WithError(err).Warn("Validation failed for order transition")
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "validation failed", Details: utils.CustomValidationErrors(validationErrors)})
+		return
+	}
+
+	order, err := h.usecase.Transition(c.GetString("user_id"), c.GetString("role"), c.Param("id"), req.Status, req.Reason)
+	if err != nil {
+		h.logger.WithError(err).Warn("Order transition failed")
+		c.JSON(orderErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	h.logger.Info("Order status changed successfully")
+	c.JSON(http.StatusOK, orderResponse(order))
+}
+
+// GetOrderHistory возвращает историю статусов заказа.
+func (h *OrderHandler) GetOrderHistory(c *gin.Context) {
+	history, err := h.usecase.History(c.GetString("user_id"), c.GetString("role"), c.Param("id"))
+	if err != nil {
+		h.logger.WithError(err).Warn("Order history not available")
+		c.JSON(orderErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	result := make([]responses.OrderStatusChangeResponse, 0, len(history))
+	for _, change := range history {
+		result = append(result, responses.OrderStatusChangeResponse{
+			FromStatus: change.FromStatus,
+			ToStatus:   change.ToStatus,
+			ActorID:    change.ActorID,
+			ActorRole:  change.ActorRole,
+			Reason:     change.Reason,
+			CreatedAt:  change.CreatedAt,
+		})
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// bindOrder разбирает и валидирует тело заказа; при ошибке ответ уже отправлен.
+func (h *OrderHandler) bindOrder(c *gin.Context, action string) (*domain.Order, bool, bool) {
+	var req requests.OrderRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.WithError(err).Errorf("Invalid request format for %s", action)
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "invalid request format"})
+		return nil, false, false
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		h.logger.WithError(err).Warnf("Validation failed for %s", action)
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "validation failed", Details: utils.CustomValidationErrors(validationErrors)})
+		return nil, false, false
+	}
+
+	return &domain.Order{
+		Title:          req.Title,
+		Description:    req.Description,
+		Specialization: req.Specialization,
+		PaymentMode:    req.PaymentMode,
+		Budget:         req.Budget,
+		Deadline:       req.Deadline,
+		WorkFormat:     req.WorkFormat,
+		City:           req.City,
+	}, req.Publish, true
+}
+
+func (h *OrderHandler) bindListQuery(c *gin.Context) (repository.OrderFilter, bool) {
+	var query requests.ListOrdersQuery
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.logger.WithError(err).Error("Invalid query for order list")
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "invalid request format"})
+		return repository.OrderFilter{}, false
+	}
+
+	if err := h.validate.Struct(query); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		h.logger.WithError(err).Warn("Validation failed for order list")
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "validation failed", Details: utils.CustomValidationErrors(validationErrors)})
+		return repository.OrderFilter{}, false
+	}
+
+	return repository.OrderFilter{
+		Specialization: query.Specialization,
+		City:           query.City,
+		WorkFormat:     query.WorkFormat,
+		Limit:          query.Limit,
+		Offset:         query.Offset,
+	}, true
+}
+
+// orderErrorStatus подбирает HTTP-статус для ошибки OrderUsecase и ResponseUsecase.
+func orderErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, usecase.ErrOrderNotFound), errors.Is(err, usecase.ErrResponseNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, usecase.ErrOrderForbidden), errors.Is(err, usecase.ErrEmailNotVerified):
+		return http.StatusForbidden
+	case errors.Is(err, usecase.ErrInvalidOrderTransition), errors.Is(err, usecase.ErrResponseStatusChanged):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+func orderResponse(order *domain.Order) responses.OrderResponse {
+	return responses.OrderResponse{
+		ID:             order.ID,
+		CustomerID:     order.CustomerID,
+		ExecutorID:     order.ExecutorID,
+		Title:          order.Title,
+		Description:    order.Description,
+		Specialization: order.Specialization,
+		PaymentMode:    order.PaymentMode,
+		Budget:         order.Budget,
+		Deadline:       order.Deadline,
+		WorkFormat:     order.WorkFormat,
+		City:           order.City,
+		Status:         order.Status,
+		CreatedAt:      order.CreatedAt,
+		UpdatedAt:      order.UpdatedAt,
+	}
+}
+
+func orderResponses(orders []domain.Order) []responses.OrderResponse {
+	result := make([]responses.OrderResponse, 0, len(orders))
+	for i := range orders {
+		result = append(result, orderResponse(&orders[i]))
+	}
+	return result
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"BruhPro/internal/delivery/http/requests"
+	responses "BruhPro/internal/delivery/http/response"
+	"BruhPro/internal/usecase"
+	"BruhPro/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+// PasswordHandler обслуживает /password/forgot, /password/reset и /me/password для всех ролей.
+type PasswordHandler struct {
+	usecase  *usecase.PasswordUsecase
+	validate *validator.Validate
+	logger   *logrus.Logger
+}
+
+func NewPasswordHandler(u *usecase.PasswordUsecase, logger *logrus.Logger) *PasswordHandler {
+	return &PasswordHandler{
+		usecase:  u,
+		validate: utils.NewValidator(),
+		logger:   logger,
+	}
+}
+
+// ForgotPassword возвращает обработчик запроса ссылки для сброса пароля аккаунта с ролью role.
+func (h *PasswordHandler) ForgotPassword(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req requests.ForgotPasswordRequest
+
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.logger.WithError(err).Error("Invalid request format for password forgot")
+			c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "invalid request format"})
+			return
+		}
+
+		if err := h.validate.Struct(req); err != nil {
+			validationErrors := err.(validator.ValidationErrors)
+			h.logger.WithError(err).Warn("Validation failed for password forgot")
+			c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "validation failed", Details: utils.CustomValidationErrors(validationErrors)})
+			return
+		}
+
+		if err := h.usecase.ForgotPassword(req.Email, role); err != nil {
+			h.logger.WithError(err).Error("Password forgot failed")
+			c.JSON(http.StatusInternalServerError, responses.ErrorResponse{Error: "failed to process request"})
+			return
+		}
+
+		c.JSON(http.StatusOK, responses.AuthSuccessResponse{
+			Status:  "success",
+			Message: "if the account exists, a password reset link has been sent",
+		})
+	}
+}
+
+// ResetPassword возвращает обработчик сброса пароля по токену, выданному для роли role.
+func (h *PasswordHandler) ResetPassword(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req requests.ResetPasswordRequest
+
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.logger.WithError(err).Error("Invalid request format for password reset")
+			c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "invalid request format"})
+			return
+		}
+
+		if err := h.validate.Struct(req); err != nil {
+			validationErrors := err.(validator.ValidationErrors)
+			h.logger.WithError(err).Warn("Validation failed for password reset")
+			c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "validation failed", Details: utils.CustomValidationErrors(validationErrors)})
+			return
+		}
+
+		if err := h.usecase.ResetPassword(req.Token, role, req.Password); err != nil {
+			h.logger.WithError(err).Warn("Password reset failed")
+			c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: err.Error()})
+			return
+		}
+
+		h.logger.Info("Password reset successfully")
+		c.JSON(http.StatusOK, responses.AuthSuccessResponse{
+			Status:  "success",
+			Message: "password has been reset",
+		})
+	}
+}
+
+// ChangePassword меняет пароль текущего аккаунта; другие сессии завершаются.
+func (h *PasswordHandler) ChangePassword(c *gin.Context) {
+	var req requests.ChangePasswordRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.WithError(err).Error("Invalid request format for password change")
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "invalid request format"})
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		h.logger.WithError(err).Warn("Validation failed for password change")
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "validation failed", Details: utils.CustomValidationErrors(validationErrors)})
+		return
+	}
+
+	err := h.usecase.ChangePassword(c.GetString("user_id"), c.GetString("session_id"), req.CurrentPassword, req.NewPassword)
+	if err != nil {
+		h.logger.WithError(err).Warn("Password change failed")
+		c.JSON(passwordChangeErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	h.logger.Info("Password changed successfully")
+	c.JSON(http.StatusOK, responses.AuthSuccessResponse{
+		Status:  "success",
+		Message: "password has been changed, other sessions have been signed out",
+	})
+}
+
+func passwordChangeErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, usecase.ErrWrongCurrentPassword):
+		return http.StatusForbidden
+	case errors.Is(err, usecase.ErrPasswordTooWeak), errors.Is(err, usecase.ErrPasswordNotChanged):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
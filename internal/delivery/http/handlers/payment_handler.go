@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"BruhPro/internal/delivery/http/requests"
+	responses "BruhPro/internal/delivery/http/response"
+	"BruhPro/internal/domain"
+	"BruhPro/internal/payments"
+	"BruhPro/internal/usecase"
+	"BruhPro/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+// webhookSignatureHeader — заголовок с подписью уведомления провайдера.
+const webhookSignatureHeader = "X-Signature"
+
+// PaymentHandler обслуживает оплату курсов, платежи пользователя и уведомления провайдера.
+// Этапы заказов оплачиваются через EscrowHandler.
+type PaymentHandler struct {
+	usecase  *usecase.PaymentUsecase
+	validate *validator.Validate
+	logger   *logrus.Logger
+}
+
+func NewPaymentHandler(u *usecase.PaymentUsecase, logger *logrus.Logger) *PaymentHandler {
+	return &PaymentHandler{
+		usecase:  u,
+		validate: utils.NewValidator(),
+		logger:   logger,
+	}
+}
+
+func (h *PaymentHandler) PayCourse(c *gin.Context) {
+	payment, err := h.usecase.PayCourse(c.GetString("user_id"), c.Param("id"))
+	if err != nil {
+		h.logger.WithError(err).Warn("Course payment failed")
+		c.JSON(paymentErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, paymentResponse(payment))
+}
+
+func (h *PaymentHandler) GetPayment(c *gin.Context) {
+	payment, err := h.usecase.GetByID(c.GetString("user_id"), c.GetString("role"), c.Param("id"))
+	if err != nil {
+		c.JSON(paymentErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, paymentResponse(payment))
+}
+
+func (h *PaymentHandler) ListMyPayments(c *gin.Context) {
+	var query requests.PageQuery
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.logger.WithError(err).Error("Invalid query for payment list")
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "invalid request format"})
+		return
+	}
+
+	list, err := h.usecase.ListByAccount(c.GetString("user_id"), query.Limit, query.Offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, responses.ErrorResponse{Error: "failed to list payments"})
+		return
+	}
+
+	result := make([]responses.PaymentResponse, 0, len(list))
+	for i := range list {
+		result = append(result, paymentResponse(&list[i]))
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// RefundPayment возвращает платеж по решению администратора.
+func (h *PaymentHandler) RefundPayment(c *gin.Context) {
+	var req requests.RefundRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.WithError(err).Error("Invalid request format for refund")
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "invalid request format"})
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		h.logger.WithError(err).Warn("Validation failed for refund")
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "validation failed", Details: utils.CustomValidationErrors(validationErrors)})
+		return
+	}
+
+	payment, err := h.usecase.Refund(c.Param("id"), req.Amount)
+	if err != nil {
+		h.logger.WithError(err).Warn("Refund failed")
+		c.JSON(paymentErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	h.logger.WithField("admin_id", c.GetString("user_id")).Info("Payment refunded by admin")
+	c.JSON(http.StatusOK, paymentResponse(payment))
+}
+
+// Webhook принимает уведомления платежного провайдера. Тело читается как есть,
+// потому что подпись считается по исходным байтам.
+func (h *PaymentHandler) Webhook(c *gin.Context) {
+	payload, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "invalid request format"})
+		return
+	}
+
+	if err := h.usecase.HandleWebhook(payload, c.GetHeader(webhookSignatureHeader)); err != nil {
+		c.JSON(paymentErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.AuthSuccessResponse{
+		Status:  "success",
+		Message: "webhook processed",
+	})
+}
+
+func paymentErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, payments.ErrInvalidSignature):
+		return http.StatusUnauthorized
+	case errors.Is(err, usecase.ErrPaymentNotFound), errors.Is(err, usecase.ErrOrderNotFound),
+		errors.Is(err, usecase.ErrCourseNotFound), errors.Is(err, usecase.ErrMilestoneNotFound),
+		errors.Is(err, payments.ErrChargeNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, usecase.ErrNotEnrolled):
+		return http.StatusForbidden
+	case errors.Is(err, usecase.ErrAlreadyPaid), errors.Is(err, usecase.ErrPaymentNotRefundable),
+		errors.Is(err, usecase.ErrPaymentChanged),
+		errors.Is(err, payments.ErrInvalidState):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+func paymentResponse(payment *domain.Payment) responses.PaymentResponse {
+	return responses.PaymentResponse{
+		ID:             payment.ID,
+		Purpose:        payment.Purpose,
+		OrderID:        payment.OrderID,
+		EnrollmentID:   payment.EnrollmentID,
+		Amount:         payment.Amount,
+		RefundedAmount: payment.RefundedAmount,
+		Currency:       payment.Currency,
+		Status:         payment.Status,
+		CheckoutURL:    payment.CheckoutURL,
+		FailureReason:  payment.FailureReason,
+		CreatedAt:      payment.CreatedAt,
+		UpdatedAt:      payment.UpdatedAt,
+	}
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	responses "BruhPro/internal/delivery/http/response"
+	"BruhPro/internal/domain"
+	"BruhPro/internal/usecase"
+)
+
+func profileErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, usecase.ErrProfileNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, usecase.ErrProfileVersionConflict):
+		return http.StatusConflict
+	case errors.Is(err, usecase.ErrUnknownSpecialization),
+		errors.Is(err, usecase.ErrUnknownWorkFormat),
+		errors.Is(err, usecase.ErrInvalidHourlyRate):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func profileChangeResponses(changes []domain.ProfileChange) []responses.ProfileChangeResponse {
+	result := make([]responses.ProfileChangeResponse, 0, len(changes))
+	for _, change := range changes {
+		result = append(result, responses.ProfileChangeResponse{
+			Field:     change.Field,
+			OldValue:  change.OldValue,
+			NewValue:  change.NewValue,
+			Version:   change.Version,
+			CreatedAt: change.CreatedAt,
+		})
+	}
+	return result
+}
@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"errors"
+	"math"
+	"net/http"
+
+	"BruhPro/internal/delivery/http/requests"
+	responses "BruhPro/internal/delivery/http/response"
+	"BruhPro/internal/domain"
+	"BruhPro/internal/usecase"
+	"BruhPro/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+// RatingHandler обслуживает оценки и отзывы.
+type RatingHandler struct {
+	usecase  *usecase.RatingUsecase
+	validate *validator.Validate
+	logger   *logrus.Logger
+}
+
+func NewRatingHandler(u *usecase.RatingUsecase, logger *logrus.Logger) *RatingHandler {
+	return &RatingHandler{
+		usecase:  u,
+		validate: utils.NewValidator(),
+		logger:   logger,
+	}
+}
+
+func (h *RatingHandler) RateOrder(c *gin.Context) {
+	var req requests.RatingRequest
+	if !h.bind(c, &req, "order rating") {
+		return
+	}
+
+	rating := ratingFromRequest(req)
+	if err := h.usecase.Create(c.GetString("user_id"), c.Param("id"), rating); err != nil {
+		h.logger.WithError(err).Warn("Order rating failed")
+		c.JSON(ratingErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	h.logger.Info("Order rated successfully")
+	c.JSON(http.StatusCreated, ratingResponse(rating))
+}
+
+// RateCourse оставляет оценку коучу курса, на который записан текущий пользователь.
+func (h *RatingHandler) RateCourse(c *gin.Context) {
+	var req requests.RatingRequest
+	if !h.bind(c, &req, "course rating") {
+		return
+	}
+
+	rating := ratingFromRequest(req)
+	if err := h.usecase.RateCourse(c.GetString("user_id"), c.Param("id"), rating); err != nil {
+		h.logger.WithError(err).Warn("Course rating failed")
+		c.JSON(ratingErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	h.logger.Info("Course rated successfully")
+	c.JSON(http.StatusCreated, ratingResponse(rating))
+}
+
+func (h *RatingHandler) UpdateRating(c *gin.Context) {
+	var req requests.RatingRequest
+	if !h.bind(c, &req, "rating update") {
+		return
+	}
+
+	rating, err := h.usecase.Update(c.GetString("user_id"), c.Param("id"), ratingFromRequest(req))
+	if err != nil {
+		h.logger.WithError(err).Warn("Rating update failed")
+		c.JSON(ratingErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	h.logger.Info("Rating updated successfully")
+	c.JSON(http.StatusOK, ratingResponse(rating))
+}
+
+func (h *RatingHandler) ReplyToRating(c *gin.Context) {
+	var req requests.RatingReplyRequest
+	if !h.bind(c, &req, "rating reply") {
+		return
+	}
+
+	rating, err := h.usecase.Reply(c.GetString("user_id"), c.GetString("role"), c.Param("id"), req.Reply)
+	if err != nil {
+		h.logger.WithError(err).Warn("Rating reply failed")
+		c.JSON(ratingErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	h.logger.Info("Rating reply saved successfully")
+	c.JSON(http.StatusOK, ratingResponse(rating))
+}
+
+// ListExecutorRatings возвращает публичные отзывы об исполнителе.
+func (h *RatingHandler) ListExecutorRatings(c *gin.Context) {
+	h.listRatings(c, domain.RoleExecutor)
+}
+
+// ListCoachRatings возвращает публичные отзывы о коуче.
+func (h *RatingHandler) ListCoachRatings(c *gin.Context) {
+	h.listRatings(c, domain.RoleCoach)
+}
+
+func (h *RatingHandler) listRatings(c *gin.Context, targetRole string) {
+	var query requests.PageQuery
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.logger.WithError(err).Error("Invalid query for rating list")
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "invalid request format"})
+		return
+	}
+
+	ratings, err := h.usecase.ListForTarget(c.Param("id"), targetRole, query.Limit, query.Offset)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to list ratings")
+		c.JSON(http.StatusInternalServerError, responses.ErrorResponse{Error: "failed to list ratings"})
+		return
+	}
+
+	result := make([]responses.RatingResponse, 0, len(ratings))
+	for i := range ratings {
+		result = append(result, ratingResponse(&ratings[i]))
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// bind разбирает и валидирует тело запроса; при ошибке ответ уже отправлен.
+func (h *RatingHandler) bind(c *gin.Context, req interface{}, action string) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		h.logger.WithError(err).Errorf("Invalid request format for %s", action)
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "invalid request format"})
+		return false
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		h.logger.WithError(err).Warnf("Validation failed for %s", action)
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "validation failed", Details: utils.CustomValidationErrors(validationErrors)})
+		return false
+	}
+	return true
+}
+
+func ratingErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, usecase.ErrRatingNotFound), errors.Is(err, usecase.ErrOrderNotFound),
+		errors.Is(err, usecase.ErrCourseNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, usecase.ErrNotEnrolled):
+		return http.StatusForbidden
+	case errors.Is(err, usecase.ErrCourseNotPaid):
+		return http.StatusPaymentRequired
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+func ratingFromRequest(req requests.RatingRequest) *domain.Rating {
+	return &domain.Rating{
+		Score:         req.Score,
+		Quality:       req.Quality,
+		Deadlines:     req.Deadlines,
+		Communication: req.Communication,
+		Review:        req.Review,
+	}
+}
+
+func ratingResponse(rating *domain.Rating) responses.RatingResponse {
+	return responses.RatingResponse{
+		ID:            rating.ID,
+		OrderID:       rating.OrderID,
+		EnrollmentID:  rating.EnrollmentID,
+		TargetRole:    rating.TargetRole,
+		TargetID:      rating.TargetID,
+		Score:         rating.Score,
+		Quality:       rating.Quality,
+		Deadlines:     rating.Deadlines,
+		Communication: rating.Communication,
+		Review:        rating.Review,
+		Reply:         rating.Reply,
+		RepliedAt:     rating.RepliedAt,
+		CreatedAt:     rating.CreatedAt,
+	}
+}
+
+// ratingSummaryResponse округляет средние до сотых.
+func ratingSummaryResponse(stats domain.RatingStats) responses.RatingSummaryResponse {
+	round := func(v float64) float64 { return math.Round(v*100) / 100 }
+	return responses.RatingSummaryResponse{
+		Count:         stats.Count,
+		Average:       round(stats.Average),
+		Quality:       round(stats.Quality),
+		Deadlines:     round(stats.Deadlines),
+		Communication: round(stats.Communication),
+	}
+}
@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"net/http"
+
+	"BruhPro/internal/delivery/http/requests"
+	responses "BruhPro/internal/delivery/http/response"
+	"BruhPro/internal/domain"
+	"BruhPro/internal/usecase"
+	"BruhPro/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+// ResponseHandler обслуживает отклики исполнителей на заказы.
+type ResponseHandler struct {
+	usecase  *usecase.ResponseUsecase
+	validate *validator.Validate
+	logger   *logrus.Logger
+}
+
+func NewResponseHandler(u *usecase.ResponseUsecase, logger *logrus.Logger) *ResponseHandler {
+	return &ResponseHandler{
+		usecase:  u,
+		validate: utils.NewValidator(),
+		logger:   logger,
+	}
+}
+
+func (h *ResponseHandler) CreateResponse(c *gin.Context) {
+	var req requests.CreateResponseRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.WithError(err).Error("Invalid request format for order response")
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "invalid request format"})
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		h.logger.WithError(err).Warn("Validation failed for order response")
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "validation failed", Details: utils.CustomValidationErrors(validationErrors)})
+		return
+	}
+
+	response := &domain.Response{
+		CoverMessage:  req.CoverMessage,
+		Price:         req.Price,
+		EstimatedDays: req.EstimatedDays,
+	}
+	if err := h.usecase.Create(c.GetString("user_id"), c.Param("id"), response); err != nil {
+		h.logger.WithError(err).Warn("Order response failed")
+		c.JSON(orderErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	h.logger.Info("Order response created successfully")
+	c.JSON(http.StatusCreated, bidResponse(response))
+}
+
+// ListOrderResponses возвращает отклики на заказ его клиенту.
+func (h *ResponseHandler) ListOrderResponses(c *gin.Context) {
+	list, err := h.usecase.ListForOrder(c.GetString("user_id"), c.Param("id"))
+	if err != nil {
+		h.logger.WithError(err).Warn("Failed to list order responses")
+		c.JSON(orderErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, bidResponses(list))
+}
+
+// ListMyResponses возвращает отклики текущего исполнителя.
+func (h *ResponseHandler) ListMyResponses(c *gin.Context) {
+	list, err := h.usecase.ListByExecutor(c.GetString("user_id"))
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to list executor responses")
+		c.JSON(http.StatusInternalServerError, responses.ErrorResponse{Error: "failed to list responses"})
+		return
+	}
+
+	c.JSON(http.StatusOK, bidResponses(list))
+}
+
+func (h *ResponseHandler) ShortlistResponse(c *gin.Context) {
+	response, err := h.usecase.Shortlist(c.GetString("user_id"), c.Param("id"))
+	if err != nil {
+		h.logger.WithError(err).Warn("Response shortlist failed")
+		c.JSON(orderErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	h.logger.Info("Response shortlisted successfully")
+	c.JSON(http.StatusOK, bidResponse(response))
+}
+
+func (h *ResponseHandler) RejectResponse(c *gin.Context) {
+	response, err := h.usecase.Reject(c.GetString("user_id"), c.Param("id"))
+	if err != nil {
+		h.logger.WithError(err).Warn("Response rejection failed")
+		c.JSON(orderErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	h.logger.Info("Response rejected successfully")
+	c.JSON(http.StatusOK, bidResponse(response))
+}
+
+// AcceptResponse выбирает исполнителя и возвращает заказ, переведенный в работу.
+func (h *ResponseHandler) AcceptResponse(c *gin.Context) {
+	order, err := h.usecase.Accept(c.GetString("user_id"), c.Param("id"))
+	if err != nil {
+		h.logger.WithError(err).Warn("Response acceptance failed")
+		c.JSON(orderErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	h.logger.Info("Response accepted successfully")
+	c.JSON(http.StatusOK, orderResponse(order))
+}
+
+func bidResponse(response *domain.Response) responses.BidResponse {
+	return responses.BidResponse{
+		ID:            response.ID,
+		OrderID:       response.OrderID,
+		ExecutorID:    response.ExecutorID,
+		CoverMessage:  response.CoverMessage,
+		Price:         response.Price,
+		EstimatedDays: response.EstimatedDays,
+		Status:        response.Status,
+		CreatedAt:     response.CreatedAt,
+	}
+}
+
+func bidResponses(list []domain.Response) []responses.BidResponse {
+	result := make([]responses.BidResponse, 0, len(list))
+	for i := range list {
+		result = append(result, bidResponse(&list[i]))
+	}
+	return result
+}
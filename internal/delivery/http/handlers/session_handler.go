@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"net/http"
+
+	responses "BruhPro/internal/delivery/http/response"
+	"BruhPro/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// SessionHandler обслуживает /logout и /sessions для всех ролей.
+type SessionHandler struct {
+	usecase *usecase.AuthUsecase
+	logger  *logrus.Logger
+}
+
+func NewSessionHandler(u *usecase.AuthUsecase, logger *logrus.Logger) *SessionHandler {
+	return &SessionHandler{
+		usecase: u,
+		logger:  logger,
+	}
+}
+
+func (h *SessionHandler) Logout(c *gin.Context) {
+	userID := c.GetString("user_id")
+	sessionID := c.GetString("session_id")
+
+	if err := h.usecase.Logout(userID, sessionID); err != nil {
+		h.logger.WithError(err).Warn("Logout failed")
+		c.JSON(http.StatusInternalServerError, responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	h.logger.Info("Logged out successfully")
+	c.JSON(http.StatusOK, responses.AuthSuccessResponse{
+		Status:  "success",
+		Message: "logged out successfully",
+	})
+}
+
+func (h *SessionHandler) ListSessions(c *gin.Context) {
+	userID := c.GetString("user_id")
+	currentSessionID := c.GetString("session_id")
+
+	sessions, err := h.usecase.ListSessions(userID)
+	if err != nil {
+		h.logger.WithError(err).Warn("Failed to list sessions")
+		c.JSON(http.StatusInternalServerError, responses.ErrorResponse{Error: "failed to list sessions"})
+		return
+	}
+
+	result := make([]responses.SessionResponse, 0, len(sessions))
+	for _, s := range sessions {
+		result = append(result, responses.SessionResponse{
+			ID:         s.ID,
+			Role:       s.Role,
+			UserAgent:  s.UserAgent,
+			IP:         s.IP,
+			CreatedAt:  s.CreatedAt,
+			LastUsedAt: s.LastUsedAt,
+			Current:    s.ID == currentSessionID,
+		})
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *SessionHandler) RevokeSession(c *gin.Context) {
+	userID := c.GetString("user_id")
+
+	if err := h.usecase.RevokeSession(userID, c.Param("id")); err != nil {
+		h.logger.WithError(err).Warn("Session revocation failed")
+		c.JSON(http.StatusNotFound, responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	h.logger.Info("Session revoked successfully")
+	c.JSON(http.StatusOK, responses.AuthSuccessResponse{
+		Status:  "success",
+		Message: "session revoked successfully",
+	})
+}
+
+// clientInfo собирает данные устройства для сохранения в сессии.
+func clientInfo(c *gin.Context) usecase.ClientInfo {
+	return usecase.ClientInfo{
+		UserAgent: c.Request.UserAgent(),
+		IP:        c.ClientIP(),
+	}
+}
+
+// writeLoginResponse отправляет ответ на вход: пару токенов или MFA-челлендж.
+// Флаг tokens_issued в контексте говорит LoginProtection, что вход завершен и счетчик
+// неудачных попыток можно сбросить; MFA-челлендж вход еще не завершает.
+func writeLoginResponse(c *gin.Context, tokens *usecase.TokenPair) {
+	c.Set("tokens_issued", tokens.AccessToken != "")
+	c.JSON(http.StatusOK, responses.LoginResponse{
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		Roles:        tokens.Roles,
+		MFARequired:  tokens.MFARequired,
+		MFAToken:     tokens.MFAToken,
+	})
+}
@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+
+	"BruhPro/internal/delivery/http/requests"
+	responses "BruhPro/internal/delivery/http/response"
+	"BruhPro/internal/domain"
+	"BruhPro/internal/usecase"
+	"BruhPro/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+// specializationCode — допустимый код специализации: он попадает в URL и фильтры поиска.
+var specializationCode = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// SpecializationHandler отдает справочник специализаций и позволяет администратору вести его.
+type SpecializationHandler struct {
+	usecase  *usecase.SpecializationUsecase
+	validate *validator.Validate
+	logger   *logrus.Logger
+}
+
+func NewSpecializationHandler(u *usecase.SpecializationUsecase, logger *logrus.Logger) *SpecializationHandler {
+	return &SpecializationHandler{
+		usecase:  u,
+		validate: utils.NewValidator(),
+		logger:   logger,
+	}
+}
+
+// ListSpecializations возвращает включенные специализации для форм регистрации, заказов и поиска.
+func (h *SpecializationHandler) ListSpecializations(c *gin.Context) {
+	h.list(c, false)
+}
+
+// ListAllSpecializations возвращает весь справочник, включая выключенные специализации.
+func (h *SpecializationHandler) ListAllSpecializations(c *gin.Context) {
+	h.list(c, true)
+}
+
+func (h *SpecializationHandler) list(c *gin.Context, includeInactive bool) {
+	var query requests.ListSpecializationsQuery
+	if !h.bindQuery(c, &query) {
+		return
+	}
+
+	specializations, err := h.usecase.List(query.Role, includeInactive)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, responses.ErrorResponse{Error: "failed to list specializations"})
+		return
+	}
+
+	c.JSON(http.StatusOK, specializationResponses(specializations))
+}
+
+func (h *SpecializationHandler) CreateSpecialization(c *gin.Context) {
+	var req requests.SpecializationRequest
+	if !h.bind(c, &req) {
+		return
+	}
+	if !specializationCode.MatchString(req.Code) {
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "code must contain only lowercase latin letters, digits and underscores"})
+		return
+	}
+
+	specialization := &domain.Specialization{
+		Code:     req.Code,
+		Role:     req.Role,
+		NameRu:   req.NameRu,
+		NameKk:   req.NameKk,
+		Position: req.Position,
+		Active:   true,
+	}
+	if err := h.usecase.Create(specialization); err != nil {
+		c.JSON(specializationErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, specializationResponse(specialization))
+}
+
+func (h *SpecializationHandler) UpdateSpecialization(c *gin.Context) {
+	var req requests.UpdateSpecializationRequest
+	if !h.bind(c, &req) {
+		return
+	}
+
+	specialization, err := h.usecase.Update(c.Param("code"), &domain.Specialization{
+		NameRu:   req.NameRu,
+		NameKk:   req.NameKk,
+		Position: req.Position,
+		Active:   *req.Active,
+	})
+	if err != nil {
+		c.JSON(specializationErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, specializationResponse(specialization))
+}
+
+func (h *SpecializationHandler) DeleteSpecialization(c *gin.Context) {
+	if err := h.usecase.Delete(c.Param("code")); err != nil {
+		c.JSON(specializationErrorStatus(err), responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *SpecializationHandler) bind(c *gin.Context, req interface{}) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		h.logger.WithError(err).Error("Invalid request format for specialization")
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "invalid request format"})
+		return false
+	}
+	return h.validateRequest(c, req)
+}
+
+func (h *SpecializationHandler) bindQuery(c *gin.Context, query interface{}) bool {
+	if err := c.ShouldBindQuery(query); err != nil {
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "invalid request format"})
+		return false
+	}
+	return h.validateRequest(c, query)
+}
+
+func (h *SpecializationHandler) validateRequest(c *gin.Context, req interface{}) bool {
+	if err := h.validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		h.logger.WithError(err).Warn("Validation failed for specialization")
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "validation failed", Details: utils.CustomValidationErrors(validationErrors)})
+		return false
+	}
+	return true
+}
+
+func specializationErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, usecase.ErrSpecializationNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, usecase.ErrSpecializationExists), errors.Is(err, usecase.ErrSpecializationInUse):
+		return http.StatusConflict
+	case errors.Is(err, usecase.ErrUnknownSpecialization):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func specializationResponse(specialization *domain.Specialization) responses.SpecializationResponse {
+	return responses.SpecializationResponse{
+		Code:     specialization.Code,
+		Role:     specialization.Role,
+		NameRu:   specialization.NameRu,
+		NameKk:   specialization.NameKk,
+		Position: specialization.Position,
+		Active:   specialization.Active,
+	}
+}
+
+func specializationResponses(specializations []domain.Specialization) []responses.SpecializationResponse {
+	result := make([]responses.SpecializationResponse, 0, len(specializations))
+	for i := range specializations {
+		result = append(result, specializationResponse(&specializations[i]))
+	}
+	return result
+}
+
+// specializationsFromCodes готовит ссылки профиля на справочник по кодам из запроса.
+func specializationsFromCodes(codes []string) []domain.Specialization {
+	specializations := make([]domain.Specialization, 0, len(codes))
+	seen := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		if !seen[code] {
+			seen[code] = true
+			specializations = append(specializations, domain.Specialization{Code: code})
+		}
+	}
+	return specializations
+}
+
+// registrationErrorStatus — неизвестная специализация это ошибка запроса, остальное — конфликт с аккаунтом.
+func registrationErrorStatus(err error) int {
+	if errors.Is(err, usecase.ErrUnknownSpecialization) {
+		return http.StatusBadRequest
+	}
+	return http.StatusConflict
+}
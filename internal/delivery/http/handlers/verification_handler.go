@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"net/http"
+
+	"BruhPro/internal/delivery/http/requests"
+	responses "BruhPro/internal/delivery/http/response"
+	"BruhPro/internal/usecase"
+	"BruhPro/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+// VerificationHandler обслуживает подтверждение email для всех ролей.
+type VerificationHandler struct {
+	usecase  *usecase.EmailVerificationUsecase
+	validate *validator.Validate
+	logger   *logrus.Logger
+}
+
+func NewVerificationHandler(u *usecase.EmailVerificationUsecase, logger *logrus.Logger) *VerificationHandler {
+	return &VerificationHandler{
+		usecase:  u,
+		validate: utils.NewValidator(),
+		logger:   logger,
+	}
+}
+
+func (h *VerificationHandler) VerifyEmail(c *gin.Context) {
+	var req requests.VerifyEmailRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.WithError(err).Error("Invalid request format for email verification")
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "invalid request format"})
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		h.logger.WithError(err).Warn("Validation failed for email verification")
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: "validation failed", Details: utils.CustomValidationErrors(validationErrors)})
+		return
+	}
+
+	if err := h.usecase.VerifyEmail(req.Token); err != nil {
+		h.logger.WithError(err).Warn("Email verification failed")
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	h.logger.Info("Email verified successfully")
+	c.JSON(http.StatusOK, responses.AuthSuccessResponse{
+		Status:  "success",
+		Message: "email verified successfully",
+	})
+}
+
+func (h *VerificationHandler) ResendVerification(c *gin.Context) {
+	userID := c.GetString("user_id")
+	role := c.GetString("role")
+
+	if err := h.usecase.ResendVerification(userID, role); err != nil {
+		h.logger.WithError(err).Warn("Verification resend failed")
+		c.JSON(http.StatusBadRequest, responses.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	h.logger.Info("Verification email resent")
+	c.JSON(http.StatusOK, responses.AuthSuccessResponse{
+		Status:  "success",
+		Message: "verification email sent",
+	})
+}
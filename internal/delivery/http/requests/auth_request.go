@@ -1,11 +1,23 @@
 package requests
 
+import (
+	"BruhPro/internal/iin"
+	"BruhPro/internal/phone"
+)
+
 // AuthRequest представляет общую структуру для запросов на аутентификацию (регистрация/логин).
 type AuthRequest struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required,min=8"`
 }
 
+// LoginRequest представляет запрос на общий логин аккаунта.
+// Если роль не указана, токен выдается для роли user.
+type LoginRequest struct {
+	AuthRequest
+	Role string `json:"role" validate:"omitempty,oneof=user customer coach executor admin"`
+}
+
 // RefreshRequest представляет структуру для запроса на обновление токена.
 type RefreshRequest struct {
 	RefreshToken string `json:"refresh_token" validate:"required"`
@@ -16,45 +28,89 @@ type RefreshRequest struct {
 // Если вы хотите, чтобы все поля передавались с фронтенда, раскомментируйте их.
 type CustomerRegisterRequest struct {
 	AuthRequest
-	ClientType      string  `json:"client_type" validate:"required"`
-	CompanyName     string  `json:"company_name" validate:"required"`
-	IIN             float64 `json:"iin" validate:"required"`
-	Name            string  `json:"name" validate:"required"`
-	JobPosition     string  `json:"job_position" validate:"required"`
-	PhoneNumber     float64 `json:"phone_number" validate:"required"`
-	Address         string  `json:"address" validate:"required"`
-	WorkDescription string  `json:"work_description" validate:"required"`
+	ClientType      string       `json:"client_type" validate:"required"`
+	CompanyName     string       `json:"company_name" validate:"required"`
+	IIN             iin.Number   `json:"iin" validate:"required,iin_or_bin,bin_if=ClientType ТОО"`
+	Name            string       `json:"name" validate:"required"`
+	JobPosition     string       `json:"job_position" validate:"required"`
+	PhoneNumber     phone.Number `json:"phone_number" validate:"required,phone"`
+	Address         string       `json:"address" validate:"required"`
+	WorkDescription string       `json:"work_description" validate:"required"`
 }
 
 // CoachRegisterRequest представляет структуру для регистрации коуча.
 // Расширяет AuthRequest для включения специфичных полей Coach.
 type CoachRegisterRequest struct {
 	AuthRequest
-	Name                   string  `json:"name" validate:"required"`
-	Surname                string  `json:"surname" validate:"required"`
-	PhoneNumber            float64 `json:"phone_number" validate:"required"`
-	ExpCoach               string  `json:"exp_coach" validate:"required"`
-	Specializations        string  `json:"specializations" validate:"required"`
-	EducationCertificates  string  `json:"education_certificates" validate:"required"`
-	AchievementsExperience string  `json:"achievements_experience" validate:"required"`
-	Methodology            string  `json:"methodology" validate:"required"`
-	AboutCoach             string  `json:"about_coach" validate:"required"`
+	Name                   string       `json:"name" validate:"required"`
+	Surname                string       `json:"surname" validate:"required"`
+	PhoneNumber            phone.Number `json:"phone_number" validate:"required,phone"`
+	ExpCoach               string       `json:"exp_coach" validate:"required"`
+	Specializations        []string     `json:"specializations" validate:"required,min=1,max=10,dive,required"` // коды из справочника
+	EducationCertificates  string       `json:"education_certificates" validate:"required"`
+	AchievementsExperience string       `json:"achievements_experience" validate:"required"`
+	Methodology            string       `json:"methodology" validate:"required"`
+	AboutCoach             string       `json:"about_coach" validate:"required"`
 }
 
 // ExecutorRegisterRequest представляет структуру для регистрации исполнителя.
 // Расширяет AuthRequest для включения специфичных полей Executor.
 type ExecutorRegisterRequest struct {
 	AuthRequest
-	Name            string  `json:"name" validate:"required"`
-	Surname         string  `json:"surname" validate:"required"`
-	Patronymic      string  `json:"patronymic" validate:"required"`
-	IIN             float64 `json:"iin" validate:"required"`
-	PhoneNumber     float64 `json:"phone_number" validate:"required"`
-	City            string  `json:"city" validate:"required"`
-	ExpWork         string  `json:"exp_work" validate:"required"`
-	Specializations string  `json:"specializations" validate:"required"`
-	Education       string  `json:"education" validate:"required"`
-	WorkFormat      string  `json:"work_format" validate:"required"`
-	HourlyRate      float64 `json:"hourly_rate" validate:"required"`
-	AboutExecutor   string  `json:"about_executor" validate:"required"`
+	Name            string       `json:"name" validate:"required"`
+	Surname         string       `json:"surname" validate:"required"`
+	Patronymic      string       `json:"patronymic" validate:"required"`
+	IIN             iin.Number   `json:"iin" validate:"required,iin"`
+	PhoneNumber     phone.Number `json:"phone_number" validate:"required,phone"`
+	City            string       `json:"city" validate:"required"`
+	ExpWork         string       `json:"exp_work" validate:"required"`
+	Specializations []string     `json:"specializations" validate:"required,min=1,max=10,dive,required"` // коды из справочника
+	Education       string       `json:"education" validate:"required"`
+	WorkFormat      string       `json:"work_format" validate:"required"`
+	HourlyRate      float64      `json:"hourly_rate" validate:"required"`
+	AboutExecutor   string       `json:"about_executor" validate:"required"`
+}
+
+// ForgotPasswordRequest представляет запрос на отправку ссылки для сброса пароля.
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// ResetPasswordRequest представляет запрос на установку нового пароля по токену из письма.
+type ResetPasswordRequest struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required,min=8"`
+}
+
+// VerifyEmailRequest представляет запрос на подтверждение email по токену из письма.
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// MFACodeRequest представляет код из приложения-аутентификатора или код восстановления.
+type MFACodeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+// MFALoginRequest представляет второй шаг входа при включенной 2FA.
+type MFALoginRequest struct {
+	MFAToken string `json:"mfa_token" validate:"required"`
+	Code     string `json:"code" validate:"required"`
+}
+
+// ChangePasswordRequest представляет запрос на смену пароля из личного кабинета.
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,min=8"`
+}
+
+// ChangeEmailRequest представляет запрос на смену email; текущий пароль подтверждает владельца.
+type ChangeEmailRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
+// EmailChangeTokenRequest представляет токен из письма о смене email.
+type EmailChangeTokenRequest struct {
+	Token string `json:"token" validate:"required"`
 }
@@ -0,0 +1,11 @@
+package requests
+
+// SearchCoachesQuery представляет фильтры, сортировку и курсор публичного поиска коучей.
+// Q — полнотекстовый запрос на русском или казахском.
+type SearchCoachesQuery struct {
+	Q              string `form:"q" validate:"max=200"`
+	Specialization string `form:"specialization"`
+	Sort           string `form:"sort" validate:"omitempty,oneof=relevance rating recent"`
+	Cursor         string `form:"cursor"`
+	Limit          int    `form:"limit" validate:"omitempty,min=1,max=100"`
+}
@@ -0,0 +1,39 @@
+package requests
+
+// CourseRequest представляет структуру для создания и редактирования курса.
+// Price передается в тиынах; 0 — бесплатный курс.
+type CourseRequest struct {
+	Title          string `json:"title" validate:"required,max=200"`
+	Description    string `json:"description" validate:"required"`
+	Specialization string `json:"specialization" validate:"required"`
+	Price          int64  `json:"price" validate:"min=0"`
+}
+
+// CourseModuleRequest представляет раздел курса.
+type CourseModuleRequest struct {
+	Title    string `json:"title" validate:"required,max=200"`
+	Position int    `json:"position" validate:"min=0"`
+}
+
+// LessonRequest представляет урок: текст, ссылку на видео и файлы.
+type LessonRequest struct {
+	Title       string                    `json:"title" validate:"required,max=200"`
+	Content     string                    `json:"content"`
+	VideoURL    string                    `json:"video_url" validate:"omitempty,url"`
+	Position    int                       `json:"position" validate:"min=0"`
+	Attachments []LessonAttachmentRequest `json:"attachments" validate:"max=20,dive"`
+}
+
+// LessonAttachmentRequest представляет файл урока.
+type LessonAttachmentRequest struct {
+	FileName string `json:"file_name" validate:"required,max=255"`
+	URL      string `json:"url" validate:"required,url"`
+}
+
+// ListCoursesQuery представляет фильтры и страницу каталога курсов.
+type ListCoursesQuery struct {
+	Specialization string `form:"specialization"`
+	CoachID        string `form:"coach_id" validate:"omitempty,uuid"`
+	Limit          int    `form:"limit" validate:"omitempty,min=1,max=100"`
+	Offset         int    `form:"offset" validate:"omitempty,min=0"`
+}
@@ -0,0 +1,16 @@
+package requests
+
+// SearchExecutorsQuery представляет фильтры, сортировку и курсор публичного поиска исполнителей.
+// Q — полнотекстовый запрос на русском или казахском; MinRate и MaxRate — почасовая ставка в тенге, как в профиле.
+type SearchExecutorsQuery struct {
+	Q              string  `form:"q" validate:"max=200"`
+	Specialization string  `form:"specialization"`
+	City           string  `form:"city"`
+	WorkFormat     string  `form:"work_format"`
+	Experience     string  `form:"experience" validate:"omitempty,oneof=under_1 1_3 3_5 over_5"`
+	MinRate        float64 `form:"min_rate" validate:"omitempty,gte=0"`
+	MaxRate        float64 `form:"max_rate" validate:"omitempty,gte=0"`
+	Sort           string  `form:"sort" validate:"omitempty,oneof=relevance rating price_asc price_desc recent"`
+	Cursor         string  `form:"cursor"`
+	Limit          int     `form:"limit" validate:"omitempty,min=1,max=100"`
+}
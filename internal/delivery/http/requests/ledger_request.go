@@ -0,0 +1,14 @@
+package requests
+
+import "time"
+
+// LedgerBalanceQuery представляет момент, на который считается сальдо (RFC 3339, по умолчанию — сейчас).
+type LedgerBalanceQuery struct {
+	At time.Time `form:"at"`
+}
+
+// LedgerPeriodQuery представляет период [from, to) в RFC 3339; по умолчанию — последние сутки.
+type LedgerPeriodQuery struct {
+	From time.Time `form:"from"`
+	To   time.Time `form:"to"`
+}
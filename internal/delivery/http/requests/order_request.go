@@ -0,0 +1,47 @@
+package requests
+
+import "time"
+
+// OrderRequest представляет структуру для создания и редактирования заказа.
+// Budget передается в тиынах: бюджет всей работы для fixed или ставка в час для hourly.
+type OrderRequest struct {
+	Title          string     `json:"title" validate:"required,max=200"`
+	Description    string     `json:"description" validate:"required"`
+	Specialization string     `json:"specialization" validate:"required"`
+	PaymentMode    string     `json:"payment_mode" validate:"required,oneof=fixed hourly"`
+	Budget         int64      `json:"budget" validate:"required,gt=0"`
+	Deadline       *time.Time `json:"deadline"`
+	WorkFormat     string     `json:"work_format" validate:"required"`
+	City           string     `json:"city" validate:"required"`
+	Publish        bool       `json:"publish"` // опубликовать сразу, минуя черновик
+}
+
+// OrderTransitionRequest представляет запрос на смену статуса заказа.
+type OrderTransitionRequest struct {
+	Status string `json:"status" validate:"required,oneof=draft published on_review completed in_progress cancelled disputed"`
+	Reason string `json:"reason" validate:"max=1000"`
+}
+
+// ListOrdersQuery представляет фильтры и страницу списка заказов.
+type ListOrdersQuery struct {
+	Specialization string `form:"specialization"`
+	City           string `form:"city"`
+	WorkFormat     string `form:"work_format"`
+	Limit          int    `form:"limit" validate:"omitempty,min=1,max=100"`
+	Offset         int    `form:"offset" validate:"omitempty,min=0"`
+}
+
+// CreateResponseRequest представляет отклик исполнителя на заказ.
+// Price в тиынах; для почасового заказа можно не указывать — подставится ставка из профиля.
+type CreateResponseRequest struct {
+	CoverMessage  string `json:"cover_message" validate:"required,max=5000"`
+	Price         int64  `json:"price" validate:"omitempty,gt=0"`
+	EstimatedDays int    `json:"estimated_days" validate:"required,min=1,max=365"`
+}
+
+// MilestoneRequest представляет этап заказа. Amount в тиынах.
+type MilestoneRequest struct {
+	Title    string `json:"title" validate:"required,max=200"`
+	Amount   int64  `json:"amount" validate:"required,gt=0"`
+	Position int    `json:"position" validate:"min=0"`
+}
@@ -0,0 +1,12 @@
+package requests
+
+// RefundRequest представляет возврат платежа. Amount в тиынах; без суммы возвращается весь остаток.
+type RefundRequest struct {
+	Amount int64 `json:"amount" validate:"omitempty,gt=0"`
+}
+
+// FakeCheckoutRequest имитирует результат оплаты на странице локального провайдера.
+type FakeCheckoutRequest struct {
+	Status string `json:"status" validate:"required,oneof=authorized failed"`
+	Reason string `json:"reason" validate:"max=500"`
+}
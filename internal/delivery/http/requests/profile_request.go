@@ -0,0 +1,62 @@
+package requests
+
+import (
+	"encoding/json"
+
+	"BruhPro/internal/phone"
+)
+
+// Запросы частичного изменения профиля: поле, которого нет в JSON, не меняется.
+// Version — версия профиля из последнего GET /{role}/me; если профиль с тех пор изменили,
+// запрос отклоняется. Email и ИИН меняются только через отдельные подтверждаемые сценарии,
+// поэтому их наличие в запросе — ошибка валидации.
+
+// UpdateCustomerProfileRequest представляет запрос на изменение профиля клиента.
+type UpdateCustomerProfileRequest struct {
+	Version         int64         `json:"version" validate:"required,min=1"`
+	CompanyName     *string       `json:"company_name" validate:"omitnil,min=1,max=255"`
+	Name            *string       `json:"name" validate:"omitnil,min=1,max=255"`
+	JobPosition     *string       `json:"job_position" validate:"omitnil,min=1,max=255"`
+	PhoneNumber     *phone.Number `json:"phone_number" validate:"omitnil,phone"`
+	Address         *string       `json:"address" validate:"omitnil,min=1,max=500"`
+	WorkDescription *string       `json:"work_description" validate:"omitnil,min=1,max=5000"`
+
+	Email      json.RawMessage `json:"email" validate:"isdefault"`
+	IIN        json.RawMessage `json:"iin" validate:"isdefault"`
+	ClientType json.RawMessage `json:"client_type" validate:"isdefault"`
+}
+
+// UpdateCoachProfileRequest представляет запрос на изменение профиля коуча.
+type UpdateCoachProfileRequest struct {
+	Version                int64         `json:"version" validate:"required,min=1"`
+	Name                   *string       `json:"name" validate:"omitnil,min=1,max=255"`
+	Surname                *string       `json:"surname" validate:"omitnil,min=1,max=255"`
+	PhoneNumber            *phone.Number `json:"phone_number" validate:"omitnil,phone"`
+	ExpCoach               *string       `json:"exp_coach" validate:"omitnil,min=1,max=255"`
+	Specializations        []string      `json:"specializations" validate:"omitnil,min=1,max=10,dive,required"` // коды из справочника, заменяют текущие
+	EducationCertificates  *string       `json:"education_certificates" validate:"omitnil,min=1,max=5000"`
+	AchievementsExperience *string       `json:"achievements_experience" validate:"omitnil,min=1,max=5000"`
+	Methodology            *string       `json:"methodology" validate:"omitnil,min=1,max=5000"`
+	AboutCoach             *string       `json:"about_coach" validate:"omitnil,min=1,max=5000"`
+
+	Email json.RawMessage `json:"email" validate:"isdefault"`
+}
+
+// UpdateExecutorProfileRequest представляет запрос на изменение профиля исполнителя.
+type UpdateExecutorProfileRequest struct {
+	Version         int64         `json:"version" validate:"required,min=1"`
+	Name            *string       `json:"name" validate:"omitnil,min=1,max=255"`
+	Surname         *string       `json:"surname" validate:"omitnil,min=1,max=255"`
+	Patronymic      *string       `json:"patronymic" validate:"omitnil,max=255"`
+	PhoneNumber     *phone.Number `json:"phone_number" validate:"omitnil,phone"`
+	City            *string       `json:"city" validate:"omitnil,min=1,max=255"`
+	ExpWork         *string       `json:"exp_work" validate:"omitnil,min=1,max=255"`
+	Specializations []string      `json:"specializations" validate:"omitnil,min=1,max=10,dive,required"` // коды из справочника, заменяют текущие
+	Education       *string       `json:"education" validate:"omitnil,min=1,max=5000"`
+	WorkFormat      *string       `json:"work_format" validate:"omitnil,min=1"`
+	HourlyRate      *float64      `json:"hourly_rate" validate:"omitnil,gt=0"`
+	AboutExecutor   *string       `json:"about_executor" validate:"omitnil,min=1,max=5000"`
+
+	Email json.RawMessage `json:"email" validate:"isdefault"`
+	IIN   json.RawMessage `json:"iin" validate:"isdefault"`
+}
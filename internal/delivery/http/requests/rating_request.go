@@ -0,0 +1,21 @@
+package requests
+
+// RatingRequest представляет оценку заказа или курса: общая оценка и подоценки от 1 до 5.
+type RatingRequest struct {
+	Score         int    `json:"score" validate:"required,min=1,max=5"`
+	Quality       int    `json:"quality" validate:"required,min=1,max=5"`
+	Deadlines     int    `json:"deadlines" validate:"required,min=1,max=5"`
+	Communication int    `json:"communication" validate:"required,min=1,max=5"`
+	Review        string `json:"review" validate:"max=5000"`
+}
+
+// RatingReplyRequest представляет публичный ответ исполнителя или коуча на отзыв.
+type RatingReplyRequest struct {
+	Reply string `json:"reply" validate:"required,max=2000"`
+}
+
+// PageQuery представляет страницу списка.
+type PageQuery struct {
+	Limit  int `form:"limit" validate:"omitempty,min=1,max=100"`
+	Offset int `form:"offset" validate:"omitempty,min=0"`
+}
@@ -0,0 +1,24 @@
+package requests
+
+// SpecializationRequest представляет новую специализацию справочника.
+// Code — латиница в нижнем регистре, цифры и подчеркивание; после создания не меняется.
+type SpecializationRequest struct {
+	Code     string `json:"code" validate:"required,max=64"`
+	Role     string `json:"role" validate:"required,oneof=executor coach"`
+	NameRu   string `json:"name_ru" validate:"required,max=200"`
+	NameKk   string `json:"name_kk" validate:"required,max=200"`
+	Position int    `json:"position" validate:"min=0"`
+}
+
+// UpdateSpecializationRequest представляет изменение специализации справочника.
+type UpdateSpecializationRequest struct {
+	NameRu   string `json:"name_ru" validate:"required,max=200"`
+	NameKk   string `json:"name_kk" validate:"required,max=200"`
+	Position int    `json:"position" validate:"min=0"`
+	Active   *bool  `json:"active" validate:"required"`
+}
+
+// ListSpecializationsQuery представляет фильтр справочника по роли.
+type ListSpecializationsQuery struct {
+	Role string `form:"role" validate:"omitempty,oneof=executor coach"`
+}
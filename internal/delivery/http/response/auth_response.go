@@ -1,5 +1,7 @@
 package responses
 
+import "time"
+
 // AuthSuccessResponse представляет успешный ответ на регистрацию/логин.
 type AuthSuccessResponse struct {
 	Status  string `json:"status"`
@@ -7,14 +9,33 @@ type AuthSuccessResponse struct {
 }
 
 // LoginResponse представляет ответ на успешный логин.
+// Roles содержит все роли аккаунта, чтобы клиент мог предложить переключение между ними.
+// Если у аккаунта включена 2FA, токенов нет: клиент получает mfa_token и отправляет его
+// вместе с кодом из приложения на /login/mfa.
 type LoginResponse struct {
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
+	AccessToken  string   `json:"access_token,omitempty"`
+	RefreshToken string   `json:"refresh_token,omitempty"`
+	Roles        []string `json:"roles,omitempty"`
+	MFARequired  bool     `json:"mfa_required,omitempty"`
+	MFAToken     string   `json:"mfa_token,omitempty"`
 }
 
 // TokenRefreshResponse представляет ответ на успешное обновление токена.
+// Старый refresh token после обмена недействителен, клиент должен сохранить новый.
 type TokenRefreshResponse struct {
-	AccessToken string `json:"access_token"`
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// MFAEnrollResponse представляет секрет TOTP и URI для QR-кода приложения-аутентификатора.
+type MFAEnrollResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// RecoveryCodesResponse представляет коды восстановления, которые показываются один раз.
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
 }
 
 // ErrorResponse представляет стандартизированный ответ для ошибок.
@@ -23,55 +44,95 @@ type ErrorResponse struct {
 	Details interface{} `json:"details,omitempty"`
 }
 
-// UserProfileResponse представляет информацию профиля обычного пользователя.
+// UserProfileResponse представляет информацию об аккаунте и его ролях.
 type UserProfileResponse struct {
-	ID    string `json:"id"`
-	Email string `json:"email"`
+	ID            string   `json:"id"`
+	Email         string   `json:"email"`
+	EmailVerified bool     `json:"email_verified"`
+	Roles         []string `json:"roles"`
 }
 
 // CustomerProfileResponse представляет информацию профиля клиента.
 type CustomerProfileResponse struct {
-	ID              string  `json:"id"`
-	ClientType      string  `json:"client_type"`
-	CompanyName     string  `json:"company_name"`
-	IIN             float64 `json:"iin"`
-	Name            string  `json:"name"`
-	JobPosition     string  `json:"job_position"`
-	PhoneNumber     float64 `json:"phone_number"`
-	Email           string  `json:"email"`
-	Address         string  `json:"address"`
-	WorkDescription string  `json:"work_description"`
+	ID              string    `json:"id"`
+	ClientType      string    `json:"client_type"`
+	CompanyName     string    `json:"company_name"`
+	IIN             string    `json:"iin"`
+	Name            string    `json:"name"`
+	JobPosition     string    `json:"job_position"`
+	PhoneNumber     string    `json:"phone_number"` // E.164
+	PhoneFormatted  string    `json:"phone_formatted"`
+	Email           string    `json:"email"`
+	EmailVerified   bool      `json:"email_verified"`
+	Address         string    `json:"address"`
+	WorkDescription string    `json:"work_description"`
+	Version         int64     `json:"version"` // передается обратно в PATCH /{role}/me
+	UpdatedAt       time.Time `json:"updated_at"`
 }
 
 // CoachProfileResponse представляет информацию профиля коуча.
 type CoachProfileResponse struct {
-	ID                     string  `json:"id"`
-	Name                   string  `json:"name"`
-	Surname                string  `json:"surname"`
-	PhoneNumber            float64 `json:"phone_number"`
-	Email                  string  `json:"email"`
-	ExpCoach               string  `json:"exp_coach"`
-	Specializations        string  `json:"specializations"`
-	EducationCertificates  string  `json:"education_certificates"`
-	AchievementsExperience string  `json:"achievements_experience"`
-	Methodology            string  `json:"methodology"`
-	AboutCoach             string  `json:"about_coach"`
+	ID                     string                   `json:"id"`
+	Name                   string                   `json:"name"`
+	Surname                string                   `json:"surname"`
+	PhoneNumber            string                   `json:"phone_number"` // E.164
+	PhoneFormatted         string                   `json:"phone_formatted"`
+	Email                  string                   `json:"email"`
+	EmailVerified          bool                     `json:"email_verified"`
+	ExpCoach               string                   `json:"exp_coach"`
+	Specializations        []SpecializationResponse `json:"specializations"`
+	EducationCertificates  string                   `json:"education_certificates"`
+	AchievementsExperience string                   `json:"achievements_experience"`
+	Methodology            string                   `json:"methodology"`
+	AboutCoach             string                   `json:"about_coach"`
+	Version                int64                    `json:"version"` // передается обратно в PATCH /{role}/me
+	UpdatedAt              time.Time                `json:"updated_at"`
+
+	Rating RatingSummaryResponse `json:"rating"`
 }
 
 // ExecutorProfileResponse представляет информацию профиля исполнителя.
 type ExecutorProfileResponse struct {
-	ID              string  `json:"id"`
-	Name            string  `json:"name"`
-	Surname         string  `json:"surname"`
-	Patronymic      string  `json:"patronymic"`
-	IIN             float64 `json:"iin"`
-	PhoneNumber     float64 `json:"phone_number"`
-	Email           string  `json:"email"`
-	City            string  `json:"city"`
-	ExpWork         string  `json:"exp_work"`
-	Specializations string  `json:"specializations"`
-	Education       string  `json:"education"`
-	WorkFormat      string  `json:"work_format"`
-	HourlyRate      float64 `json:"hourly_rate"`
-	AboutExecutor   string  `json:"about_executor"`
+	ID              string                   `json:"id"`
+	Name            string                   `json:"name"`
+	Surname         string                   `json:"surname"`
+	Patronymic      string                   `json:"patronymic"`
+	IIN             string                   `json:"iin"`
+	BirthDate       string                   `json:"birth_date,omitempty"` // из ИИН, ГГГГ-ММ-ДД
+	Gender          string                   `json:"gender,omitempty"`     // из ИИН: male или female
+	PhoneNumber     string                   `json:"phone_number"`         // E.164
+	PhoneFormatted  string                   `json:"phone_formatted"`
+	Email           string                   `json:"email"`
+	EmailVerified   bool                     `json:"email_verified"`
+	City            string                   `json:"city"`
+	ExpWork         string                   `json:"exp_work"`
+	Specializations []SpecializationResponse `json:"specializations"`
+	Education       string                   `json:"education"`
+	WorkFormat      string                   `json:"work_format"`
+	HourlyRate      float64                  `json:"hourly_rate"`
+	AboutExecutor   string                   `json:"about_executor"`
+	Version         int64                    `json:"version"` // передается обратно в PATCH /{role}/me
+	UpdatedAt       time.Time                `json:"updated_at"`
+
+	Rating RatingSummaryResponse `json:"rating"`
+}
+
+// ProfileChangeResponse представляет запись истории изменений профиля.
+type ProfileChangeResponse struct {
+	Field     string    `json:"field"`
+	OldValue  string    `json:"old_value"`
+	NewValue  string    `json:"new_value"`
+	Version   int64     `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SessionResponse представляет активную сессию аккаунта.
+type SessionResponse struct {
+	ID         string    `json:"id"`
+	Role       string    `json:"role"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	Current    bool      `json:"current"`
 }
@@ -0,0 +1,28 @@
+package responses
+
+import "time"
+
+// CoachPublicResponse представляет публичную карточку коуча без email и телефона.
+type CoachPublicResponse struct {
+	ID                     string                   `json:"id"`
+	Name                   string                   `json:"name"`
+	Surname                string                   `json:"surname"`
+	ExpCoach               string                   `json:"exp_coach"`
+	Specializations        []SpecializationResponse `json:"specializations"`
+	EducationCertificates  string                   `json:"education_certificates"`
+	AchievementsExperience string                   `json:"achievements_experience"`
+	Methodology            string                   `json:"methodology"`
+	AboutCoach             string                   `json:"about_coach"`
+	CreatedAt              time.Time                `json:"created_at"`
+
+	Rating RatingSummaryResponse `json:"rating"`
+
+	Search *SearchMatchResponse `json:"search,omitempty"`
+}
+
+// CoachSearchResponse представляет страницу поиска коучей.
+// NextCursor передается в cursor для следующей страницы; пустой, если страниц больше нет.
+type CoachSearchResponse struct {
+	Items      []CoachPublicResponse `json:"items"`
+	NextCursor string                `json:"next_cursor,omitempty"`
+}
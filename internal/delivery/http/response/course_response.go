@@ -0,0 +1,69 @@
+package responses
+
+import "time"
+
+// CourseResponse представляет курс коуча. Price указан в тиынах.
+// Modules заполняется только в карточке курса, в списках программа не выводится.
+type CourseResponse struct {
+	ID             string                 `json:"id"`
+	CoachID        string                 `json:"coach_id"`
+	Title          string                 `json:"title"`
+	Description    string                 `json:"description"`
+	Specialization string                 `json:"specialization"`
+	Price          int64                  `json:"price"`
+	Status         string                 `json:"status"`
+	PublishedAt    *time.Time             `json:"published_at,omitempty"`
+	Modules        []CourseModuleResponse `json:"modules,omitempty"`
+	CreatedAt      time.Time              `json:"created_at"`
+	UpdatedAt      time.Time              `json:"updated_at"`
+}
+
+// CourseModuleResponse представляет раздел курса с оглавлением уроков.
+type CourseModuleResponse struct {
+	ID       string                  `json:"id"`
+	Title    string                  `json:"title"`
+	Position int                     `json:"position"`
+	Lessons  []LessonSummaryResponse `json:"lessons"`
+}
+
+// LessonSummaryResponse представляет урок в программе курса без содержимого.
+type LessonSummaryResponse struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Position int    `json:"position"`
+	HasVideo bool   `json:"has_video"`
+}
+
+// LessonResponse представляет урок целиком; доступен коучу и записавшимся на курс.
+type LessonResponse struct {
+	ID          string                     `json:"id"`
+	ModuleID    string                     `json:"module_id"`
+	CourseID    string                     `json:"course_id"`
+	Title       string                     `json:"title"`
+	Content     string                     `json:"content,omitempty"`
+	VideoURL    string                     `json:"video_url,omitempty"`
+	Position    int                        `json:"position"`
+	Attachments []LessonAttachmentResponse `json:"attachments"`
+}
+
+// LessonAttachmentResponse представляет файл урока.
+type LessonAttachmentResponse struct {
+	FileName string `json:"file_name"`
+	URL      string `json:"url"`
+}
+
+// EnrollmentResponse представляет запись на курс.
+type EnrollmentResponse struct {
+	ID        string    `json:"id"`
+	CourseID  string    `json:"course_id"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CourseProgressResponse представляет прогресс прохождения курса.
+type CourseProgressResponse struct {
+	Enrollment       EnrollmentResponse `json:"enrollment"`
+	CompletedLessons []string           `json:"completed_lessons"`
+	TotalLessons     int                `json:"total_lessons"`
+	Percent          int                `json:"percent"`
+}
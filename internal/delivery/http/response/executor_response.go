@@ -0,0 +1,38 @@
+package responses
+
+import "time"
+
+// ExecutorPublicResponse представляет публичную карточку исполнителя.
+// В отличие от ExecutorProfileResponse не содержит ИИН, email и телефон.
+type ExecutorPublicResponse struct {
+	ID              string                   `json:"id"`
+	Name            string                   `json:"name"`
+	Surname         string                   `json:"surname"`
+	Patronymic      string                   `json:"patronymic"`
+	City            string                   `json:"city"`
+	ExpWork         string                   `json:"exp_work"`
+	Specializations []SpecializationResponse `json:"specializations"`
+	Education       string                   `json:"education"`
+	WorkFormat      string                   `json:"work_format"`
+	HourlyRate      float64                  `json:"hourly_rate"`
+	AboutExecutor   string                   `json:"about_executor"`
+	CreatedAt       time.Time                `json:"created_at"`
+
+	Rating RatingSummaryResponse `json:"rating"`
+
+	Search *SearchMatchResponse `json:"search,omitempty"`
+}
+
+// SearchMatchResponse представляет совпадение профиля с текстовым запросом.
+// Snippet экранирован для HTML, найденные слова обрамлены <mark>…</mark>.
+type SearchMatchResponse struct {
+	Rank    float64 `json:"rank"`
+	Snippet string  `json:"snippet"`
+}
+
+// ExecutorSearchResponse представляет страницу поиска исполнителей.
+// NextCursor передается в cursor для следующей страницы; пустой, если страниц больше нет.
+type ExecutorSearchResponse struct {
+	Items      []ExecutorPublicResponse `json:"items"`
+	NextCursor string                   `json:"next_cursor,omitempty"`
+}
@@ -0,0 +1,46 @@
+package responses
+
+import "time"
+
+// LedgerBalanceResponse представляет сальдо счета журнала в тиынах.
+type LedgerBalanceResponse struct {
+	Account string    `json:"account"`
+	Type    string    `json:"type"`
+	Balance int64     `json:"balance"`
+	At      time.Time `json:"at"`
+}
+
+// LedgerEntryResponse представляет проводку журнала.
+type LedgerEntryResponse struct {
+	ID          string               `json:"id"`
+	Kind        string               `json:"kind"`
+	Reference   string               `json:"reference"`
+	Description string               `json:"description"`
+	OccurredAt  time.Time            `json:"occurred_at"`
+	Lines       []LedgerLineResponse `json:"lines"`
+}
+
+// LedgerLineResponse представляет строку проводки в тиынах.
+type LedgerLineResponse struct {
+	Account string `json:"account"`
+	Debit   int64  `json:"debit,omitempty"`
+	Credit  int64  `json:"credit,omitempty"`
+}
+
+// ReconciliationResponse представляет сверку журнала с провайдером.
+type ReconciliationResponse struct {
+	From          time.Time             `json:"from"`
+	To            time.Time             `json:"to"`
+	ProviderNet   int64                 `json:"provider_net"`
+	LedgerNet     int64                 `json:"ledger_net"`
+	Matched       int                   `json:"matched"`
+	Discrepancies []DiscrepancyResponse `json:"discrepancies"`
+}
+
+// DiscrepancyResponse представляет расхождение по одному платежу.
+type DiscrepancyResponse struct {
+	PaymentID   string `json:"payment_id"`
+	ChargeID    string `json:"charge_id,omitempty"`
+	ProviderNet int64  `json:"provider_net"`
+	LedgerNet   int64  `json:"ledger_net"`
+}
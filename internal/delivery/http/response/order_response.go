@@ -0,0 +1,62 @@
+package responses
+
+import "time"
+
+// OrderResponse представляет заказ клиента. Budget указан в тиынах.
+type OrderResponse struct {
+	ID             string     `json:"id"`
+	CustomerID     string     `json:"customer_id"`
+	ExecutorID     *string    `json:"executor_id,omitempty"`
+	Title          string     `json:"title"`
+	Description    string     `json:"description"`
+	Specialization string     `json:"specialization"`
+	PaymentMode    string     `json:"payment_mode"`
+	Budget         int64      `json:"budget"`
+	Deadline       *time.Time `json:"deadline,omitempty"`
+	WorkFormat     string     `json:"work_format"`
+	City           string     `json:"city"`
+	Status         string     `json:"status"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// OrderStatusChangeResponse представляет запись истории статусов заказа.
+type OrderStatusChangeResponse struct {
+	FromStatus string    `json:"from_status,omitempty"`
+	ToStatus   string    `json:"to_status"`
+	ActorID    string    `json:"actor_id"`
+	ActorRole  string    `json:"actor_role"`
+	Reason     string    `json:"reason,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// BidResponse представляет отклик исполнителя на заказ. Price указан в тиынах.
+type BidResponse struct {
+	ID            string    `json:"id"`
+	OrderID       string    `json:"order_id"`
+	ExecutorID    string    `json:"executor_id"`
+	CoverMessage  string    `json:"cover_message"`
+	Price         int64     `json:"price"`
+	EstimatedDays int       `json:"estimated_days"`
+	Status        string    `json:"status"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// MilestoneResponse представляет этап заказа. Суммы указаны в тиынах:
+// Commission удерживает платформа, Payout получает исполнитель.
+type MilestoneResponse struct {
+	ID            string     `json:"id"`
+	OrderID       string     `json:"order_id"`
+	Title         string     `json:"title"`
+	Amount        int64      `json:"amount"`
+	Position      int        `json:"position"`
+	Status        string     `json:"status"`
+	PaymentID     *string    `json:"payment_id,omitempty"`
+	Commission    int64      `json:"commission"`
+	Payout        int64      `json:"payout"`
+	SubmittedAt   *time.Time `json:"submitted_at,omitempty"`
+	AutoReleaseAt *time.Time `json:"auto_release_at,omitempty"`
+	ReleasedAt    *time.Time `json:"released_at,omitempty"`
+	ReleasedBy    string     `json:"released_by,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
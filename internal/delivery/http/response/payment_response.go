@@ -0,0 +1,19 @@
+package responses
+
+import "time"
+
+// PaymentResponse представляет платеж. Суммы указаны в тиынах.
+type PaymentResponse struct {
+	ID             string    `json:"id"`
+	Purpose        string    `json:"purpose"`
+	OrderID        *string   `json:"order_id,omitempty"`
+	EnrollmentID   *string   `json:"enrollment_id,omitempty"`
+	Amount         int64     `json:"amount"`
+	RefundedAmount int64     `json:"refunded_amount"`
+	Currency       string    `json:"currency"`
+	Status         string    `json:"status"`
+	CheckoutURL    string    `json:"checkout_url,omitempty"`
+	FailureReason  string    `json:"failure_reason,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
@@ -0,0 +1,29 @@
+package responses
+
+import "time"
+
+// RatingResponse представляет отзыв и ответ оцененного исполнителя или коуча.
+type RatingResponse struct {
+	ID            string     `json:"id"`
+	OrderID       *string    `json:"order_id,omitempty"`
+	EnrollmentID  *string    `json:"enrollment_id,omitempty"`
+	TargetID      string     `json:"target_id"`
+	TargetRole    string     `json:"target_role"`
+	Score         int        `json:"score"`
+	Quality       int        `json:"quality"`
+	Deadlines     int        `json:"deadlines"`
+	Communication int        `json:"communication"`
+	Review        string     `json:"review,omitempty"`
+	Reply         string     `json:"reply,omitempty"`
+	RepliedAt     *time.Time `json:"replied_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// RatingSummaryResponse представляет средние оценки профиля и число отзывов.
+type RatingSummaryResponse struct {
+	Count         int64   `json:"count"`
+	Average       float64 `json:"average"`
+	Quality       float64 `json:"quality"`
+	Deadlines     float64 `json:"deadlines"`
+	Communication float64 `json:"communication"`
+}
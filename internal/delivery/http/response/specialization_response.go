@@ -0,0 +1,11 @@
+package responses
+
+// SpecializationResponse представляет специализацию из справочника.
+type SpecializationResponse struct {
+	Code     string `json:"code"`
+	Role     string `json:"role"`
+	NameRu   string `json:"name_ru"`
+	NameKk   string `json:"name_kk"`
+	Position int    `json:"position"`
+	Active   bool   `json:"active"`
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// Account — единая учетная запись: email, пароль и сессии.
+// Профили Customer, Coach и Executor используют ID аккаунта как собственный первичный ключ.
+type Account struct {
+	ID              string `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	Email           string `gorm:"unique;not null"`
+	PasswordHash    string `gorm:"not null"`
+	EmailVerifiedAt *time.Time
+	CreatedAt       time.Time `gorm:"autoCreateTime"`
+}
+
+// AccountRole — роль, доступная аккаунту для входа (user, customer, coach, executor, admin).
+type AccountRole struct {
+	AccountID string    `gorm:"primaryKey;type:uuid"`
+	Role      string    `gorm:"primaryKey"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
@@ -1,22 +1,34 @@
 package domain
 
-import "time"
+import (
+	"time"
+
+	"BruhPro/internal/phone"
+)
 
 type Coach struct {
-	ID      string `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ID      string `gorm:"primaryKey;type:uuid"` // совпадает с Account.ID
 	Name    string `gorm:"not null"`
 	Surname string `gorm:"not null"`
 
-	PhoneNumber float64 `gorm:"not null"`
-	Email       string  `gorm:"unique;not null"`
+	PhoneNumber     phone.Number `gorm:"type:varchar(16);not null"` // E.164
+	Email           string       `gorm:"->;-:migration"`            // хранится в accounts, подтягивается репозиторием только для чтения
+	EmailVerifiedAt *time.Time   `gorm:"->;-:migration"`
 
-	ExpCoach        string `gorm:"not null"` //1-2 года, 3-5 лет, 6-10 лет, Более 10 лет
-	Specializations string `gorm:"not null"` // Бизнес-коучинг, Карьерный коучинг, Финансовый коучинг, Лидерство, Личностный рост
+	ExpCoach        string           `gorm:"not null"` //1-2 года, 3-5 лет, 6-10 лет, Более 10 лет
+	Specializations []Specialization `gorm:"many2many:coach_specializations;joinForeignKey:CoachID;references:Code;joinReferences:SpecializationCode"`
 
 	EducationCertificates  string    `gorm:"not null"`
 	AchievementsExperience string    `gorm:"not null"`
 	Methodology            string    `gorm:"not null"`
 	AboutCoach             string    `gorm:"not null"`
-	PasswordHash           string    `gorm:"not null"`
 	CreatedAt              time.Time `gorm:"autoCreateTime"`
+	UpdatedAt              time.Time `gorm:"autoUpdateTime"`
+	Version                int64     `gorm:"not null;default:1"` // растет с каждым изменением профиля, для оптимистичной блокировки
+
+	Rating RatingStats `gorm:"embedded;embeddedPrefix:rating_"`
+
+	// Заполняются только полнотекстовым поиском.
+	SearchRank    float64 `gorm:"->;-:migration"`
+	SearchSnippet string  `gorm:"->;-:migration"`
 }
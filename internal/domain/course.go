@@ -1 +1,84 @@
 package domain
+
+import "time"
+
+// Статусы курса: черновик виден только коучу, опубликованный — в каталоге.
+const (
+	CourseStatusDraft     = "draft"
+	CourseStatusPublished = "published"
+)
+
+// Статусы записи на курс. Запись на платный курс активируется после оплаты.
+const (
+	EnrollmentStatusPendingPayment = "pending_payment"
+	EnrollmentStatusActive         = "active"
+)
+
+// Course — курс коуча. Price в тиынах; 0 — бесплатный курс.
+type Course struct {
+	ID             string `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	CoachID        string `gorm:"type:uuid;not null;index"`
+	Title          string `gorm:"not null"`
+	Description    string `gorm:"not null"`
+	Specialization string `gorm:"not null;index"` // код Specialization с ролью coach
+	Price          int64  `gorm:"not null"`
+	Status         string `gorm:"not null;index"`
+	PublishedAt    *time.Time
+
+	Modules []CourseModule `gorm:"foreignKey:CourseID;constraint:OnDelete:CASCADE"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+// CourseModule — раздел курса. Position задает порядок внутри курса.
+type CourseModule struct {
+	ID       string `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	CourseID string `gorm:"type:uuid;not null;index"`
+	Title    string `gorm:"not null"`
+	Position int    `gorm:"not null"`
+
+	Lessons []Lesson `gorm:"foreignKey:ModuleID;constraint:OnDelete:CASCADE"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+// Lesson — урок: текст, ссылка на видео и файлы для скачивания.
+type Lesson struct {
+	ID       string `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ModuleID string `gorm:"type:uuid;not null;index"`
+	CourseID string `gorm:"type:uuid;not null;index"`
+	Title    string `gorm:"not null"`
+	Content  string
+	VideoURL string
+	Position int `gorm:"not null"`
+
+	Attachments []LessonAttachment `gorm:"foreignKey:LessonID;constraint:OnDelete:CASCADE"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+// LessonAttachment — файл урока, доступный по ссылке.
+type LessonAttachment struct {
+	ID       string `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	LessonID string `gorm:"type:uuid;not null;index"`
+	FileName string `gorm:"not null"`
+	URL      string `gorm:"not null"`
+}
+
+// CourseEnrollment — запись клиента или исполнителя на курс.
+type CourseEnrollment struct {
+	ID        string    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	CourseID  string    `gorm:"type:uuid;not null;uniqueIndex:idx_course_enrollments_course_account"`
+	AccountID string    `gorm:"type:uuid;not null;uniqueIndex:idx_course_enrollments_course_account;index"`
+	Role      string    `gorm:"not null"` // customer, executor
+	Status    string    `gorm:"not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+// LessonProgress — отметка о прохождении урока в рамках записи на курс.
+type LessonProgress struct {
+	EnrollmentID string    `gorm:"primaryKey;type:uuid"`
+	LessonID     string    `gorm:"primaryKey;type:uuid"`
+	CompletedAt  time.Time `gorm:"not null"`
+}
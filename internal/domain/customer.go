@@ -1,20 +1,27 @@
 package domain
 
-import "time"
+import (
+	"time"
+
+	"BruhPro/internal/iin"
+	"BruhPro/internal/phone"
+)
 
 type Customer struct {
-	ID         string `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
-	ClientType string `gorm:"not null"` //ТОО, ИП, Доверенный представитель
+	ID         string `gorm:"primaryKey;type:uuid"` // совпадает с Account.ID
+	ClientType string `gorm:"not null"`             //ТОО, ИП, Доверенный представитель
 
-	CompanyName string  `gorm:"not null"`
-	IIN         float64 `gorm:"not null"`
-	Name        string  `gorm:"not null"`
-	JobPosition string  `gorm:"not null"`
+	CompanyName string     `gorm:"not null"`
+	IIN         iin.Number `gorm:"type:varchar(12);not null"` // БИН для ТОО, ИИН для ИП и представителя
+	Name        string     `gorm:"not null"`
+	JobPosition string     `gorm:"not null"`
 
-	PhoneNumber     float64   `gorm:"not null"`
-	Email           string    `gorm:"unique;not null"`
-	Address         string    `gorm:"not null"`
-	WorkDescription string    `gorm:"not null"`
-	PasswordHash    string    `gorm:"not null"`
-	CreatedAt       time.Time `gorm:"autoCreateTime"`
+	PhoneNumber     phone.Number `gorm:"type:varchar(16);not null"` // E.164
+	Email           string       `gorm:"->;-:migration"`            // хранится в accounts, подтягивается репозиторием только для чтения
+	EmailVerifiedAt *time.Time   `gorm:"->;-:migration"`
+	Address         string       `gorm:"not null"`
+	WorkDescription string       `gorm:"not null"`
+	CreatedAt       time.Time    `gorm:"autoCreateTime"`
+	UpdatedAt       time.Time    `gorm:"autoUpdateTime"`
+	Version         int64        `gorm:"not null;default:1"` // растет с каждым изменением профиля, для оптимистичной блокировки
 }
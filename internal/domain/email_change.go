@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// EmailChange — заявка на смену email аккаунта. Новый адрес подтверждается ссылкой из письма
+// на него; после замены на старый адрес уходит ссылка отмены, действующая до RevertExpiresAt.
+// Хранятся только хеши токенов.
+type EmailChange struct {
+	ID               string    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	AccountID        string    `gorm:"type:uuid;not null;index"`
+	Role             string    `gorm:"not null"` // роль, из кабинета которой запрошена смена: для ссылок в письмах
+	OldEmail         string    `gorm:"not null"`
+	NewEmail         string    `gorm:"not null"`
+	ConfirmTokenHash string    `gorm:"uniqueIndex;not null"`
+	ExpiresAt        time.Time `gorm:"not null"`
+	ConfirmedAt      *time.Time
+	CanceledAt       *time.Time // заявку заменила более новая
+	RevertTokenHash  *string    `gorm:"uniqueIndex"`
+	RevertExpiresAt  *time.Time
+	RevertedAt       *time.Time
+	CreatedAt        time.Time `gorm:"autoCreateTime"`
+}
@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// EmailVerificationToken — одноразовый токен подтверждения email из письма.
+// Email фиксирует адрес, на который было отправлено письмо.
+type EmailVerificationToken struct {
+	ID        string    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	AccountID string    `gorm:"type:uuid;not null;index"`
+	Email     string    `gorm:"not null"`
+	TokenHash string    `gorm:"uniqueIndex;not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
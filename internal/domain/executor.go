@@ -1,24 +1,39 @@
 package domain
 
-import "time"
+import (
+	"time"
+
+	"BruhPro/internal/iin"
+	"BruhPro/internal/phone"
+)
 
 type Executor struct {
-	ID          string  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
-	Name        string  `gorm:"not null"`
-	Surname     string  `gorm:"not null"`
-	Patronymic  string  `gorm:"not null"`
-	IIN         float64 `gorm:"not null"`
-	PhoneNumber float64 `gorm:"not null"`
-	Email       string  `gorm:"unique;not null"`
-	City        string  `gorm:"not null"`
-	ExpWork     string  `gorm:"not null"`
-
-	Specializations string  `gorm:"not null"` // Бухгалтерский учет, Налоговое консультирование, Аудиторские услуги, Финансовый анализ, Подготовка отчетности, Восстановление учета, Управленческий учет, Международные стандарты (МСФО), Налоговое планирование, Кадровое делопроизводство
-	Education       string  `gorm:"not null"`
-	WorkFormat      string  `gorm:"not null"` //Удаленно, В офисе клиента, Смешанный формат, Гибкий график
-	HourlyRate      float64 `gorm:"not null"`
-	AboutExecutor   string  `gorm:"not null"`
-
-	PasswordHash string    `gorm:"not null"`
-	CreatedAt    time.Time `gorm:"autoCreateTime"`
+	ID              string       `gorm:"primaryKey;type:uuid"` // совпадает с Account.ID
+	Name            string       `gorm:"not null"`
+	Surname         string       `gorm:"not null"`
+	Patronymic      string       `gorm:"not null"`
+	IIN             iin.Number   `gorm:"type:varchar(12);not null"`
+	PhoneNumber     phone.Number `gorm:"type:varchar(16);not null"` // E.164
+	Email           string       `gorm:"->;-:migration"`            // хранится в accounts, подтягивается репозиторием только для чтения
+	EmailVerifiedAt *time.Time   `gorm:"->;-:migration"`
+	City            string       `gorm:"not null"`
+	ExpWork         string       `gorm:"not null"`
+	ExpYears        int          `gorm:"->;-:migration"` // вычисляемая колонка: первое число из ExpWork, для фильтра по стажу
+
+	Specializations []Specialization `gorm:"many2many:executor_specializations;joinForeignKey:ExecutorID;references:Code;joinReferences:SpecializationCode"`
+
+	Education     string  `gorm:"not null"`
+	WorkFormat    string  `gorm:"not null"` //Удаленно, В офисе клиента, Смешанный формат, Гибкий график
+	HourlyRate    float64 `gorm:"not null"`
+	AboutExecutor string  `gorm:"not null"`
+
+	Rating RatingStats `gorm:"embedded;embeddedPrefix:rating_"`
+
+	// Заполняются только полнотекстовым поиском.
+	SearchRank    float64 `gorm:"->;-:migration"`
+	SearchSnippet string  `gorm:"->;-:migration"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+	Version   int64     `gorm:"not null;default:1"` // растет с каждым изменением профиля, для оптимистичной блокировки
 }
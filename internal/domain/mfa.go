@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// AccountMFA — TOTP-секрет аккаунта. До подтверждения первым кодом EnabledAt пуст,
+// и второй фактор при входе не запрашивается.
+type AccountMFA struct {
+	AccountID    string `gorm:"primaryKey;type:uuid"`
+	Secret       string `gorm:"not null"`
+	EnabledAt    *time.Time
+	LastUsedStep int64     // последний принятый TOTP-интервал, защищает от повторного ввода кода
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+}
+
+// MFARecoveryCode — одноразовый код восстановления на случай потери устройства.
+type MFARecoveryCode struct {
+	ID        string `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	AccountID string `gorm:"type:uuid;not null;index"`
+	CodeHash  string `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+// Статусы этапа заказа. Оплаченный этап хранится на эскроу платформы до выплаты исполнителю.
+const (
+	MilestoneStatusPending   = "pending"   // ожидает оплаты
+	MilestoneStatusFunded    = "funded"    // оплачен, деньги на эскроу
+	MilestoneStatusSubmitted = "submitted" // исполнитель сдал работу, ждет приемки
+	MilestoneStatusReleased  = "released"  // принят, деньги выплачены исполнителю
+	MilestoneStatusRefunded  = "refunded"  // деньги возвращены клиенту
+	MilestoneStatusCancelled = "cancelled" // отменен до оплаты
+)
+
+// Milestone — этап заказа, например «Восстановление учета за Q1».
+// Суммы в тиынах: Commission удерживается платформой, Payout получает исполнитель.
+type Milestone struct {
+	ID            string  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	OrderID       string  `gorm:"type:uuid;not null;index"`
+	Title         string  `gorm:"not null"`
+	Amount        int64   `gorm:"not null"`
+	Position      int     `gorm:"not null"`
+	Status        string  `gorm:"not null;index"`
+	PaymentID     *string `gorm:"type:uuid"`
+	Commission    int64   `gorm:"not null;default:0"`
+	Payout        int64   `gorm:"not null;default:0"`
+	SubmittedAt   *time.Time
+	AutoReleaseAt *time.Time `gorm:"index"` // после этого срока сданный этап принимается автоматически
+	ReleasedAt    *time.Time
+	ReleasedBy    string // ID клиента или "system" при автоматической приемке
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
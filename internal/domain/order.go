@@ -1 +1,53 @@
 package domain
+
+import "time"
+
+// Режимы оплаты заказа: фиксированный бюджет за всю работу или почасовая ставка.
+const (
+	PaymentModeFixed  = "fixed"
+	PaymentModeHourly = "hourly"
+)
+
+// Статусы жизненного цикла заказа. Допустимые переходы описаны в usecase.OrderUsecase.
+const (
+	OrderStatusDraft      = "draft"
+	OrderStatusPublished  = "published"
+	OrderStatusInProgress = "in_progress"
+	OrderStatusOnReview   = "on_review"
+	OrderStatusCompleted  = "completed"
+	OrderStatusCancelled  = "cancelled"
+	OrderStatusDisputed   = "disputed"
+)
+
+// Order — заказ клиента на бухгалтерскую работу.
+// Суммы хранятся в тиынах (1 тенге = 100 тиын), чтобы избежать ошибок округления.
+type Order struct {
+	ID          string  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	CustomerID  string  `gorm:"type:uuid;not null;index"`
+	ExecutorID  *string `gorm:"type:uuid;index"` // назначается при переходе в in_progress
+	Title       string  `gorm:"not null"`
+	Description string  `gorm:"not null"`
+
+	Specialization string `gorm:"not null;index"` // код Specialization с ролью executor
+	PaymentMode    string `gorm:"not null"`       // fixed, hourly
+	Budget         int64  `gorm:"not null"`       // бюджет (fixed) или ставка в час (hourly), тиын
+	Deadline       *time.Time
+	WorkFormat     string `gorm:"not null"` // одно из WorkFormats
+	City           string `gorm:"not null"`
+
+	Status    string    `gorm:"not null;index"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+// OrderStatusChange — запись истории заказа: кто, когда и почему изменил статус.
+type OrderStatusChange struct {
+	ID         string `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	OrderID    string `gorm:"type:uuid;not null;index"`
+	FromStatus string // пусто для создания заказа
+	ToStatus   string `gorm:"not null"`
+	ActorID    string `gorm:"type:uuid"`
+	ActorRole  string `gorm:"not null"`
+	Reason     string
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+}
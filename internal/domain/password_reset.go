@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// PasswordResetToken — одноразовый токен сброса пароля. Хранится только хеш токена.
+// Токен действует только для роли, из кабинета которой запрошен сброс.
+type PasswordResetToken struct {
+	ID        string    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	AccountID string    `gorm:"type:uuid;not null;index"`
+	Role      string    `gorm:"not null"`
+	TokenHash string    `gorm:"uniqueIndex;not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
@@ -1 +1,52 @@
 package domain
+
+import "time"
+
+// Статусы платежа. authorized — деньги заблокированы на карте, captured — списаны.
+const (
+	PaymentStatusPending    = "pending"
+	PaymentStatusAuthorized = "authorized"
+	PaymentStatusCaptured   = "captured"
+	PaymentStatusRefunded   = "refunded"
+	PaymentStatusFailed     = "failed"
+)
+
+// Назначение платежа: оплата этапа заказа или записи на платный курс.
+const (
+	PaymentPurposeOrder  = "order"
+	PaymentPurposeCourse = "course"
+)
+
+// CurrencyKZT — единственная поддерживаемая валюта; суммы хранятся в тиынах.
+const CurrencyKZT = "KZT"
+
+// Payment — платеж клиента через платежного провайдера. Amount и RefundedAmount в тиынах.
+type Payment struct {
+	ID                string  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	AccountID         string  `gorm:"type:uuid;not null;index"` // плательщик
+	Purpose           string  `gorm:"not null"`
+	OrderID           *string `gorm:"type:uuid;index"`
+	MilestoneID       *string `gorm:"type:uuid;index"`
+	EnrollmentID      *string `gorm:"type:uuid;index"`
+	Amount            int64   `gorm:"not null"`
+	RefundedAmount    int64   `gorm:"not null;default:0"`
+	Currency          string  `gorm:"not null"`
+	Status            string  `gorm:"not null;index"`
+	Provider          string  `gorm:"not null;uniqueIndex:idx_payments_provider_payment"`
+	ProviderPaymentID *string `gorm:"uniqueIndex:idx_payments_provider_payment"`
+	CheckoutURL       string
+	FailureReason     string
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+// PaymentEvent — обработанное уведомление провайдера. Повторная доставка того же
+// события отсекается первичным ключом (Provider, EventID).
+type PaymentEvent struct {
+	Provider  string    `gorm:"primaryKey"`
+	EventID   string    `gorm:"primaryKey"`
+	PaymentID string    `gorm:"type:uuid;not null;index"`
+	Status    string    `gorm:"not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// ProfileChange — изменение одного поля профиля роли. Значения хранятся текстом,
+// как их видит владелец профиля; Version — версия профиля после изменения.
+type ProfileChange struct {
+	ID        string    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	AccountID string    `gorm:"type:uuid;not null;index:idx_profile_changes_account"`
+	Role      string    `gorm:"not null;index:idx_profile_changes_account"`
+	Field     string    `gorm:"not null"`
+	OldValue  string    `gorm:"not null"`
+	NewValue  string    `gorm:"not null"`
+	Version   int64     `gorm:"not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
@@ -1 +1,46 @@
 package domain
+
+import "time"
+
+// Rating — оценка исполнителю по завершенному заказу или коучу по курсу, на котором учится автор.
+// На один заказ и на одну запись на курс — одна оценка. Score и подоценки — целые от 1 до 5.
+type Rating struct {
+	ID            string  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	OrderID       *string `gorm:"type:uuid;uniqueIndex"`    // для оценки исполнителя
+	EnrollmentID  *string `gorm:"type:uuid;uniqueIndex"`    // для оценки коуча
+	AuthorID      string  `gorm:"type:uuid;not null;index"` // клиент или ученик курса
+	TargetID      string  `gorm:"type:uuid;not null;index:idx_ratings_target"`
+	TargetRole    string  `gorm:"not null;index:idx_ratings_target"` // executor, coach
+	Score         int     `gorm:"not null"`
+	Quality       int     `gorm:"not null"`
+	Deadlines     int     `gorm:"not null"`
+	Communication int     `gorm:"not null"`
+	Review        string
+	Reply         string // публичный ответ оцененного
+	RepliedAt     *time.Time
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+// RatingSummary — накопленные суммы оценок профиля. Обновляется вместе с каждой оценкой,
+// чтобы средние не пересчитывались при каждом чтении профиля.
+type RatingSummary struct {
+	TargetID         string `gorm:"primaryKey;type:uuid"`
+	TargetRole       string `gorm:"primaryKey"`
+	Count            int64  `gorm:"not null;default:0"`
+	SumScore         int64  `gorm:"not null;default:0"`
+	SumQuality       int64  `gorm:"not null;default:0"`
+	SumDeadlines     int64  `gorm:"not null;default:0"`
+	SumCommunication int64  `gorm:"not null;default:0"`
+	UpdatedAt        time.Time
+}
+
+// RatingStats — средние оценки профиля, подтягиваются репозиторием только для чтения.
+type RatingStats struct {
+	Count         int64   `gorm:"->;-:migration"`
+	Average       float64 `gorm:"->;-:migration"`
+	Quality       float64 `gorm:"->;-:migration"`
+	Deadlines     float64 `gorm:"->;-:migration"`
+	Communication float64 `gorm:"->;-:migration"`
+}
@@ -2,10 +2,19 @@ package domain
 
 import "time"
 
+// RefreshToken хранит только SHA-256 хеш токена. Все токены, полученные ротацией
+// из одного логина, имеют общий FamilyID (он же Session.ID): при повторном использовании
+// уже обмененного токена отзывается вся семья вместе с сессией.
+// Токен привязан к роли, для которой был выдан, и обменивается на /refresh этой роли
+// или на общем /refresh, который берет роль из токена.
 type RefreshToken struct {
-	ID        string    `gorm:"primaryKey"`
-	UserID    string    `gorm:"not null"`
-	Token     string    `gorm:"not null"`
-	ExpiresAt time.Time `gorm:"not null"`
+	ID        string     `gorm:"primaryKey"`
+	AccountID string     `gorm:"type:uuid;not null;index"`
+	Role      string     `gorm:"not null"`
+	FamilyID  string     `gorm:"type:uuid;not null;index"`
+	TokenHash string     `gorm:"uniqueIndex;not null"`
+	ExpiresAt time.Time  `gorm:"not null"`
+	UsedAt    *time.Time // момент обмена на новый токен
+	RevokedAt *time.Time
 	CreatedAt time.Time
 }
@@ -1 +1,27 @@
 package domain
+
+import "time"
+
+// Статусы отклика исполнителя на заказ.
+const (
+	ResponseStatusPending     = "pending"
+	ResponseStatusShortlisted = "shortlisted"
+	ResponseStatusRejected    = "rejected"
+	ResponseStatusAccepted    = "accepted"
+	ResponseStatusDeclined    = "declined" // отклонен автоматически после выбора другого исполнителя
+)
+
+// Response — отклик исполнителя на опубликованный заказ.
+// Price трактуется по режиму оплаты заказа: цена всей работы (fixed) или ставка в час (hourly), в тиынах.
+type Response struct {
+	ID            string `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	OrderID       string `gorm:"type:uuid;not null;uniqueIndex:idx_responses_order_executor"`
+	ExecutorID    string `gorm:"type:uuid;not null;uniqueIndex:idx_responses_order_executor;index"`
+	CoverMessage  string `gorm:"not null"`
+	Price         int64  `gorm:"not null"`
+	EstimatedDays int    `gorm:"not null"`
+	Status        string `gorm:"not null"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
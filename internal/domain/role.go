@@ -0,0 +1,10 @@
+package domain
+
+// Роли, которые записываются в JWT и проверяются middleware.RequireRole.
+const (
+	RoleUser     = "user"
+	RoleCustomer = "customer"
+	RoleCoach    = "coach"
+	RoleExecutor = "executor"
+	RoleAdmin    = "admin"
+)
@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// Session — один вход в систему (устройство). ID сессии совпадает с FamilyID ее refresh токенов.
+type Session struct {
+	ID              string `gorm:"primaryKey;type:uuid"`
+	AccountID       string `gorm:"type:uuid;not null;index"`
+	Role            string `gorm:"not null"`
+	UserAgent       string
+	IP              string
+	MFA             bool      // вход подтвержден вторым фактором
+	AccessExpiresAt time.Time // срок действия последнего выданного access token
+	CreatedAt       time.Time `gorm:"autoCreateTime"`
+	LastUsedAt      time.Time
+	RevokedAt       *time.Time
+}
+
+// RevokedToken — запись denylist: отозванный jti access token или ID сессии.
+// Хранится до ExpiresAt, после чего токен истек бы сам.
+type RevokedToken struct {
+	TokenID   string    `gorm:"primaryKey"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
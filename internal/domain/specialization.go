@@ -0,0 +1,62 @@
+package domain
+
+import "time"
+
+// Specialization — запись справочника специализаций. Role — чья это специализация:
+// исполнителя (по ним публикуются заказы) или коуча (по ним фильтруется каталог курсов).
+// Профили, заказы и курсы ссылаются на специализацию по Code.
+// Выключенная специализация остается у существующих профилей, но новой ее выбрать нельзя.
+type Specialization struct {
+	Code      string    `gorm:"primaryKey"`
+	Role      string    `gorm:"not null;index"`
+	NameRu    string    `gorm:"not null"`
+	NameKk    string    `gorm:"not null"`
+	Position  int       `gorm:"not null;default:0"`
+	Active    bool      `gorm:"not null;default:true"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+// WorkFormats — форматы работы исполнителя и заказа.
+var WorkFormats = []string{
+	"Удаленно",
+	"В офисе клиента",
+	"Смешанный формат",
+	"Гибкий график",
+}
+
+// ExperienceBucket — диапазон стажа исполнителя в полных годах: [MinYears, MaxYears).
+// MaxYears = 0 означает диапазон без верхней границы.
+type ExperienceBucket struct {
+	Code     string
+	MinYears int
+	MaxYears int
+}
+
+// ExperienceBuckets — диапазоны стажа для поиска исполнителей.
+var ExperienceBuckets = []ExperienceBucket{
+	{Code: "under_1", MinYears: 0, MaxYears: 1},
+	{Code: "1_3", MinYears: 1, MaxYears: 3},
+	{Code: "3_5", MinYears: 3, MaxYears: 5},
+	{Code: "over_5", MinYears: 5},
+}
+
+// FindExperienceBucket возвращает диапазон стажа по коду.
+func FindExperienceBucket(code string) (ExperienceBucket, bool) {
+	for _, bucket := range ExperienceBuckets {
+		if bucket.Code == code {
+			return bucket, true
+		}
+	}
+	return ExperienceBucket{}, false
+}
+
+// Contains сообщает, входит ли value в список values.
+func Contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,166 @@
+// Package iin разбирает и проверяет казахстанские ИИН (индивидуальный идентификационный номер
+// физического лица) и БИН (бизнес-идентификационный номер юридического лица).
+// Оба номера — 12 цифр с контрольным разрядом, вычисляемым по одному алгоритму.
+package iin
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+const length = 12
+
+var (
+	ErrInvalidFormat   = errors.New("iin/bin must be exactly 12 digits")
+	ErrInvalidChecksum = errors.New("iin/bin checksum does not match")
+	ErrNotIndividual   = errors.New("number is a bin, an individual iin is required")
+	ErrNotLegalEntity  = errors.New("number is an iin, a bin is required")
+	ErrNoBirthDate     = errors.New("iin does not encode a valid birth date")
+)
+
+// Пол владельца ИИН.
+const (
+	GenderMale   = "male"
+	GenderFemale = "female"
+)
+
+// Number — ИИН или БИН, хранится строкой из 12 цифр: ведущие нули значимы.
+type Number string
+
+// Parse проверяет формат и контрольный разряд. Пробелы и дефисы, которыми номер
+// часто разбивают на группы, отбрасываются.
+func Parse(value string) (Number, error) {
+	digits := normalize(value)
+
+	if len(digits) != length {
+		return "", ErrInvalidFormat
+	}
+	for i := 0; i < length; i++ {
+		if digits[i] < '0' || digits[i] > '9' {
+			return "", ErrInvalidFormat
+		}
+	}
+	if checksum(digits) != int(digits[length-1]-'0') {
+		return "", ErrInvalidChecksum
+	}
+	return Number(digits), nil
+}
+
+// ParseIIN разбирает ИИН физического лица: кроме контрольного разряда, проверяется дата рождения.
+func ParseIIN(value string) (Number, error) {
+	number, err := Parse(value)
+	if err != nil {
+		return "", err
+	}
+	if number.IsBIN() {
+		return "", ErrNotIndividual
+	}
+	if _, err := number.BirthDate(); err != nil {
+		return "", err
+	}
+	return number, nil
+}
+
+// ParseBIN разбирает БИН юридического лица.
+func ParseBIN(value string) (Number, error) {
+	number, err := Parse(value)
+	if err != nil {
+		return "", err
+	}
+	if !number.IsBIN() {
+		return "", ErrNotLegalEntity
+	}
+	return number, nil
+}
+
+// checksum вычисляет контрольный разряд по первым 11 цифрам. Сначала используются веса 1…11;
+// если остаток от деления на 11 равен 10 — веса 3…11, 1, 2. Повторный остаток 10 означает,
+// что номер не выдается, и такой номер никогда не совпадет с разрядом 0…9.
+func checksum(digits string) int {
+	sum := func(first int) int {
+		total := 0
+		for i := 0; i < length-1; i++ {
+			weight := (first+i-1)%11 + 1
+			total += int(digits[i]-'0') * weight
+		}
+		return total % 11
+	}
+
+	control := sum(1)
+	if control == 10 {
+		control = sum(3)
+	}
+	return control
+}
+
+// IsBIN сообщает, что номер — БИН: в нем пятая цифра обозначает тип организации (4, 5 или 6),
+// а в ИИН на этом месте первая цифра дня рождения (0…3).
+func (n Number) IsBIN() bool {
+	return len(n) == length && n[4] >= '4' && n[4] <= '6'
+}
+
+// BirthDate возвращает дату рождения из ИИН: цифры 1–6 — ГГММДД, седьмая задает век и пол
+// (1, 2 — XIX век, 3, 4 — XX, 5, 6 — XXI).
+func (n Number) BirthDate() (time.Time, error) {
+	if len(n) != length || n.IsBIN() {
+		return time.Time{}, ErrNoBirthDate
+	}
+
+	centuries := map[byte]int{'1': 1800, '2': 1800, '3': 1900, '4': 1900, '5': 2000, '6': 2000}
+	century, ok := centuries[n[6]]
+	if !ok {
+		return time.Time{}, ErrNoBirthDate
+	}
+
+	year := century + twoDigits(n[0:2])
+	month := twoDigits(n[2:4])
+	day := twoDigits(n[4:6])
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if date.Year() != year || int(date.Month()) != month || date.Day() != day {
+		return time.Time{}, ErrNoBirthDate
+	}
+	return date, nil
+}
+
+// Gender возвращает пол владельца ИИН по седьмой цифре: нечетная — мужской, четная — женский.
+// Для БИН и ИИН без даты рождения возвращает пустую строку.
+func (n Number) Gender() string {
+	if _, err := n.BirthDate(); err != nil {
+		return ""
+	}
+	if (n[6]-'0')%2 == 1 {
+		return GenderMale
+	}
+	return GenderFemale
+}
+
+func (n Number) String() string {
+	return string(n)
+}
+
+// UnmarshalJSON принимает номер строкой и убирает из него разделители групп.
+// Формат и контрольный разряд здесь не проверяются — это делают теги валидатора.
+func (n *Number) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return ErrInvalidFormat
+	}
+	*n = Number(normalize(value))
+	return nil
+}
+
+// normalize убирает пробелы и дефисы, которыми номер разбивают на группы.
+func normalize(value string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' {
+			return -1
+		}
+		return r
+	}, value)
+}
+
+func twoDigits(s Number) int {
+	return int(s[0]-'0')*10 + int(s[1]-'0')
+}
@@ -0,0 +1,110 @@
+package iin
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestParseIIN(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		birth  time.Time
+		gender string
+	}{
+		{"male XX century", "900515300107", time.Date(1990, 5, 15, 0, 0, 0, 0, time.UTC), GenderMale},
+		{"second weights pass", "900515400502", time.Date(1990, 5, 15, 0, 0, 0, 0, time.UTC), GenderFemale},
+		{"leap day XXI century", "040229500104", time.Date(2004, 2, 29, 0, 0, 0, 0, time.UTC), GenderMale},
+		{"female XXI century", "040229600100", time.Date(2004, 2, 29, 0, 0, 0, 0, time.UTC), GenderFemale},
+		{"male XIX century", "890131100102", time.Date(1889, 1, 31, 0, 0, 0, 0, time.UTC), GenderMale},
+		{"female XIX century", "991231200106", time.Date(1899, 12, 31, 0, 0, 0, 0, time.UTC), GenderFemale},
+		{"grouped with spaces", "900515 300107", time.Date(1990, 5, 15, 0, 0, 0, 0, time.UTC), GenderMale},
+		{"grouped with dashes", "9005-1530-0107", time.Date(1990, 5, 15, 0, 0, 0, 0, time.UTC), GenderMale},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			number, err := ParseIIN(tt.value)
+			if err != nil {
+				t.Fatalf("ParseIIN(%q) error = %v", tt.value, err)
+			}
+			if len(number) != length {
+				t.Errorf("ParseIIN(%q) = %q, want 12 digits", tt.value, number)
+			}
+			if number.IsBIN() {
+				t.Errorf("%s detected as bin", number)
+			}
+			birth, err := number.BirthDate()
+			if err != nil || !birth.Equal(tt.birth) {
+				t.Errorf("BirthDate() = %v, %v; want %v", birth, err, tt.birth)
+			}
+			if got := number.Gender(); got != tt.gender {
+				t.Errorf("Gender() = %q, want %q", got, tt.gender)
+			}
+		})
+	}
+}
+
+func TestParseIINRejects(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  error
+	}{
+		{"empty", "", ErrInvalidFormat},
+		{"too short", "90051530010", ErrInvalidFormat},
+		{"too long", "9005153001070", ErrInvalidFormat},
+		{"letters", "90051530010a", ErrInvalidFormat},
+		{"wrong control digit", "900515300108", ErrInvalidChecksum},
+		{"second pass control digit", "900515400503", ErrInvalidChecksum},
+		{"bin", "090440000109", ErrNotIndividual},
+		{"no such date", "050229500806", ErrNoBirthDate},
+		{"century digit 0", "991231000103", ErrNoBirthDate},
+		{"century digit 7", "000101700103", ErrNoBirthDate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseIIN(tt.value); !errors.Is(err, tt.want) {
+				t.Fatalf("ParseIIN(%q) error = %v, want %v", tt.value, err, tt.want)
+			}
+		})
+	}
+}
+
+// Для первых 11 цифр 10000000028 обе суммы дают остаток 10: такой номер не выдается,
+// и ни один контрольный разряд не должен подойти.
+func TestParseRejectsUnissuableNumber(t *testing.T) {
+	for d := 0; d <= 9; d++ {
+		value := "10000000028" + strconv.Itoa(d)
+		if _, err := Parse(value); !errors.Is(err, ErrInvalidChecksum) {
+			t.Errorf("Parse(%q) error = %v, want %v", value, err, ErrInvalidChecksum)
+		}
+	}
+}
+
+func TestParseBIN(t *testing.T) {
+	for _, value := range []string{"090440000109", "120540000205", "150650000302"} {
+		number, err := ParseBIN(value)
+		if err != nil {
+			t.Errorf("ParseBIN(%q) error = %v", value, err)
+			continue
+		}
+		if !number.IsBIN() {
+			t.Errorf("%s not detected as bin", number)
+		}
+		if _, err := number.BirthDate(); !errors.Is(err, ErrNoBirthDate) {
+			t.Errorf("BirthDate() of bin %s error = %v, want %v", number, err, ErrNoBirthDate)
+		}
+		if got := number.Gender(); got != "" {
+			t.Errorf("Gender() of bin %s = %q, want empty", number, got)
+		}
+	}
+
+	if _, err := ParseBIN("900515300107"); !errors.Is(err, ErrNotLegalEntity) {
+		t.Errorf("ParseBIN(iin) error = %v, want %v", err, ErrNotLegalEntity)
+	}
+	if _, err := ParseBIN("090440000108"); !errors.Is(err, ErrInvalidChecksum) {
+		t.Errorf("ParseBIN(bad checksum) error = %v, want %v", err, ErrInvalidChecksum)
+	}
+}
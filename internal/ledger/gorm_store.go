@@ -0,0 +1,100 @@
+package ledger
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormStore хранит журнал в таблицах ledger_entries и ledger_lines.
+// Изменение и удаление строк журнала запрещено триггерами в миграции.
+type GormStore struct {
+	db *gorm.DB
+}
+
+func NewGormStore(db *gorm.DB) *GormStore {
+	return &GormStore{db}
+}
+
+func (s *GormStore) Append(entry *Entry) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		lines := entry.Lines
+		entry.Lines = nil
+
+		result := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "key"}}, DoNothing: true}).Create(entry)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			entry.Lines = lines
+			return ErrDuplicateEntry
+		}
+
+		for i := range lines {
+			lines[i].EntryID = entry.ID
+		}
+		entry.Lines = lines
+		return tx.Create(&entry.Lines).Error
+	})
+}
+
+func (s *GormStore) Totals(account string, at time.Time) (int64, int64, error) {
+	var totals struct {
+		Debit  int64
+		Credit int64
+	}
+	err := s.db.Model(&Line{}).
+		Select("COALESCE(SUM(ledger_lines.debit), 0) AS debit, COALESCE(SUM(ledger_lines.credit), 0) AS credit").
+		Joins("JOIN ledger_entries ON ledger_entries.id = ledger_lines.entry_id").
+		Where("ledger_lines.account = ? AND ledger_entries.occurred_at <= ?", account, at).
+		Scan(&totals).Error
+	return totals.Debit, totals.Credit, err
+}
+
+func (s *GormStore) Entries(account string, from, to time.Time) ([]Entry, error) {
+	var entries []Entry
+	err := s.db.Preload("Lines").
+		Where("id IN (?)", s.db.Model(&Line{}).Select("entry_id").Where("account = ?", account)).
+		Where("occurred_at >= ? AND occurred_at < ?", from, to).
+		Order("occurred_at").
+		Find(&entries).Error
+	return entries, err
+}
+
+func (s *GormStore) NetByReference(account string, references []string) (map[string]int64, error) {
+	result := make(map[string]int64, len(references))
+	if len(references) == 0 {
+		return result, nil
+	}
+
+	var rows []struct {
+		Reference string
+		Net       int64
+	}
+	err := s.db.Model(&Line{}).
+		Select("ledger_entries.reference AS reference, SUM(ledger_lines.debit - ledger_lines.credit) AS net").
+		Joins("JOIN ledger_entries ON ledger_entries.id = ledger_lines.entry_id").
+		Where("ledger_lines.account = ? AND ledger_entries.reference IN ?", account, references).
+		Group("ledger_entries.reference").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		result[row.Reference] = row.Net
+	}
+	return result, nil
+}
+
+func (s *GormStore) FirstPostedReferences(account string, from, to time.Time) ([]string, error) {
+	var references []string
+	err := s.db.Model(&Line{}).
+		Joins("JOIN ledger_entries ON ledger_entries.id = ledger_lines.entry_id").
+		Where("ledger_lines.account = ?", account).
+		Group("ledger_entries.reference").
+		Having("MIN(ledger_entries.occurred_at) >= ? AND MIN(ledger_entries.occurred_at) < ?", from, to).
+		Pluck("ledger_entries.reference", &references).Error
+	return references, err
+}
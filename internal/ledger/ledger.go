@@ -0,0 +1,182 @@
+package ledger
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrUnbalanced     = errors.New("journal entry is not balanced")
+	ErrInvalidLine    = errors.New("journal line must have either a positive debit or a positive credit")
+	ErrUnknownAccount = errors.New("unknown ledger account")
+	ErrDuplicateEntry = errors.New("journal entry has already been posted")
+)
+
+// Типы счетов задают знак сальдо: активы растут по дебету, обязательства и выручка — по кредиту.
+const (
+	TypeAsset     = "asset"
+	TypeLiability = "liability"
+	TypeRevenue   = "revenue"
+)
+
+// Виды счетов. Счета участников имеют вид "<вид>:<id>", например "escrow:<order_id>".
+const (
+	ProviderClearing = "provider_clearing" // деньги, поступившие через платежного провайдера
+	PlatformRevenue  = "platform_revenue"  // комиссия платформы
+	CustomerWallet   = "customer_wallet"   // кошелек плательщика
+	Escrow           = "escrow"            // деньги заказа, удерживаемые до приемки этапов
+	ExecutorPayable  = "executor_payable"  // к выплате исполнителю
+	CoachPayable     = "coach_payable"     // к выплате коучу за курсы
+)
+
+var accountTypes = map[string]string{
+	ProviderClearing: TypeAsset,
+	PlatformRevenue:  TypeRevenue,
+	CustomerWallet:   TypeLiability,
+	Escrow:           TypeLiability,
+	ExecutorPayable:  TypeLiability,
+	CoachPayable:     TypeLiability,
+}
+
+// Account возвращает код счета участника.
+func Account(kind, ownerID string) string {
+	return kind + ":" + ownerID
+}
+
+// AccountType возвращает тип счета по его коду или пустую строку для неизвестного счета.
+func AccountType(account string) string {
+	kind, _, _ := strings.Cut(account, ":")
+	return accountTypes[kind]
+}
+
+// Entry — проводка журнала. Проводки не изменяются и не удаляются: ошибка исправляется
+// сторнирующей проводкой. Key делает повторное проведение той же операции безопасным.
+type Entry struct {
+	ID          string    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	Key         string    `gorm:"not null;uniqueIndex"`
+	Kind        string    `gorm:"not null;index"`
+	Reference   string    `gorm:"not null;index"` // ID платежа или этапа заказа
+	Description string    `gorm:"not null"`
+	OccurredAt  time.Time `gorm:"not null;index"`
+	Lines       []Line    `gorm:"foreignKey:EntryID"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+}
+
+func (Entry) TableName() string {
+	return "ledger_entries"
+}
+
+// Line — строка проводки: дебет или кредит одного счета в тиынах.
+type Line struct {
+	ID      string `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	EntryID string `gorm:"type:uuid;not null;index"`
+	Account string `gorm:"not null;index"`
+	Debit   int64  `gorm:"not null;default:0"`
+	Credit  int64  `gorm:"not null;default:0"`
+}
+
+func (Line) TableName() string {
+	return "ledger_lines"
+}
+
+func Debit(account string, amount int64) Line {
+	return Line{Account: account, Debit: amount}
+}
+
+func Credit(account string, amount int64) Line {
+	return Line{Account: account, Credit: amount}
+}
+
+// Store хранит журнал. Реализация должна записывать проводку со строками атомарно
+// и возвращать ErrDuplicateEntry для уже записанного Key.
+type Store interface {
+	Append(entry *Entry) error
+	// Totals возвращает обороты счета по дебету и кредиту по проводкам до момента at включительно.
+	Totals(account string, at time.Time) (debit, credit int64, err error)
+	// Entries возвращает проводки по счету за период [from, to).
+	Entries(account string, from, to time.Time) ([]Entry, error)
+	// NetByReference возвращает дебет минус кредит счета по всем проводкам с Reference из references
+	// в разрезе Reference.
+	NetByReference(account string, references []string) (map[string]int64, error)
+	// FirstPostedReferences возвращает Reference, первая проводка по которым на счете
+	// попала в период [from, to).
+	FirstPostedReferences(account string, from, to time.Time) ([]string, error)
+}
+
+// Ledger — журнал двойной записи платформы.
+type Ledger struct {
+	store Store
+	now   func() time.Time
+}
+
+func New(store Store) *Ledger {
+	return &Ledger{store: store, now: time.Now}
+}
+
+// WithClock подменяет источник времени (для тестов).
+func (l *Ledger) WithClock(now func() time.Time) *Ledger {
+	l.now = now
+	return l
+}
+
+// Post проводит операцию. Сумма дебета должна совпадать с суммой кредита.
+// Повторное проведение с тем же key ничего не меняет и не считается ошибкой.
+func (l *Ledger) Post(key, kind, reference, description string, lines ...Line) error {
+	if len(lines) < 2 {
+		return ErrUnbalanced
+	}
+
+	var debit, credit int64
+	for _, line := range lines {
+		if AccountType(line.Account) == "" {
+			return ErrUnknownAccount
+		}
+		if line.Debit < 0 || line.Credit < 0 || (line.Debit == 0) == (line.Credit == 0) {
+			return ErrInvalidLine
+		}
+		debit += line.Debit
+		credit += line.Credit
+	}
+	if debit != credit {
+		return ErrUnbalanced
+	}
+
+	entry := &Entry{
+		Key:         key,
+		Kind:        kind,
+		Reference:   reference,
+		Description: description,
+		OccurredAt:  l.now(),
+		Lines:       lines,
+	}
+	if err := l.store.Append(entry); err != nil && !errors.Is(err, ErrDuplicateEntry) {
+		return err
+	}
+	return nil
+}
+
+// Balance возвращает сальдо счета на момент at с учетом его типа.
+func (l *Ledger) Balance(account string, at time.Time) (int64, error) {
+	accountType := AccountType(account)
+	if accountType == "" {
+		return 0, ErrUnknownAccount
+	}
+
+	debit, credit, err := l.store.Totals(account, at)
+	if err != nil {
+		return 0, err
+	}
+	if accountType == TypeAsset {
+		return debit - credit, nil
+	}
+	return credit - debit, nil
+}
+
+// Statement возвращает проводки по счету за период [from, to).
+func (l *Ledger) Statement(account string, from, to time.Time) ([]Entry, error) {
+	if AccountType(account) == "" {
+		return nil, ErrUnknownAccount
+	}
+	return l.store.Entries(account, from, to)
+}
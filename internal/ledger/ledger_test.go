@@ -0,0 +1,271 @@
+package ledger
+
+import (
+	"errors"
+	"sort"
+	"testing"
+	"time"
+)
+
+// memoryStore хранит журнал в памяти с теми же правилами, что GormStore:
+// повторный Key не записывается и возвращает ErrDuplicateEntry.
+type memoryStore struct {
+	entries []Entry
+}
+
+func (s *memoryStore) Append(entry *Entry) error {
+	for _, existing := range s.entries {
+		if existing.Key == entry.Key {
+			return ErrDuplicateEntry
+		}
+	}
+	copied := *entry
+	copied.Lines = append([]Line(nil), entry.Lines...)
+	s.entries = append(s.entries, copied)
+	return nil
+}
+
+func (s *memoryStore) Totals(account string, at time.Time) (int64, int64, error) {
+	var debit, credit int64
+	for _, entry := range s.entries {
+		if entry.OccurredAt.After(at) {
+			continue
+		}
+		for _, line := range entry.Lines {
+			if line.Account == account {
+				debit += line.Debit
+				credit += line.Credit
+			}
+		}
+	}
+	return debit, credit, nil
+}
+
+func (s *memoryStore) Entries(account string, from, to time.Time) ([]Entry, error) {
+	var result []Entry
+	for _, entry := range s.entries {
+		if entry.OccurredAt.Before(from) || !entry.OccurredAt.Before(to) {
+			continue
+		}
+		for _, line := range entry.Lines {
+			if line.Account == account {
+				result = append(result, entry)
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func (s *memoryStore) NetByReference(account string, references []string) (map[string]int64, error) {
+	wanted := make(map[string]bool, len(references))
+	for _, reference := range references {
+		wanted[reference] = true
+	}
+
+	result := make(map[string]int64, len(references))
+	for _, entry := range s.entries {
+		if !wanted[entry.Reference] {
+			continue
+		}
+		for _, line := range entry.Lines {
+			if line.Account == account {
+				result[entry.Reference] += line.Debit - line.Credit
+			}
+		}
+	}
+	return result, nil
+}
+
+func (s *memoryStore) FirstPostedReferences(account string, from, to time.Time) ([]string, error) {
+	first := map[string]time.Time{}
+	for _, entry := range s.entries {
+		for _, line := range entry.Lines {
+			if line.Account != account {
+				continue
+			}
+			if at, ok := first[entry.Reference]; !ok || entry.OccurredAt.Before(at) {
+				first[entry.Reference] = entry.OccurredAt
+			}
+		}
+	}
+
+	var result []string
+	for reference, at := range first {
+		if !at.Before(from) && at.Before(to) {
+			result = append(result, reference)
+		}
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// testClock — часы журнала, которые тест переставляет вручную.
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func newTestLedger(start time.Time) (*Ledger, *memoryStore, *testClock) {
+	store := &memoryStore{}
+	clock := &testClock{now: start}
+	return New(store).WithClock(clock.Now), store, clock
+}
+
+func TestPostRejectsInvalidEntries(t *testing.T) {
+	l, store, _ := newTestLedger(time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC))
+	wallet := Account(CustomerWallet, "payer")
+
+	tests := []struct {
+		name  string
+		lines []Line
+		want  error
+	}{
+		{"single line", []Line{Debit(ProviderClearing, 100)}, ErrUnbalanced},
+		{"unbalanced", []Line{Debit(ProviderClearing, 100), Credit(wallet, 90)}, ErrUnbalanced},
+		{"zero amount", []Line{Debit(ProviderClearing, 0), Credit(wallet, 0)}, ErrInvalidLine},
+		{"negative amount", []Line{Debit(ProviderClearing, -100), Credit(wallet, -100)}, ErrInvalidLine},
+		{"debit and credit", []Line{{Account: ProviderClearing, Debit: 100, Credit: 100}, Credit(wallet, 0)}, ErrInvalidLine},
+		{"unknown account", []Line{Debit("cash", 100), Credit(wallet, 100)}, ErrUnknownAccount},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := l.Post("key:"+tt.name, "test", "ref", "test", tt.lines...)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Post() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+	if len(store.entries) != 0 {
+		t.Fatalf("rejected entries were stored: %d", len(store.entries))
+	}
+}
+
+func TestPostIsIdempotentByKey(t *testing.T) {
+	l, store, _ := newTestLedger(time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC))
+
+	for i := 0; i < 2; i++ {
+		if err := l.PaymentCaptured("pay-1", "payer", Account(Escrow, "order"), 5000); err != nil {
+			t.Fatalf("PaymentCaptured() attempt %d error = %v", i+1, err)
+		}
+	}
+	if len(store.entries) != 1 {
+		t.Fatalf("entries = %d, want 1", len(store.entries))
+	}
+}
+
+func TestBalanceSignFollowsAccountType(t *testing.T) {
+	l, _, clock := newTestLedger(time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC))
+	escrow := Account(Escrow, "order")
+	payable := Account(ExecutorPayable, "executor")
+
+	if err := l.PaymentCaptured("pay-1", "payer", escrow, 10000); err != nil {
+		t.Fatalf("PaymentCaptured() error = %v", err)
+	}
+	clock.now = clock.now.Add(time.Hour)
+	if err := l.MilestoneReleased("ms-1", "order", "executor", 9000, 1000); err != nil {
+		t.Fatalf("MilestoneReleased() error = %v", err)
+	}
+
+	tests := []struct {
+		account string
+		want    int64
+	}{
+		{ProviderClearing, 10000},             // актив: дебет минус кредит
+		{escrow, 0},                           // обязательство: кредит минус дебет
+		{payable, 9000},                       // обязательство
+		{PlatformRevenue, 1000},               // выручка: кредит минус дебет
+		{Account(CustomerWallet, "payer"), 0}, // деньги прошли через кошелек транзитом
+		{Account(CoachPayable, "coach"), 0},   // счет без проводок
+	}
+	for _, tt := range tests {
+		got, err := l.Balance(tt.account, clock.now)
+		if err != nil {
+			t.Fatalf("Balance(%s) error = %v", tt.account, err)
+		}
+		if got != tt.want {
+			t.Errorf("Balance(%s) = %d, want %d", tt.account, got, tt.want)
+		}
+	}
+
+	before, err := l.Balance(escrow, clock.now.Add(-time.Minute))
+	if err != nil {
+		t.Fatalf("Balance(escrow) before release error = %v", err)
+	}
+	if before != 10000 {
+		t.Errorf("Balance(escrow) before release = %d, want 10000", before)
+	}
+
+	if _, err := l.Balance("cash", clock.now); !errors.Is(err, ErrUnknownAccount) {
+		t.Errorf("Balance(cash) error = %v, want %v", err, ErrUnknownAccount)
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	l, _, clock := newTestLedger(from.Add(-time.Hour))
+	escrow := Account(Escrow, "order")
+
+	// pay-0 проведен до периода и у провайдера тоже в прошлом периоде
+	if err := l.PaymentCaptured("pay-0", "payer", escrow, 700); err != nil {
+		t.Fatal(err)
+	}
+
+	clock.now = from.Add(24 * time.Hour)
+	for _, p := range []struct {
+		id     string
+		amount int64
+	}{{"pay-1", 5000}, {"pay-2", 3000}, {"pay-3", 2000}, {"pay-4", 900}} {
+		if err := l.PaymentCaptured(p.id, "payer", escrow, p.amount); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Возврат по pay-1 проведен уже после периода: сверка все равно должна его учесть,
+	// потому что провайдер отдает текущее состояние платежа
+	clock.now = to.Add(24 * time.Hour)
+	if err := l.PaymentRefunded("pay-1", "payer", escrow, 1000, 1000); err != nil {
+		t.Fatal(err)
+	}
+
+	records := []ProviderRecord{
+		{ChargeID: "ch-1", Reference: "pay-1", Captured: 5000, Refunded: 1000},
+		{ChargeID: "ch-2", Reference: "pay-2", Captured: 3000},
+		{ChargeID: "ch-3", Reference: "pay-3", Captured: 2500}, // в журнале 2000
+		{ChargeID: "ch-5", Reference: "pay-5", Captured: 400},  // нет в журнале
+		// pay-4 нет у провайдера
+	}
+
+	report, err := l.Reconcile(records, from, to)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	if report.Matched != 2 {
+		t.Errorf("Matched = %d, want 2", report.Matched)
+	}
+	if report.ProviderNet != 4000+3000+2500+400 {
+		t.Errorf("ProviderNet = %d, want %d", report.ProviderNet, 4000+3000+2500+400)
+	}
+	if report.LedgerNet != 4000+3000+2000+900 {
+		t.Errorf("LedgerNet = %d, want %d", report.LedgerNet, 4000+3000+2000+900)
+	}
+
+	want := []Discrepancy{
+		{Reference: "pay-3", ChargeID: "ch-3", ProviderNet: 2500, LedgerNet: 2000},
+		{Reference: "pay-4", LedgerNet: 900},
+		{Reference: "pay-5", ChargeID: "ch-5", ProviderNet: 400},
+	}
+	if len(report.Discrepancies) != len(want) {
+		t.Fatalf("Discrepancies = %+v, want %+v", report.Discrepancies, want)
+	}
+	for i := range want {
+		if report.Discrepancies[i] != want[i] {
+			t.Errorf("Discrepancies[%d] = %+v, want %+v", i, report.Discrepancies[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package ledger
+
+import "fmt"
+
+// Виды проводок, которые делают платежи и эскроу.
+const (
+	KindPaymentCaptured   = "payment_captured"
+	KindPaymentRefunded   = "payment_refunded"
+	KindMilestoneReleased = "milestone_released"
+)
+
+// PaymentCaptured отражает списание платежа: деньги поступают через провайдера
+// в кошелек плательщика и сразу резервируются на holdAccount — эскроу заказа или
+// счет к выплате коучу.
+func (l *Ledger) PaymentCaptured(paymentID, payerID, holdAccount string, amount int64) error {
+	wallet := Account(CustomerWallet, payerID)
+	return l.Post(KindPaymentCaptured+":"+paymentID, KindPaymentCaptured, paymentID,
+		"Списание платежа",
+		Debit(ProviderClearing, amount),
+		Credit(wallet, amount),
+		Debit(wallet, amount),
+		Credit(holdAccount, amount),
+	)
+}
+
+// PaymentRefunded отражает возврат amount плательщику с holdAccount.
+// refundedTotal — сумма всех возвратов по платежу после этого, она различает частичные возвраты.
+func (l *Ledger) PaymentRefunded(paymentID, payerID, holdAccount string, amount, refundedTotal int64) error {
+	wallet := Account(CustomerWallet, payerID)
+	return l.Post(fmt.Sprintf("%s:%s:%d", KindPaymentRefunded, paymentID, refundedTotal), KindPaymentRefunded, paymentID,
+		"Возврат платежа",
+		Debit(holdAccount, amount),
+		Credit(wallet, amount),
+		Debit(wallet, amount),
+		Credit(ProviderClearing, amount),
+	)
+}
+
+// MilestoneReleased отражает выплату этапа заказа: деньги уходят с эскроу заказа
+// исполнителю за вычетом комиссии платформы.
+func (l *Ledger) MilestoneReleased(milestoneID, orderID, executorID string, payout, commission int64) error {
+	lines := []Line{
+		Debit(Account(Escrow, orderID), payout+commission),
+		Credit(Account(ExecutorPayable, executorID), payout),
+	}
+	if commission > 0 {
+		lines = append(lines, Credit(PlatformRevenue, commission))
+	}
+	return l.Post(KindMilestoneReleased+":"+milestoneID, KindMilestoneReleased, milestoneID,
+		"Выплата этапа заказа", lines...)
+}
@@ -0,0 +1,101 @@
+package ledger
+
+import (
+	"sort"
+	"time"
+)
+
+// ProviderRecord — платеж по данным провайдера. Суммы в тиынах.
+type ProviderRecord struct {
+	ChargeID  string
+	Reference string // ID платежа в BuhPro
+	Captured  int64
+	Refunded  int64
+}
+
+// Discrepancy — расхождение между провайдером и журналом по одному платежу.
+type Discrepancy struct {
+	Reference   string
+	ChargeID    string
+	ProviderNet int64
+	LedgerNet   int64
+}
+
+// ReconciliationReport — сверка поступлений через провайдера со счетом provider_clearing.
+type ReconciliationReport struct {
+	From          time.Time
+	To            time.Time
+	ProviderNet   int64
+	LedgerNet     int64
+	Matched       int
+	Discrepancies []Discrepancy
+}
+
+// Reconcile сверяет чистые поступления (списано минус возвращено) по каждому платежу
+// провайдера, созданному за период [from, to), с оборотом счета provider_clearing по этому
+// платежу за все время: списание или возврат могут быть проведены уже после периода.
+// Платеж, который есть только у провайдера, или платеж из журнала, первое поступление
+// по которому попало в период, но которого нет у провайдера, тоже считается расхождением.
+func (l *Ledger) Reconcile(records []ProviderRecord, from, to time.Time) (*ReconciliationReport, error) {
+	seen := make(map[string]bool, len(records))
+	references := make([]string, 0, len(records))
+	for _, record := range records {
+		if !seen[record.Reference] {
+			seen[record.Reference] = true
+			references = append(references, record.Reference)
+		}
+	}
+
+	posted, err := l.store.FirstPostedReferences(ProviderClearing, from, to)
+	if err != nil {
+		return nil, err
+	}
+	var journalOnly []string
+	for _, reference := range posted {
+		if !seen[reference] {
+			journalOnly = append(journalOnly, reference)
+		}
+	}
+
+	ledgerNet, err := l.store.NetByReference(ProviderClearing, append(references, journalOnly...))
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ReconciliationReport{From: from, To: to}
+
+	for _, record := range records {
+		providerNet := record.Captured - record.Refunded
+		journalNet := ledgerNet[record.Reference]
+
+		report.ProviderNet += providerNet
+		report.LedgerNet += journalNet
+		if providerNet == journalNet {
+			report.Matched++
+			continue
+		}
+		report.Discrepancies = append(report.Discrepancies, Discrepancy{
+			Reference:   record.Reference,
+			ChargeID:    record.ChargeID,
+			ProviderNet: providerNet,
+			LedgerNet:   journalNet,
+		})
+	}
+
+	for _, reference := range journalOnly {
+		journalNet := ledgerNet[reference]
+		if journalNet == 0 {
+			continue
+		}
+		report.LedgerNet += journalNet
+		report.Discrepancies = append(report.Discrepancies, Discrepancy{
+			Reference: reference,
+			LedgerNet: journalNet,
+		})
+	}
+
+	sort.Slice(report.Discrepancies, func(i, j int) bool {
+		return report.Discrepancies[i].Reference < report.Discrepancies[j].Reference
+	})
+	return report, nil
+}
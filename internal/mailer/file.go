@@ -0,0 +1,53 @@
+package mailer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// FileMailer сохраняет каждое письмо в отдельный .eml файл в каталоге dir
+// и пишет запись в лог. Используется для разработки и тестов вместо реальной отправки.
+type FileMailer struct {
+	dir    string
+	from   string
+	logger *logrus.Logger
+}
+
+func NewFileMailer(dir, from string, logger *logrus.Logger) *FileMailer {
+	return &FileMailer{dir, from, logger}
+}
+
+func (m *FileMailer) Send(msg Message) error {
+	if err := os.MkdirAll(m.dir, 0755); err != nil {
+		return fmt.Errorf("create mail dir: %w", err)
+	}
+
+	name := fmt.Sprintf("%d_%s.eml", time.Now().UnixNano(), sanitizeFileName(msg.To))
+	path := filepath.Join(m.dir, name)
+	if err := os.WriteFile(path, buildMessage(m.from, msg), 0644); err != nil {
+		return fmt.Errorf("write mail file: %w", err)
+	}
+
+	m.logger.WithFields(logrus.Fields{
+		"to":      msg.To,
+		"subject": msg.Subject,
+		"file":    path,
+	}).Info("Mail saved to file")
+	return nil
+}
+
+func sanitizeFileName(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_', r == '@':
+			return r
+		default:
+			return '_'
+		}
+	}, s)
+}
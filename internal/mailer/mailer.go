@@ -0,0 +1,14 @@
+package mailer
+
+// Message — письмо в простом текстовом формате.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer отправляет письма. Реализации: SMTPMailer для продакшена
+// и FileMailer для локальной разработки и тестов.
+type Mailer interface {
+	Send(msg Message) error
+}
@@ -0,0 +1,179 @@
+package payments
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FakeProvider — полностью локальный провайдер для разработки и тестов. Платежи
+// хранятся в памяти процесса, оплата плательщиком имитируется методом Simulate,
+// уведомления подписываются HMAC-SHA256 так же, как у настоящего провайдера.
+type FakeProvider struct {
+	secret []byte
+
+	mu      sync.Mutex
+	charges map[string]*Charge
+}
+
+func NewFakeProvider(secret string) *FakeProvider {
+	return &FakeProvider{
+		secret:  []byte(secret),
+		charges: make(map[string]*Charge),
+	}
+}
+
+func (p *FakeProvider) Name() string {
+	return "fake"
+}
+
+func (p *FakeProvider) Create(req CreateRequest) (*Charge, error) {
+	if req.Amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	id := "fake_" + uuid.NewString()
+	charge := &Charge{
+		ID:          id,
+		Reference:   req.Reference,
+		Status:      StatusPending,
+		Amount:      req.Amount,
+		CheckoutURL: "/payments/fake/" + id,
+		CreatedAt:   time.Now(),
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.charges[id] = charge
+
+	result := *charge
+	return &result, nil
+}
+
+func (p *FakeProvider) Capture(chargeID string, amount int64) (*Charge, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	charge, ok := p.charges[chargeID]
+	if !ok {
+		return nil, ErrChargeNotFound
+	}
+	if charge.Status == StatusCaptured {
+		result := *charge
+		return &result, nil
+	}
+	if charge.Status != StatusAuthorized {
+		return nil, ErrInvalidState
+	}
+	if amount <= 0 || amount > charge.Amount {
+		return nil, ErrInvalidAmount
+	}
+
+	charge.Captured = amount
+	charge.Status = StatusCaptured
+
+	result := *charge
+	return &result, nil
+}
+
+func (p *FakeProvider) Refund(chargeID string, amount int64) (*Charge, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	charge, ok := p.charges[chargeID]
+	if !ok {
+		return nil, ErrChargeNotFound
+	}
+	switch charge.Status {
+	case StatusAuthorized:
+		charge.Status = StatusRefunded
+	case StatusCaptured:
+		if amount <= 0 || amount > charge.Captured-charge.Refunded {
+			return nil, ErrInvalidAmount
+		}
+		charge.Refunded += amount
+		if charge.Refunded == charge.Captured {
+			charge.Status = StatusRefunded
+		}
+	default:
+		return nil, ErrInvalidState
+	}
+
+	result := *charge
+	return &result, nil
+}
+
+func (p *FakeProvider) ParseWebhook(payload []byte, signature string) (*Event, error) {
+	if !hmac.Equal([]byte(p.Sign(payload)), []byte(signature)) {
+		return nil, ErrInvalidSignature
+	}
+
+	var event Event
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+func (p *FakeProvider) ListCharges(from, to time.Time) ([]Charge, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var result []Charge
+	for _, charge := range p.charges {
+		if !charge.CreatedAt.Before(from) && charge.CreatedAt.Before(to) {
+			result = append(result, *charge)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CreatedAt.Before(result[j].CreatedAt) })
+	return result, nil
+}
+
+// Simulate имитирует действие плательщика на странице оплаты: status — authorized
+// (оплата прошла) или failed (отказ). Возвращает подписанное уведомление, которое
+// настоящий провайдер отправил бы на вебхук.
+func (p *FakeProvider) Simulate(chargeID, status, reason string) ([]byte, string, error) {
+	if status != StatusAuthorized && status != StatusFailed {
+		return nil, "", ErrInvalidState
+	}
+
+	p.mu.Lock()
+	charge, ok := p.charges[chargeID]
+	if !ok {
+		p.mu.Unlock()
+		return nil, "", ErrChargeNotFound
+	}
+	if charge.Status != StatusPending {
+		p.mu.Unlock()
+		return nil, "", ErrInvalidState
+	}
+	charge.Status = status
+	event := Event{
+		ID:        "evt_" + uuid.NewString(),
+		ChargeID:  charge.ID,
+		Reference: charge.Reference,
+		Status:    status,
+		Amount:    charge.Amount,
+		Reason:    reason,
+	}
+	p.mu.Unlock()
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, "", err
+	}
+	return payload, p.Sign(payload), nil
+}
+
+// Sign возвращает подпись уведомления: hex(HMAC-SHA256(secret, payload)).
+func (p *FakeProvider) Sign(payload []byte) string {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
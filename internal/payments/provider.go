@@ -0,0 +1,70 @@
+package payments
+
+import (
+	"errors"
+	"time"
+)
+
+// Статусы платежа у провайдера. Совпадают со статусами domain.Payment.
+const (
+	StatusPending    = "pending"
+	StatusAuthorized = "authorized"
+	StatusCaptured   = "captured"
+	StatusRefunded   = "refunded"
+	StatusFailed     = "failed"
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrChargeNotFound   = errors.New("charge not found")
+	ErrInvalidAmount    = errors.New("invalid amount")
+	ErrInvalidState     = errors.New("operation is not allowed in the current charge status")
+)
+
+// CreateRequest — запрос на создание платежа. Amount в тиынах.
+type CreateRequest struct {
+	Reference   string // ID платежа в BuhPro, провайдер возвращает его в уведомлениях
+	Amount      int64
+	Currency    string
+	Description string
+}
+
+// Charge — платеж на стороне провайдера. Суммы в тиынах.
+type Charge struct {
+	ID          string
+	Reference   string
+	Status      string
+	Amount      int64
+	Captured    int64
+	Refunded    int64
+	CheckoutURL string // страница оплаты, на которую отправляется плательщик
+	CreatedAt   time.Time
+}
+
+// Event — уведомление провайдера о смене статуса платежа.
+// ID уникален у провайдера: по нему отсекаются повторные доставки.
+type Event struct {
+	ID        string `json:"id"`
+	ChargeID  string `json:"charge_id"`
+	Reference string `json:"reference"`
+	Status    string `json:"status"`
+	Amount    int64  `json:"amount"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// Provider — платежный провайдер. Платеж создается со статусом pending, после оплаты
+// плательщиком провайдер присылает уведомление authorized или failed; списание
+// и возврат выполняются явно через Capture и Refund.
+// Реализации: FakeProvider для разработки и тестов.
+type Provider interface {
+	Name() string
+	Create(req CreateRequest) (*Charge, error)
+	Capture(chargeID string, amount int64) (*Charge, error)
+	// Refund возвращает amount со списанного платежа или снимает блокировку с авторизованного
+	// (тогда amount не учитывается и Refunded не меняется).
+	Refund(chargeID string, amount int64) (*Charge, error)
+	// ParseWebhook проверяет подпись уведомления и разбирает его.
+	ParseWebhook(payload []byte, signature string) (*Event, error)
+	// ListCharges возвращает платежи, созданные за период [from, to), — для сверки с журналом.
+	ListCharges(from, to time.Time) ([]Charge, error)
+}
@@ -0,0 +1,98 @@
+// Package phone приводит телефонные номера к формату E.164 (+77011234567).
+// Казахстанские номера принимаются в привычных записях: 8 701 …, +7 701 …, 7 701 …, 701 ….
+package phone
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidNumber — номер не удалось привести к E.164.
+var ErrInvalidNumber = errors.New("phone number must be in E.164 format or a Kazakhstan number")
+
+// Номер в E.164: «+» и до 15 цифр, код страны не начинается с нуля.
+const (
+	minDigits = 8
+	maxDigits = 15
+)
+
+// Number — телефон в формате E.164.
+type Number string
+
+// Parse приводит номер к E.164. Пробелы, дефисы, точки и скобки отбрасываются.
+// Номер без «+» считается казахстанским: ведущая 8 или 7 заменяется кодом +7,
+// десять цифр без кода (701 123 45 67) дополняются им.
+func Parse(value string) (Number, error) {
+	value = strings.TrimSpace(value)
+	international := strings.HasPrefix(value, "+")
+	digits := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.', '(', ')':
+			return -1
+		}
+		return r
+	}, strings.TrimPrefix(value, "+"))
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return "", ErrInvalidNumber
+		}
+	}
+
+	if !international {
+		switch {
+		case len(digits) == 11 && (digits[0] == '8' || digits[0] == '7'):
+			digits = "7" + digits[1:]
+		case len(digits) == 10 && digits[0] != '0':
+			digits = "7" + digits
+		default:
+			return "", ErrInvalidNumber
+		}
+	}
+
+	if len(digits) < minDigits || len(digits) > maxDigits || digits[0] == '0' {
+		return "", ErrInvalidNumber
+	}
+	// В зоне +7 (Казахстан и Россия) после кода страны всегда десять цифр
+	if digits[0] == '7' && len(digits) != 11 {
+		return "", ErrInvalidNumber
+	}
+	return Number("+" + digits), nil
+}
+
+// Format возвращает номер для показа: +7 701 123 45 67. Номера других стран — как в E.164.
+func (n Number) Format() string {
+	s := string(n)
+	if len(s) != 12 || !strings.HasPrefix(s, "+7") {
+		return s
+	}
+	return s[0:2] + " " + s[2:5] + " " + s[5:8] + " " + s[8:10] + " " + s[10:12]
+}
+
+func (n Number) String() string {
+	return string(n)
+}
+
+// UnmarshalJSON принимает номер строкой или, как раньше, числом и приводит его к E.164.
+// Номер, который не удалось разобрать, сохраняется как есть: его отклонит тег валидатора phone.
+func (n *Number) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		var number json.Number
+		if err := json.Unmarshal(data, &number); err != nil {
+			return ErrInvalidNumber
+		}
+		if _, err := strconv.ParseUint(number.String(), 10, 64); err != nil {
+			return ErrInvalidNumber
+		}
+		value = number.String()
+	}
+
+	if parsed, err := Parse(value); err == nil {
+		*n = parsed
+		return nil
+	}
+	*n = Number(value)
+	return nil
+}
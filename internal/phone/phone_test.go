@@ -0,0 +1,100 @@
+package phone
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  Number
+	}{
+		{"8 and mobile code", "8 701 123 45 67", "+77011234567"},
+		{"8 without spaces", "87011234567", "+77011234567"},
+		{"8 with brackets and dashes", "8 (701) 123-45-67", "+77011234567"},
+		{"+7 and mobile code", "+7 701 123 45 67", "+77011234567"},
+		{"+7 with dots", "+7.701.123.45.67", "+77011234567"},
+		{"7 without plus", "77011234567", "+77011234567"},
+		{"10 digits without code", "701 123 45 67", "+77011234567"},
+		{"10 digits city number", "7272123456", "+77272123456"},
+		{"surrounding spaces", "  +77011234567  ", "+77011234567"},
+		{"other country", "+49 30 123456", "+4930123456"},
+		{"max length", "+123456789012345", "+123456789012345"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.value)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRejects(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{"empty", ""},
+		{"letters", "8 701 ABC 45 67"},
+		{"9 digits", "701123456"},
+		{"12 digits without plus", "870112345678"},
+		{"11 digits not starting with 7 or 8", "97011234567"},
+		{"10 digits starting with 0", "0701234567"},
+		{"+7 with 9 digits", "+7701123456"},
+		{"+7 with 11 digits", "+770112345678"},
+		{"country code 0", "+0701123456"},
+		{"too short", "+1234567"},
+		{"too long", "+1234567890123456"},
+		{"plus inside", "8701+1234567"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := Parse(tt.value); !errors.Is(err, ErrInvalidNumber) {
+				t.Fatalf("Parse(%q) = %q, %v; want %v", tt.value, got, err, ErrInvalidNumber)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	if got := Number("+77011234567").Format(); got != "+7 701 123 45 67" {
+		t.Errorf("Format() = %q", got)
+	}
+	if got := Number("+4930123456").Format(); got != "+4930123456" {
+		t.Errorf("Format() of foreign number = %q", got)
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		data string
+		want Number
+	}{
+		{`"8 701 123 45 67"`, "+77011234567"},
+		{`87011234567`, "+77011234567"},
+		{`"not a phone"`, "not a phone"}, // отклонит валидатор
+	}
+	for _, tt := range tests {
+		var got Number
+		if err := json.Unmarshal([]byte(tt.data), &got); err != nil {
+			t.Errorf("Unmarshal(%s) error = %v", tt.data, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Unmarshal(%s) = %q, want %q", tt.data, got, tt.want)
+		}
+	}
+
+	var got Number
+	if err := json.Unmarshal([]byte(`-87011234567`), &got); !errors.Is(err, ErrInvalidNumber) {
+		t.Errorf("Unmarshal(negative) error = %v, want %v", err, ErrInvalidNumber)
+	}
+}
@@ -0,0 +1,29 @@
+package ratelimit
+
+import "time"
+
+// Limiter ограничивает число запросов с одного ключа (например, IP) фиксированным окном.
+type Limiter struct {
+	store  Store
+	prefix string
+	limit  int64
+	window time.Duration
+}
+
+// NewLimiter разрешает limit запросов за window. prefix отделяет счетчики разных лимитов в общем Store.
+func NewLimiter(store Store, prefix string, limit int64, window time.Duration) *Limiter {
+	return &Limiter{store, prefix, limit, window}
+}
+
+// Allow учитывает запрос и сообщает, укладывается ли он в лимит.
+// Если нет, retryAfter — время до начала следующего окна.
+func (l *Limiter) Allow(key string) (allowed bool, retryAfter time.Duration, err error) {
+	count, ttl, err := l.store.Increment(l.prefix+":"+key, l.window)
+	if err != nil {
+		return false, 0, err
+	}
+	if count > l.limit {
+		return false, ttl, nil
+	}
+	return true, 0, nil
+}
@@ -0,0 +1,66 @@
+package ratelimit
+
+import "time"
+
+// Lockout блокирует аккаунт после серии неудачных попыток входа.
+// Первые threshold ошибок проходят без блокировки, затем каждая следующая ошибка
+// блокирует аккаунт на baseDelay, 2*baseDelay, 4*baseDelay... но не дольше maxDelay.
+// Счетчик ошибок сбрасывается успешным входом или через failureWindow после последней ошибки.
+type Lockout struct {
+	store         Store
+	threshold     int64
+	baseDelay     time.Duration
+	maxDelay      time.Duration
+	failureWindow time.Duration
+}
+
+func NewLockout(store Store, threshold int64, baseDelay, maxDelay, failureWindow time.Duration) *Lockout {
+	return &Lockout{store, threshold, baseDelay, maxDelay, failureWindow}
+}
+
+// Check возвращает оставшееся время блокировки аккаунта (0, если вход разрешен).
+func (l *Lockout) Check(account string) (time.Duration, error) {
+	locked, ttl, err := l.store.Get(lockKey(account))
+	if err != nil || locked == 0 {
+		return 0, err
+	}
+	return ttl, nil
+}
+
+// Fail учитывает неудачную попытку и возвращает длительность наступившей блокировки
+// (0, если порог еще не превышен) и число ошибок подряд.
+func (l *Lockout) Fail(account string) (time.Duration, int64, error) {
+	failures, _, err := l.store.Increment(failuresKey(account), l.failureWindow)
+	if err != nil {
+		return 0, 0, err
+	}
+	// Increment задает срок жизни только новому счетчику; продлеваем его, чтобы
+	// failureWindow отсчитывалось от последней ошибки, а не от первой
+	if err := l.store.Expire(failuresKey(account), l.failureWindow); err != nil {
+		return 0, failures, err
+	}
+	if failures <= l.threshold {
+		return 0, failures, nil
+	}
+
+	delay := l.baseDelay
+	for i := l.threshold + 1; i < failures && delay < l.maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > l.maxDelay {
+		delay = l.maxDelay
+	}
+
+	if err := l.store.Set(lockKey(account), 1, delay); err != nil {
+		return 0, failures, err
+	}
+	return delay, failures, nil
+}
+
+// Success сбрасывает счетчик ошибок после успешного входа.
+func (l *Lockout) Success(account string) error {
+	return l.store.Delete(failuresKey(account), lockKey(account))
+}
+
+func failuresKey(account string) string { return "login_failures:" + account }
+func lockKey(account string) string     { return "login_lock:" + account }
@@ -0,0 +1,21 @@
+package ratelimit
+
+import "time"
+
+// Store хранит счетчики со сроком жизни. Интерфейс повторяет INCR/EXPIRE/TTL/SET/DEL
+// Redis, чтобы несколько инстансов могли делить счетчики через общий Redis.
+// По умолчанию используется MemoryStore, счетчики которого видны только своему инстансу.
+type Store interface {
+	// Increment увеличивает счетчик key на единицу. Срок жизни ttl задается только
+	// при создании счетчика, поэтому окно не сдвигается с каждым запросом.
+	// Возвращает новое значение и оставшееся время жизни.
+	Increment(key string, ttl time.Duration) (int64, time.Duration, error)
+	// Get возвращает значение и оставшееся время жизни; для отсутствующего ключа — 0, 0.
+	Get(key string) (int64, time.Duration, error)
+	// Expire задает существующему ключу новый срок жизни; отсутствующий ключ не создается.
+	Expire(key string, ttl time.Duration) error
+	// Set записывает значение с новым сроком жизни.
+	Set(key string, value int64, ttl time.Duration) error
+	// Delete удаляет ключи.
+	Delete(keys ...string) error
+}
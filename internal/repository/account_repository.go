@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"BruhPro/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type AccountRepository interface {
+	GetByEmail(email string) (*domain.Account, error)
+	GetByID(id string) (*domain.Account, error)
+	GetRoles(accountID string) ([]string, error)
+	AttachRole(account *domain.Account, role string, profile interface{}) error
+	UpdatePassword(id, passwordHash string) error
+}
+
+type accountRepository struct {
+	db *gorm.DB
+}
+
+func NewAccountRepository(db *gorm.DB) AccountRepository {
+	return &accountRepository{db}
+}
+
+func (r *accountRepository) GetByEmail(email string) (*domain.Account, error) {
+	var account domain.Account
+	err := r.db.Where("email = ?", email).First(&account).Error
+	return &account, err
+}
+
+func (r *accountRepository) GetByID(id string) (*domain.Account, error) {
+	var account domain.Account
+	err := r.db.First(&account, "id = ?", id).Error
+	return &account, err
+}
+
+func (r *accountRepository) GetRoles(accountID string) ([]string, error) {
+	var roles []string
+	err := r.db.Model(&domain.AccountRole{}).
+		Where("account_id = ?", accountID).
+		Order("created_at").
+		Pluck("role", &roles).Error
+	return roles, err
+}
+
+// AttachRole в одной транзакции создает аккаунт (если его еще нет), добавляет ему роль
+// и сохраняет профиль этой роли. profile может быть nil для ролей без профиля (user, admin).
+func (r *accountRepository) AttachRole(account *domain.Account, role string, profile interface{}) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.FirstOrCreate(account, "id = ?", account.ID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Create(&domain.AccountRole{AccountID: account.ID, Role: role}).Error; err != nil {
+			return err
+		}
+
+		// Специализации профиля только связываются со справочником, сами записи справочника не сохраняются
+		if profile != nil {
+			return tx.Omit("Specializations.*").Create(profile).Error
+		}
+		return nil
+	})
+}
+
+func (r *accountRepository) UpdatePassword(id, passwordHash string) error {
+	return r.db.Model(&domain.Account{}).Where("id = ?", id).Update("password_hash", passwordHash).Error
+}
@@ -1,17 +1,31 @@
 package repository
 
 import (
-	"BuhPro+/internal/domain" // Обновлен импорт
+	"BruhPro/internal/domain" // Обновлен импорт
 
 	"gorm.io/gorm"
 )
 
+// coachSearchText — текст профиля коуча, из которого берется фрагмент для выдачи поиска.
+const coachSearchText = "coaches.about_coach || ' ' || coaches.methodology || ' ' || coaches.achievements_experience"
+
+// CoachFilter — условия публичного поиска коучей. Пустые поля не ограничивают выборку.
+// Query — полнотекстовый запрос по описанию, методологии и достижениям.
+type CoachFilter struct {
+	Query          string
+	Specialization string
+	Sort           string
+	VerifiedOnly   bool // только аккаунты с подтвержденным email
+	After          *SearchCursor
+	Limit          int
+}
+
+// CoachRepository работает только с профилем; аккаунт и роль создаются через AccountRepository.AttachRole.
 type CoachRepository interface {
-	Create(coach *domain.Coach) error
-	GetByEmail(email string) (*domain.Coach, error)
 	GetByID(id string) (*domain.Coach, error)
-	CreateRefreshToken(token *domain.RefreshToken) error
-	GetRefreshToken(token string) (*domain.RefreshToken, error)
+	Update(update ProfileUpdate) error
+	ListChanges(id string, limit, offset int) ([]domain.ProfileChange, error)
+	Search(filter CoachFilter) ([]domain.Coach, error)
 }
 
 type coachRepository struct {
@@ -22,28 +36,60 @@ func NewCoachRepository(db *gorm.DB) CoachRepository {
 	return &coachRepository{db}
 }
 
-func (r *coachRepository) Create(coach *domain.Coach) error {
-	return r.db.Create(coach).Error
-}
-
-func (r *coachRepository) GetByEmail(email string) (*domain.Coach, error) {
+func (r *coachRepository) GetByID(id string) (*domain.Coach, error) {
 	var coach domain.Coach
-	err := r.db.Where("email = ?", email).First(&coach).Error
+	err := r.db.Preload("Specializations", preloadSpecializations).
+		Select("coaches.*, accounts.email, accounts.email_verified_at, "+ratingStatsColumns).
+		Joins("JOIN accounts ON accounts.id = coaches.id").
+		Joins("LEFT JOIN rating_summaries ON rating_summaries.target_id = coaches.id AND rating_summaries.target_role = ?", domain.RoleCoach).
+		First(&coach, "coaches.id = ?", id).Error
 	return &coach, err
 }
 
-func (r *coachRepository) GetByID(id string) (*domain.Coach, error) {
-	var coach domain.Coach
-	err := r.db.First(&coach, "id = ?", id).Error
-	return &coach, err
+// Update применяет изменения профиля; связи со специализациями заменяются целиком.
+func (r *coachRepository) Update(update ProfileUpdate) error {
+	return updateProfile(r.db, "coaches", "coach_specializations", "coach_id", update)
 }
 
-func (r *coachRepository) CreateRefreshToken(token *domain.RefreshToken) error {
-	return r.db.Create(token).Error
+func (r *coachRepository) ListChanges(id string, limit, offset int) ([]domain.ProfileChange, error) {
+	return listProfileChanges(r.db, id, domain.RoleCoach, limit, offset)
 }
 
-func (r *coachRepository) GetRefreshToken(token string) (*domain.RefreshToken, error) {
-	var refreshToken domain.RefreshToken
-	err := r.db.Where("token = ?", token).First(&refreshToken).Error
-	return &refreshToken, err
+// Search возвращает коучей в порядке filter.Sort (relevance, rating или recent).
+// Страницы листаются по ключу (значение сортировки, id), начиная после filter.After.
+func (r *coachRepository) Search(filter CoachFilter) ([]domain.Coach, error) {
+	columns := "coaches.*, " + ratingStatsColumns
+	query := r.db.Model(&domain.Coach{}).
+		Joins("JOIN accounts ON accounts.id = coaches.id").
+		Joins("LEFT JOIN rating_summaries ON rating_summaries.target_id = coaches.id AND rating_summaries.target_role = ?", domain.RoleCoach)
+
+	if filter.VerifiedOnly {
+		query = query.Where("accounts.email_verified_at IS NOT NULL")
+	}
+
+	if filter.Query != "" {
+		query = applyTextSearch(query, "coaches", filter.Query)
+		columns += ", " + searchColumns("coaches", coachSearchText)
+	}
+	if filter.Specialization != "" {
+		query = query.Where("EXISTS (SELECT 1 FROM coach_specializations WHERE coach_id = coaches.id AND specialization_code = ?)", filter.Specialization)
+	}
+
+	key := "coaches.created_at"
+	switch filter.Sort {
+	case SortRelevance:
+		key = searchRankExpr("coaches")
+	case SortRating:
+		key = ratingAverageExpr
+	}
+	query = applyKeyset(query, key, "coaches.id", "DESC", filter.Sort == SortRecent, filter.After)
+
+	var coaches []domain.Coach
+	if err := query.Preload("Specializations", preloadSpecializations).Select(columns).Limit(filter.Limit).Find(&coaches).Error; err != nil {
+		return nil, err
+	}
+	for i := range coaches {
+		coaches[i].SearchSnippet = highlightSnippet(coaches[i].SearchSnippet)
+	}
+	return coaches, nil
 }
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"BruhPro/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// CourseFilter — условия выборки курсов каталога. Пустые поля не ограничивают выборку.
+type CourseFilter struct {
+	CoachID        string
+	Status         string
+	Specialization string
+	Limit          int
+	Offset         int
+}
+
+type CourseRepository interface {
+	Create(course *domain.Course) error
+	GetByID(id string) (*domain.Course, error)
+	GetWithContent(id string) (*domain.Course, error)
+	List(filter CourseFilter) ([]domain.Course, error)
+	Update(course *domain.Course) error
+	CreateModule(module *domain.CourseModule) error
+	GetModule(id string) (*domain.CourseModule, error)
+	CreateLesson(lesson *domain.Lesson) error
+	GetLesson(id string) (*domain.Lesson, error)
+}
+
+type courseRepository struct {
+	db *gorm.DB
+}
+
+func NewCourseRepository(db *gorm.DB) CourseRepository {
+	return &courseRepository{db}
+}
+
+func (r *courseRepository) Create(course *domain.Course) error {
+	return r.db.Omit("Modules").Create(course).Error
+}
+
+func (r *courseRepository) GetByID(id string) (*domain.Course, error) {
+	var course domain.Course
+	err := r.db.First(&course, "id = ?", id).Error
+	return &course, err
+}
+
+// GetWithContent загружает курс с разделами, уроками и файлами в порядке Position.
+func (r *courseRepository) GetWithContent(id string) (*domain.Course, error) {
+	var course domain.Course
+	err := r.db.
+		Preload("Modules", func(db *gorm.DB) *gorm.DB { return db.Order("position") }).
+		Preload("Modules.Lessons", func(db *gorm.DB) *gorm.DB { return db.Order("position") }).
+		Preload("Modules.Lessons.Attachments").
+		First(&course, "id = ?", id).Error
+	return &course, err
+}
+
+// List возвращает курсы от новых к старым.
+func (r *courseRepository) List(filter CourseFilter) ([]domain.Course, error) {
+	query := r.db.Model(&domain.Course{})
+	if filter.CoachID != "" {
+		query = query.Where("coach_id = ?", filter.CoachID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Specialization != "" {
+		query = query.Where("specialization = ?", filter.Specialization)
+	}
+
+	var courses []domain.Course
+	err := query.Order("created_at DESC").Limit(filter.Limit).Offset(filter.Offset).Find(&courses).Error
+	return courses, err
+}
+
+// Update сохраняет описание, цену и статус курса.
+func (r *courseRepository) Update(course *domain.Course) error {
+	return r.db.Model(course).Select(
+		"title", "description", "specialization", "price", "status", "published_at",
+	).Updates(course).Error
+}
+
+func (r *courseRepository) CreateModule(module *domain.CourseModule) error {
+	return r.db.Omit("Lessons").Create(module).Error
+}
+
+func (r *courseRepository) GetModule(id string) (*domain.CourseModule, error) {
+	var module domain.CourseModule
+	err := r.db.First(&module, "id = ?", id).Error
+	return &module, err
+}
+
+// CreateLesson сохраняет урок вместе с файлами.
+func (r *courseRepository) CreateLesson(lesson *domain.Lesson) error {
+	return r.db.Create(lesson).Error
+}
+
+func (r *courseRepository) GetLesson(id string) (*domain.Lesson, error) {
+	var lesson domain.Lesson
+	err := r.db.Preload("Attachments").First(&lesson, "id = ?", id).Error
+	return &lesson, err
+}
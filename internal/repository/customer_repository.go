@@ -1,17 +1,16 @@
 package repository
 
 import (
-	"BuhPro+/internal/domain" // Обновлен импорт
+	"BruhPro/internal/domain" // Обновлен импорт
 
 	"gorm.io/gorm"
 )
 
+// CustomerRepository работает только с профилем; аккаунт и роль создаются через AccountRepository.AttachRole.
 type CustomerRepository interface {
-	Create(customer *domain.Customer) error
-	GetByEmail(email string) (*domain.Customer, error)
 	GetByID(id string) (*domain.Customer, error)
-	CreateRefreshToken(token *domain.RefreshToken) error
-	GetRefreshToken(token string) (*domain.RefreshToken, error)
+	Update(update ProfileUpdate) error
+	ListChanges(id string, limit, offset int) ([]domain.ProfileChange, error)
 }
 
 type customerRepository struct {
@@ -22,28 +21,19 @@ func NewCustomerRepository(db *gorm.DB) CustomerRepository {
 	return &customerRepository{db}
 }
 
-func (r *customerRepository) Create(customer *domain.Customer) error {
-	return r.db.Create(customer).Error
-}
-
-func (r *customerRepository) GetByEmail(email string) (*domain.Customer, error) {
-	var customer domain.Customer
-	err := r.db.Where("email = ?", email).First(&customer).Error
-	return &customer, err
-}
-
 func (r *customerRepository) GetByID(id string) (*domain.Customer, error) {
 	var customer domain.Customer
-	err := r.db.First(&customer, "id = ?", id).Error
+	err := r.db.Select("customers.*, accounts.email, accounts.email_verified_at").
+		Joins("JOIN accounts ON accounts.id = customers.id").
+		First(&customer, "customers.id = ?", id).Error
 	return &customer, err
 }
 
-func (r *customerRepository) CreateRefreshToken(token *domain.RefreshToken) error {
-	return r.db.Create(token).Error
+// Update применяет изменения профиля.
+func (r *customerRepository) Update(update ProfileUpdate) error {
+	return updateProfile(r.db, "customers", "", "", update)
 }
 
-func (r *customerRepository) GetRefreshToken(token string) (*domain.RefreshToken, error) {
-	var refreshToken domain.RefreshToken
-	err := r.db.Where("token = ?", token).First(&refreshToken).Error
-	return &refreshToken, err
+func (r *customerRepository) ListChanges(id string, limit, offset int) ([]domain.ProfileChange, error) {
+	return listProfileChanges(r.db, id, domain.RoleCustomer, limit, offset)
 }
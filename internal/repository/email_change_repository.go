@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"BruhPro/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrEmailChangeInvalid возвращается, если ссылка подтверждения или отмены смены email
+	// уже использована, истекла или заявку заменила более новая.
+	ErrEmailChangeInvalid = errors.New("email change link is invalid or expired")
+	// ErrEmailInUse возвращается, если адрес, на который меняется email, занят другим аккаунтом.
+	ErrEmailInUse = errors.New("email is already in use")
+)
+
+type EmailChangeRepository interface {
+	Create(change *domain.EmailChange) error
+	GetByConfirmTokenHash(tokenHash string) (*domain.EmailChange, error)
+	GetByRevertTokenHash(tokenHash string) (*domain.EmailChange, error)
+	Confirm(change *domain.EmailChange, revertTokenHash string, revertExpiresAt time.Time) error
+	Revert(change *domain.EmailChange, resetToken *domain.PasswordResetToken) error
+}
+
+type emailChangeRepository struct {
+	db *gorm.DB
+}
+
+func NewEmailChangeRepository(db *gorm.DB) EmailChangeRepository {
+	return &emailChangeRepository{db}
+}
+
+// Create сохраняет заявку и отменяет неподтвержденные заявки аккаунта,
+// так что действует только ссылка из последнего письма.
+func (r *emailChangeRepository) Create(change *domain.EmailChange) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := cancelPendingEmailChanges(tx, change.AccountID); err != nil {
+			return err
+		}
+		return tx.Create(change).Error
+	})
+}
+
+func (r *emailChangeRepository) GetByConfirmTokenHash(tokenHash string) (*domain.EmailChange, error) {
+	var change domain.EmailChange
+	err := r.db.Where("confirm_token_hash = ?", tokenHash).First(&change).Error
+	return &change, err
+}
+
+func (r *emailChangeRepository) GetByRevertTokenHash(tokenHash string) (*domain.EmailChange, error) {
+	var change domain.EmailChange
+	err := r.db.Where("revert_token_hash = ?", tokenHash).First(&change).Error
+	return &change, err
+}
+
+// Confirm в одной транзакции погашает ссылку подтверждения, сохраняет хеш ссылки отмены
+// и меняет email аккаунта на новый, уже подтвержденный адрес.
+// Если email аккаунта с момента заявки изменился, заявка недействительна.
+func (r *emailChangeRepository) Confirm(change *domain.EmailChange, revertTokenHash string, revertExpiresAt time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&domain.EmailChange{}).
+			Where("id = ? AND confirmed_at IS NULL AND canceled_at IS NULL AND expires_at > ?", change.ID, now).
+			Updates(map[string]interface{}{
+				"confirmed_at":      now,
+				"revert_token_hash": revertTokenHash,
+				"revert_expires_at": revertExpiresAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrEmailChangeInvalid
+		}
+
+		if err := ensureEmailFree(tx, change.NewEmail, change.AccountID); err != nil {
+			return err
+		}
+
+		result = tx.Model(&domain.Account{}).
+			Where("id = ? AND email = ?", change.AccountID, change.OldEmail).
+			Updates(map[string]interface{}{"email": change.NewEmail, "email_verified_at": now})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrEmailChangeInvalid
+		}
+		return nil
+	})
+}
+
+// Revert в одной транзакции погашает ссылку отмены, возвращает аккаунту старый email
+// и отменяет неподтвержденные заявки. Старый адрес возвращается, даже если email
+// с тех пор меняли еще раз: цепочкой смен нельзя отнять у владельца ссылку отмены.
+// Тот, кто сменил адрес, мог знать пароль или подключить свою 2FA, поэтому пароль заменяется
+// непригодным хешем и выдается resetToken для его сброса, а 2FA, подключенная после заявки
+// на смену, отключается вместе с кодами восстановления.
+func (r *emailChangeRepository) Revert(change *domain.EmailChange, resetToken *domain.PasswordResetToken) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&domain.EmailChange{}).
+			Where("id = ? AND confirmed_at IS NOT NULL AND reverted_at IS NULL AND revert_expires_at > ?", change.ID, now).
+			Update("reverted_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrEmailChangeInvalid
+		}
+
+		if err := ensureEmailFree(tx, change.OldEmail, change.AccountID); err != nil {
+			return err
+		}
+		if err := cancelPendingEmailChanges(tx, change.AccountID); err != nil {
+			return err
+		}
+
+		// Пустой хеш не совпадет ни с одним паролем: войти можно только после сброса
+		if err := tx.Model(&domain.Account{}).
+			Where("id = ?", change.AccountID).
+			Updates(map[string]interface{}{"email": change.OldEmail, "email_verified_at": now, "password_hash": ""}).Error; err != nil {
+			return err
+		}
+		if err := createPasswordResetToken(tx, resetToken); err != nil {
+			return err
+		}
+
+		mfa := tx.Where("account_id = ? AND (enabled_at IS NULL OR enabled_at > ?)", change.AccountID, change.CreatedAt).
+			Delete(&domain.AccountMFA{})
+		if mfa.Error != nil {
+			return mfa.Error
+		}
+		if mfa.RowsAffected > 0 {
+			return tx.Where("account_id = ?", change.AccountID).Delete(&domain.MFARecoveryCode{}).Error
+		}
+		return nil
+	})
+}
+
+func cancelPendingEmailChanges(tx *gorm.DB, accountID string) error {
+	return tx.Model(&domain.EmailChange{}).
+		Where("account_id = ? AND confirmed_at IS NULL AND canceled_at IS NULL", accountID).
+		Update("canceled_at", time.Now()).Error
+}
+
+// ensureEmailFree возвращает ErrEmailInUse, если email занят другим аккаунтом.
+// Гонку двух одновременных замен все равно остановит уникальный индекс accounts.email.
+func ensureEmailFree(tx *gorm.DB, email, accountID string) error {
+	var count int64
+	if err := tx.Model(&domain.Account{}).Where("email = ? AND id <> ?", email, accountID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrEmailInUse
+	}
+	return nil
+}
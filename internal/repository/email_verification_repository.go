@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"BruhPro/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// ErrVerificationTokenInvalid возвращается, если токен подтверждения уже использован,
+// истек или email аккаунта с тех пор изменился.
+var ErrVerificationTokenInvalid = errors.New("verification token is invalid or expired")
+
+type EmailVerificationRepository interface {
+	Create(token *domain.EmailVerificationToken) error
+	GetByTokenHash(tokenHash string) (*domain.EmailVerificationToken, error)
+	GetLatest(accountID string) (*domain.EmailVerificationToken, error)
+	Consume(token *domain.EmailVerificationToken) error
+}
+
+type emailVerificationRepository struct {
+	db *gorm.DB
+}
+
+func NewEmailVerificationRepository(db *gorm.DB) EmailVerificationRepository {
+	return &emailVerificationRepository{db}
+}
+
+// Create сохраняет новый токен и погашает ранее выданные, чтобы действовала только последняя ссылка.
+func (r *emailVerificationRepository) Create(token *domain.EmailVerificationToken) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.EmailVerificationToken{}).
+			Where("account_id = ? AND used_at IS NULL", token.AccountID).
+			Update("used_at", time.Now()).Error; err != nil {
+			return err
+		}
+		return tx.Create(token).Error
+	})
+}
+
+func (r *emailVerificationRepository) GetByTokenHash(tokenHash string) (*domain.EmailVerificationToken, error) {
+	var token domain.EmailVerificationToken
+	err := r.db.Where("token_hash = ?", tokenHash).First(&token).Error
+	return &token, err
+}
+
+func (r *emailVerificationRepository) GetLatest(accountID string) (*domain.EmailVerificationToken, error) {
+	var token domain.EmailVerificationToken
+	err := r.db.Where("account_id = ?", accountID).Order("created_at DESC").First(&token).Error
+	return &token, err
+}
+
+// Consume погашает токен и отмечает email аккаунта подтвержденным,
+// если адрес аккаунта совпадает с адресом, на который ушло письмо.
+func (r *emailVerificationRepository) Consume(token *domain.EmailVerificationToken) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&domain.EmailVerificationToken{}).
+			Where("id = ? AND used_at IS NULL AND expires_at > ?", token.ID, now).
+			Update("used_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrVerificationTokenInvalid
+		}
+
+		result = tx.Model(&domain.Account{}).
+			Where("id = ? AND email = ?", token.AccountID, token.Email).
+			Update("email_verified_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrVerificationTokenInvalid
+		}
+		return nil
+	})
+}
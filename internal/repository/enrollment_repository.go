@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"BruhPro/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type EnrollmentRepository interface {
+	Create(enrollment *domain.CourseEnrollment) error
+	GetByID(id string) (*domain.CourseEnrollment, error)
+	Get(courseID, accountID string) (*domain.CourseEnrollment, error)
+	ListByAccount(accountID string) ([]domain.CourseEnrollment, error)
+	Activate(id string) error
+	CompleteLesson(progress *domain.LessonProgress) error
+	ListProgress(enrollmentID string) ([]domain.LessonProgress, error)
+}
+
+type enrollmentRepository struct {
+	db *gorm.DB
+}
+
+func NewEnrollmentRepository(db *gorm.DB) EnrollmentRepository {
+	return &enrollmentRepository{db}
+}
+
+func (r *enrollmentRepository) Create(enrollment *domain.CourseEnrollment) error {
+	return r.db.Create(enrollment).Error
+}
+
+func (r *enrollmentRepository) GetByID(id string) (*domain.CourseEnrollment, error) {
+	var enrollment domain.CourseEnrollment
+	err := r.db.First(&enrollment, "id = ?", id).Error
+	return &enrollment, err
+}
+
+func (r *enrollmentRepository) Get(courseID, accountID string) (*domain.CourseEnrollment, error) {
+	var enrollment domain.CourseEnrollment
+	err := r.db.First(&enrollment, "course_id = ? AND account_id = ?", courseID, accountID).Error
+	return &enrollment, err
+}
+
+func (r *enrollmentRepository) ListByAccount(accountID string) ([]domain.CourseEnrollment, error) {
+	var enrollments []domain.CourseEnrollment
+	err := r.db.Where("account_id = ?", accountID).Order("created_at DESC").Find(&enrollments).Error
+	return enrollments, err
+}
+
+// Activate открывает доступ к курсу после оплаты.
+func (r *enrollmentRepository) Activate(id string) error {
+	return r.db.Model(&domain.CourseEnrollment{}).Where("id = ?", id).
+		Update("status", domain.EnrollmentStatusActive).Error
+}
+
+// CompleteLesson отмечает урок пройденным; повторная отметка ничего не меняет.
+func (r *enrollmentRepository) CompleteLesson(progress *domain.LessonProgress) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(progress).Error
+}
+
+func (r *enrollmentRepository) ListProgress(enrollmentID string) ([]domain.LessonProgress, error) {
+	var progress []domain.LessonProgress
+	err := r.db.Where("enrollment_id = ?", enrollmentID).Order("completed_at").Find(&progress).Error
+	return progress, err
+}
@@ -1,17 +1,38 @@
 package repository
 
 import (
-	"BuhPro+/internal/domain" // Обновлен импорт
+	"BruhPro/internal/domain" // Обновлен импорт
 
 	"gorm.io/gorm"
 )
 
+// executorSearchText — текст профиля исполнителя, из которого берется фрагмент для выдачи поиска.
+const executorSearchText = "executors.about_executor || ' ' || executors.education"
+
+// ExecutorFilter — условия публичного поиска исполнителей. Пустые поля не ограничивают выборку.
+// Query — полнотекстовый запрос по описанию и образованию.
+// MaxYears и MaxRate = 0 означают отсутствие верхней границы.
+type ExecutorFilter struct {
+	Query          string
+	Specialization string
+	City           string
+	WorkFormat     string
+	MinYears       int
+	MaxYears       int
+	MinRate        float64
+	MaxRate        float64
+	Sort           string
+	VerifiedOnly   bool // только аккаунты с подтвержденным email
+	After          *SearchCursor
+	Limit          int
+}
+
+// ExecutorRepository работает только с профилем; аккаунт и роль создаются через AccountRepository.AttachRole.
 type ExecutorRepository interface {
-	Create(executor *domain.Executor) error
-	GetByEmail(email string) (*domain.Executor, error)
 	GetByID(id string) (*domain.Executor, error)
-	CreateRefreshToken(token *domain.RefreshToken) error
-	GetRefreshToken(token string) (*domain.RefreshToken, error)
+	Update(update ProfileUpdate) error
+	ListChanges(id string, limit, offset int) ([]domain.ProfileChange, error)
+	Search(filter ExecutorFilter) ([]domain.Executor, error)
 }
 
 type executorRepository struct {
@@ -22,28 +43,82 @@ func NewExecutorRepository(db *gorm.DB) ExecutorRepository {
 	return &executorRepository{db}
 }
 
-func (r *executorRepository) Create(executor *domain.Executor) error {
-	return r.db.Create(executor).Error
-}
-
-func (r *executorRepository) GetByEmail(email string) (*domain.Executor, error) {
+func (r *executorRepository) GetByID(id string) (*domain.Executor, error) {
 	var executor domain.Executor
-	err := r.db.Where("email = ?", email).First(&executor).Error
+	err := r.db.Preload("Specializations", preloadSpecializations).
+		Select("executors.*, accounts.email, accounts.email_verified_at, "+ratingStatsColumns).
+		Joins("JOIN accounts ON accounts.id = executors.id").
+		Joins("LEFT JOIN rating_summaries ON rating_summaries.target_id = executors.id AND rating_summaries.target_role = ?", domain.RoleExecutor).
+		First(&executor, "executors.id = ?", id).Error
 	return &executor, err
 }
 
-func (r *executorRepository) GetByID(id string) (*domain.Executor, error) {
-	var executor domain.Executor
-	err := r.db.First(&executor, "id = ?", id).Error
-	return &executor, err
+// Update применяет изменения профиля; связи со специализациями заменяются целиком.
+func (r *executorRepository) Update(update ProfileUpdate) error {
+	return updateProfile(r.db, "executors", "executor_specializations", "executor_id", update)
 }
 
-func (r *executorRepository) CreateRefreshToken(token *domain.RefreshToken) error {
-	return r.db.Create(token).Error
+func (r *executorRepository) ListChanges(id string, limit, offset int) ([]domain.ProfileChange, error) {
+	return listProfileChanges(r.db, id, domain.RoleExecutor, limit, offset)
 }
 
-func (r *executorRepository) GetRefreshToken(token string) (*domain.RefreshToken, error) {
-	var refreshToken domain.RefreshToken
-	err := r.db.Where("token = ?", token).First(&refreshToken).Error
-	return &refreshToken, err
+// Search возвращает исполнителей в порядке filter.Sort.
+// Страницы листаются по ключу (значение сортировки, id), начиная после filter.After.
+func (r *executorRepository) Search(filter ExecutorFilter) ([]domain.Executor, error) {
+	columns := "executors.*, " + ratingStatsColumns
+	query := r.db.Model(&domain.Executor{}).
+		Joins("JOIN accounts ON accounts.id = executors.id").
+		Joins("LEFT JOIN rating_summaries ON rating_summaries.target_id = executors.id AND rating_summaries.target_role = ?", domain.RoleExecutor)
+
+	if filter.VerifiedOnly {
+		query = query.Where("accounts.email_verified_at IS NOT NULL")
+	}
+
+	if filter.Query != "" {
+		query = applyTextSearch(query, "executors", filter.Query)
+		columns += ", " + searchColumns("executors", executorSearchText)
+	}
+	if filter.Specialization != "" {
+		query = query.Where("EXISTS (SELECT 1 FROM executor_specializations WHERE executor_id = executors.id AND specialization_code = ?)", filter.Specialization)
+	}
+	if filter.City != "" {
+		query = query.Where("LOWER(executors.city) = LOWER(?)", filter.City)
+	}
+	if filter.WorkFormat != "" {
+		query = query.Where("executors.work_format = ?", filter.WorkFormat)
+	}
+	if filter.MinYears > 0 {
+		query = query.Where("executors.exp_years >= ?", filter.MinYears)
+	}
+	if filter.MaxYears > 0 {
+		query = query.Where("executors.exp_years < ?", filter.MaxYears)
+	}
+	if filter.MinRate > 0 {
+		query = query.Where("executors.hourly_rate >= ?", filter.MinRate)
+	}
+	if filter.MaxRate > 0 {
+		query = query.Where("executors.hourly_rate <= ?", filter.MaxRate)
+	}
+
+	key, direction := "executors.created_at", "DESC"
+	switch filter.Sort {
+	case SortRelevance:
+		key = searchRankExpr("executors")
+	case SortRating:
+		key = ratingAverageExpr
+	case SortPriceAsc:
+		key, direction = "executors.hourly_rate", "ASC"
+	case SortPriceDesc:
+		key = "executors.hourly_rate"
+	}
+	query = applyKeyset(query, key, "executors.id", direction, filter.Sort == SortRecent, filter.After)
+
+	var executors []domain.Executor
+	if err := query.Preload("Specializations", preloadSpecializations).Select(columns).Limit(filter.Limit).Find(&executors).Error; err != nil {
+		return nil, err
+	}
+	for i := range executors {
+		executors[i].SearchSnippet = highlightSnippet(executors[i].SearchSnippet)
+	}
+	return executors, nil
 }
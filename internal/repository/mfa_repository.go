@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"time"
+
+	"BruhPro/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MFARepository interface {
+	Get(accountID string) (*domain.AccountMFA, error)
+	Save(mfa *domain.AccountMFA) error
+	Enable(accountID string, step int64, codeHashes []string) error
+	UpdateLastUsedStep(accountID string, step int64) (bool, error)
+	UseRecoveryCode(accountID, codeHash string) (bool, error)
+	Delete(accountID string) error
+}
+
+type mfaRepository struct {
+	db *gorm.DB
+}
+
+func NewMFARepository(db *gorm.DB) MFARepository {
+	return &mfaRepository{db}
+}
+
+func (r *mfaRepository) Get(accountID string) (*domain.AccountMFA, error) {
+	var mfa domain.AccountMFA
+	err := r.db.First(&mfa, "account_id = ?", accountID).Error
+	return &mfa, err
+}
+
+// Save создает или заменяет неподтвержденный секрет аккаунта.
+func (r *mfaRepository) Save(mfa *domain.AccountMFA) error {
+	return r.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(mfa).Error
+}
+
+// Enable включает 2FA и заменяет коды восстановления в одной транзакции.
+func (r *mfaRepository) Enable(accountID string, step int64, codeHashes []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.AccountMFA{}).
+			Where("account_id = ?", accountID).
+			Updates(map[string]interface{}{"enabled_at": time.Now(), "last_used_step": step}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("account_id = ?", accountID).Delete(&domain.MFARecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		codes := make([]domain.MFARecoveryCode, 0, len(codeHashes))
+		for _, hash := range codeHashes {
+			codes = append(codes, domain.MFARecoveryCode{AccountID: accountID, CodeHash: hash})
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+// UpdateLastUsedStep запоминает принятый интервал. Возвращает false, если код
+// этого или более позднего интервала уже был использован.
+func (r *mfaRepository) UpdateLastUsedStep(accountID string, step int64) (bool, error) {
+	result := r.db.Model(&domain.AccountMFA{}).
+		Where("account_id = ? AND last_used_step < ?", accountID, step).
+		Update("last_used_step", step)
+	return result.RowsAffected > 0, result.Error
+}
+
+// UseRecoveryCode погашает код восстановления. Возвращает false, если код не найден или уже использован.
+func (r *mfaRepository) UseRecoveryCode(accountID, codeHash string) (bool, error) {
+	result := r.db.Model(&domain.MFARecoveryCode{}).
+		Where("account_id = ? AND code_hash = ? AND used_at IS NULL", accountID, codeHash).
+		Update("used_at", time.Now())
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *mfaRepository) Delete(accountID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("account_id = ?", accountID).Delete(&domain.MFARecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("account_id = ?", accountID).Delete(&domain.AccountMFA{}).Error
+	})
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"BruhPro/internal/domain"
+	"BruhPro/internal/ledger"
+
+	"gorm.io/gorm"
+)
+
+// ErrMilestoneStatusChanged возвращается, если статус этапа изменился параллельным запросом.
+var ErrMilestoneStatusChanged = errors.New("milestone status has been changed concurrently")
+
+type MilestoneRepository interface {
+	Create(milestone *domain.Milestone) error
+	GetByID(id string) (*domain.Milestone, error)
+	ListByOrder(orderID string) ([]domain.Milestone, error)
+	ListDueForRelease(now time.Time, limit int) ([]domain.Milestone, error)
+	ListReleasedUnposted(limit int) ([]domain.Milestone, error)
+	UpdateStatus(milestone *domain.Milestone, fromStatuses ...string) error
+}
+
+type milestoneRepository struct {
+	db *gorm.DB
+}
+
+func NewMilestoneRepository(db *gorm.DB) MilestoneRepository {
+	return &milestoneRepository{db}
+}
+
+func (r *milestoneRepository) Create(milestone *domain.Milestone) error {
+	return r.db.Create(milestone).Error
+}
+
+func (r *milestoneRepository) GetByID(id string) (*domain.Milestone, error) {
+	var milestone domain.Milestone
+	err := r.db.First(&milestone, "id = ?", id).Error
+	return &milestone, err
+}
+
+func (r *milestoneRepository) ListByOrder(orderID string) ([]domain.Milestone, error) {
+	var milestones []domain.Milestone
+	err := r.db.Where("order_id = ?", orderID).Order("position, created_at").Find(&milestones).Error
+	return milestones, err
+}
+
+// ListDueForRelease возвращает сданные этапы, срок приемки которых истек к моменту now.
+// Этапы заказов в споре не возвращаются: иначе они, оставаясь просроченными, занимали бы
+// начало каждой выборки и вытесняли остальные этапы.
+func (r *milestoneRepository) ListDueForRelease(now time.Time, limit int) ([]domain.Milestone, error) {
+	var milestones []domain.Milestone
+	err := r.db.Select("milestones.*").
+		Joins("JOIN orders ON orders.id = milestones.order_id").
+		Where("milestones.status = ? AND milestones.auto_release_at <= ? AND orders.status <> ?",
+			domain.MilestoneStatusSubmitted, now, domain.OrderStatusDisputed).
+		Order("milestones.auto_release_at").Limit(limit).Find(&milestones).Error
+	return milestones, err
+}
+
+// ListReleasedUnposted возвращает выплаченные этапы, выплата которых еще не проведена в журнале.
+func (r *milestoneRepository) ListReleasedUnposted(limit int) ([]domain.Milestone, error) {
+	var milestones []domain.Milestone
+	err := r.db.Where("status = ?", domain.MilestoneStatusReleased).
+		Where("NOT EXISTS (SELECT 1 FROM ledger_entries WHERE ledger_entries.key = ? || milestones.id::text)",
+			ledger.KindMilestoneReleased+":").
+		Order("released_at").Limit(limit).Find(&milestones).Error
+	return milestones, err
+}
+
+// UpdateStatus сохраняет статус этапа вместе с полями оплаты, сдачи и выплаты,
+// если текущий статус входит в fromStatuses.
+func (r *milestoneRepository) UpdateStatus(milestone *domain.Milestone, fromStatuses ...string) error {
+	milestone.UpdatedAt = time.Now()
+	result := r.db.Model(&domain.Milestone{}).
+		Where("id = ? AND status IN ?", milestone.ID, fromStatuses).
+		Updates(map[string]interface{}{
+			"status":          milestone.Status,
+			"payment_id":      milestone.PaymentID,
+			"commission":      milestone.Commission,
+			"payout":          milestone.Payout,
+			"submitted_at":    milestone.SubmittedAt,
+			"auto_release_at": milestone.AutoReleaseAt,
+			"released_at":     milestone.ReleasedAt,
+			"released_by":     milestone.ReleasedBy,
+			"updated_at":      milestone.UpdatedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrMilestoneStatusChanged
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"BruhPro/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// ErrOrderStatusChanged возвращается, если статус заказа изменился параллельным запросом.
+var ErrOrderStatusChanged = errors.New("order status has been changed concurrently")
+
+// OrderFilter — условия выборки заказов. Пустые поля не ограничивают выборку.
+type OrderFilter struct {
+	CustomerID     string
+	ExecutorID     string
+	Status         string
+	Specialization string
+	City           string
+	WorkFormat     string
+	Limit          int
+	Offset         int
+}
+
+type OrderRepository interface {
+	Create(order *domain.Order, change *domain.OrderStatusChange) error
+	GetByID(id string) (*domain.Order, error)
+	List(filter OrderFilter) ([]domain.Order, error)
+	Update(order *domain.Order, fromStatuses ...string) error
+	Delete(id string, fromStatuses ...string) error
+	Transition(order *domain.Order, fromStatus string, change *domain.OrderStatusChange) error
+	ListHistory(orderID string) ([]domain.OrderStatusChange, error)
+}
+
+type orderRepository struct {
+	db *gorm.DB
+}
+
+func NewOrderRepository(db *gorm.DB) OrderRepository {
+	return &orderRepository{db}
+}
+
+// Create сохраняет заказ вместе с первой записью истории.
+func (r *orderRepository) Create(order *domain.Order, change *domain.OrderStatusChange) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(order).Error; err != nil {
+			return err
+		}
+		change.OrderID = order.ID
+		return tx.Create(change).Error
+	})
+}
+
+func (r *orderRepository) GetByID(id string) (*domain.Order, error) {
+	var order domain.Order
+	err := r.db.First(&order, "id = ?", id).Error
+	return &order, err
+}
+
+// List возвращает заказы от новых к старым.
+func (r *orderRepository) List(filter OrderFilter) ([]domain.Order, error) {
+	query := r.db.Model(&domain.Order{})
+	if filter.CustomerID != "" {
+		query = query.Where("customer_id = ?", filter.CustomerID)
+	}
+	if filter.ExecutorID != "" {
+		query = query.Where("executor_id = ?", filter.ExecutorID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Specialization != "" {
+		query = query.Where("specialization = ?", filter.Specialization)
+	}
+	if filter.City != "" {
+		query = query.Where("city = ?", filter.City)
+	}
+	if filter.WorkFormat != "" {
+		query = query.Where("work_format = ?", filter.WorkFormat)
+	}
+
+	var orders []domain.Order
+	err := query.Order("created_at DESC").Limit(filter.Limit).Offset(filter.Offset).Find(&orders).Error
+	return orders, err
+}
+
+// Update сохраняет редактируемые поля заказа, если его текущий статус входит в fromStatuses.
+// Иначе возвращает ErrOrderStatusChanged.
+func (r *orderRepository) Update(order *domain.Order, fromStatuses ...string) error {
+	result := r.db.Model(order).Where("status IN ?", fromStatuses).Select(
+		"title", "description", "specialization", "payment_mode", "budget",
+		"deadline", "work_format", "city",
+	).Updates(order)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrOrderStatusChanged
+	}
+	return nil
+}
+
+// Delete удаляет заказ вместе с историей статусов и откликами, если его текущий статус
+// входит в fromStatuses. Иначе возвращает ErrOrderStatusChanged и ничего не удаляет.
+// Зависимые строки удаляются явно: в схеме, созданной AutoMigrate, нет ON DELETE CASCADE.
+func (r *orderRepository) Delete(id string, fromStatuses ...string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("order_id = ?", id).Delete(&domain.OrderStatusChange{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("order_id = ?", id).Delete(&domain.Response{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Where("id = ? AND status IN ?", id, fromStatuses).Delete(&domain.Order{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrOrderStatusChanged
+		}
+		return nil
+	})
+}
+
+// Transition переводит заказ из fromStatus в order.Status и записывает переход в историю.
+// Если статус в базе уже не fromStatus, возвращает ErrOrderStatusChanged.
+func (r *orderRepository) Transition(order *domain.Order, fromStatus string, change *domain.OrderStatusChange) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return transitionOrder(tx, order, fromStatus, change)
+	})
+}
+
+// transitionOrder выполняет переход в переданной транзакции, чтобы другие репозитории
+// могли менять статус заказа атомарно со своими изменениями.
+func transitionOrder(tx *gorm.DB, order *domain.Order, fromStatus string, change *domain.OrderStatusChange) error {
+	order.UpdatedAt = time.Now()
+	result := tx.Model(&domain.Order{}).
+		Where("id = ? AND status = ?", order.ID, fromStatus).
+		Updates(map[string]interface{}{
+			"status":      order.Status,
+			"executor_id": order.ExecutorID,
+			"updated_at":  order.UpdatedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrOrderStatusChanged
+	}
+
+	change.OrderID = order.ID
+	return tx.Create(change).Error
+}
+
+func (r *orderRepository) ListHistory(orderID string) ([]domain.OrderStatusChange, error) {
+	var history []domain.OrderStatusChange
+	err := r.db.Where("order_id = ?", orderID).Order("created_at").Find(&history).Error
+	return history, err
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+
+	"BruhPro/internal/domain"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// recorder — database/sql драйвер без базы: записывает выполненные запросы и исход
+// транзакций. Число затронутых строк для запроса возвращает rowsAffected.
+type recorder struct {
+	statements   []string
+	commits      int
+	rollbacks    int
+	rowsAffected func(query string) int64
+}
+
+func (r *recorder) Connect(context.Context) (driver.Conn, error) { return recorderConn{r}, nil }
+func (r *recorder) Driver() driver.Driver                        { return recorderDriver{r} }
+
+type recorderDriver struct{ r *recorder }
+
+func (d recorderDriver) Open(string) (driver.Conn, error) { return recorderConn(d), nil }
+
+type recorderConn struct{ r *recorder }
+
+func (c recorderConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements are not supported")
+}
+func (c recorderConn) Close() error              { return nil }
+func (c recorderConn) Begin() (driver.Tx, error) { return recorderTx(c), nil }
+
+func (c recorderConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	c.r.statements = append(c.r.statements, query)
+	return driver.RowsAffected(c.r.rowsAffected(query)), nil
+}
+
+type recorderTx struct{ r *recorder }
+
+func (t recorderTx) Commit() error   { t.r.commits++; return nil }
+func (t recorderTx) Rollback() error { t.r.rollbacks++; return nil }
+
+func newRecordedDB(t *testing.T, rowsAffected func(query string) int64) (*gorm.DB, *recorder) {
+	t.Helper()
+	r := &recorder{rowsAffected: rowsAffected}
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sql.OpenDB(r)}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, r
+}
+
+// deletedTables возвращает таблицы из DELETE-запросов в порядке выполнения.
+func deletedTables(statements []string) []string {
+	var tables []string
+	for _, statement := range statements {
+		if rest, ok := strings.CutPrefix(statement, "DELETE FROM "); ok {
+			tables = append(tables, strings.Trim(strings.Fields(rest)[0], `"`))
+		}
+	}
+	return tables
+}
+
+func TestOrderDeleteRemovesDependentRows(t *testing.T) {
+	db, r := newRecordedDB(t, func(string) int64 { return 1 })
+
+	if err := NewOrderRepository(db).Delete("order", domain.OrderStatusDraft); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	got := strings.Join(deletedTables(r.statements), ",")
+	if want := "order_status_changes,responses,orders"; got != want {
+		t.Errorf("deleted tables = %s, want %s", got, want)
+	}
+	if r.commits != 1 || r.rollbacks != 0 {
+		t.Errorf("commits = %d, rollbacks = %d; want one commit", r.commits, r.rollbacks)
+	}
+}
+
+func TestOrderDeleteRollsBackWhenStatusChanged(t *testing.T) {
+	db, r := newRecordedDB(t, func(query string) int64 {
+		if strings.HasPrefix(query, `DELETE FROM "orders"`) {
+			return 0
+		}
+		return 1
+	})
+
+	err := NewOrderRepository(db).Delete("order", domain.OrderStatusDraft)
+	if !errors.Is(err, ErrOrderStatusChanged) {
+		t.Fatalf("Delete() error = %v, want %v", err, ErrOrderStatusChanged)
+	}
+	// История и отклики уже удалены в транзакции — она должна откатиться
+	if r.commits != 0 || r.rollbacks != 1 {
+		t.Errorf("commits = %d, rollbacks = %d; want one rollback", r.commits, r.rollbacks)
+	}
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"BruhPro/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// ErrResetTokenInvalid возвращается, если токен сброса уже использован или истек.
+var ErrResetTokenInvalid = errors.New("reset token is invalid or expired")
+
+type PasswordResetRepository interface {
+	Create(token *domain.PasswordResetToken) error
+	GetByTokenHash(tokenHash string) (*domain.PasswordResetToken, error)
+	Consume(token *domain.PasswordResetToken, passwordHash string) error
+}
+
+type passwordResetRepository struct {
+	db *gorm.DB
+}
+
+func NewPasswordResetRepository(db *gorm.DB) PasswordResetRepository {
+	return &passwordResetRepository{db}
+}
+
+// Create сохраняет новый токен и погашает ранее выданные неиспользованные токены аккаунта,
+// так что действует только ссылка из последнего письма.
+func (r *passwordResetRepository) Create(token *domain.PasswordResetToken) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return createPasswordResetToken(tx, token)
+	})
+}
+
+func (r *passwordResetRepository) GetByTokenHash(tokenHash string) (*domain.PasswordResetToken, error) {
+	var token domain.PasswordResetToken
+	err := r.db.Where("token_hash = ?", tokenHash).First(&token).Error
+	return &token, err
+}
+
+// Consume в одной транзакции погашает токен и устанавливает аккаунту новый хеш пароля.
+func (r *passwordResetRepository) Consume(token *domain.PasswordResetToken, passwordHash string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&domain.PasswordResetToken{}).
+			Where("id = ? AND used_at IS NULL AND expires_at > ?", token.ID, now).
+			Update("used_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrResetTokenInvalid
+		}
+
+		return tx.Model(&domain.Account{}).
+			Where("id = ?", token.AccountID).
+			Update("password_hash", passwordHash).Error
+	})
+}
+
+// createPasswordResetToken выполняет Create в переданной транзакции, чтобы токен можно было
+// выдать атомарно с другими изменениями аккаунта.
+func createPasswordResetToken(tx *gorm.DB, token *domain.PasswordResetToken) error {
+	if err := tx.Model(&domain.PasswordResetToken{}).
+		Where("account_id = ? AND used_at IS NULL", token.AccountID).
+		Update("used_at", time.Now()).Error; err != nil {
+		return err
+	}
+	return tx.Create(token).Error
+}
@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"BruhPro/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrPaymentStatusChanged возвращается, если статус платежа изменился параллельным запросом.
+var ErrPaymentStatusChanged = errors.New("payment status has been changed concurrently")
+
+type PaymentRepository interface {
+	Create(payment *domain.Payment) error
+	GetByID(id string) (*domain.Payment, error)
+	GetByProviderPaymentID(provider, providerPaymentID string) (*domain.Payment, error)
+	FindActive(purpose, targetID string) (*domain.Payment, error)
+	ListByAccount(accountID string, limit, offset int) ([]domain.Payment, error)
+	AttachCharge(payment *domain.Payment) error
+	UpdateStatus(payment *domain.Payment, fromStatuses ...string) error
+	ReserveRefund(payment *domain.Payment, amount int64) error
+	ReleaseRefund(payment *domain.Payment, amount int64) error
+	ApplyEvent(event *domain.PaymentEvent, payment *domain.Payment, fromStatuses ...string) (bool, error)
+}
+
+type paymentRepository struct {
+	db *gorm.DB
+}
+
+func NewPaymentRepository(db *gorm.DB) PaymentRepository {
+	return &paymentRepository{db}
+}
+
+func (r *paymentRepository) Create(payment *domain.Payment) error {
+	return r.db.Create(payment).Error
+}
+
+func (r *paymentRepository) GetByID(id string) (*domain.Payment, error) {
+	var payment domain.Payment
+	err := r.db.First(&payment, "id = ?", id).Error
+	return &payment, err
+}
+
+func (r *paymentRepository) GetByProviderPaymentID(provider, providerPaymentID string) (*domain.Payment, error) {
+	var payment domain.Payment
+	err := r.db.First(&payment, "provider = ? AND provider_payment_id = ?", provider, providerPaymentID).Error
+	return &payment, err
+}
+
+// FindActive возвращает незавершенный или успешный платеж за этап заказа или запись на курс.
+func (r *paymentRepository) FindActive(purpose, targetID string) (*domain.Payment, error) {
+	column := "milestone_id"
+	if purpose == domain.PaymentPurposeCourse {
+		column = "enrollment_id"
+	}
+
+	var payment domain.Payment
+	err := r.db.Where(column+" = ? AND status IN ?", targetID, []string{
+		domain.PaymentStatusPending,
+		domain.PaymentStatusAuthorized,
+		domain.PaymentStatusCaptured,
+	}).Order("created_at DESC").First(&payment).Error
+	return &payment, err
+}
+
+func (r *paymentRepository) ListByAccount(accountID string, limit, offset int) ([]domain.Payment, error) {
+	var payments []domain.Payment
+	err := r.db.Where("account_id = ?", accountID).
+		Order("created_at DESC").Limit(limit).Offset(offset).
+		Find(&payments).Error
+	return payments, err
+}
+
+// AttachCharge сохраняет ID платежа у провайдера и ссылку на страницу оплаты.
+func (r *paymentRepository) AttachCharge(payment *domain.Payment) error {
+	return r.db.Model(payment).Select("provider_payment_id", "checkout_url").Updates(payment).Error
+}
+
+// UpdateStatus сохраняет статус, возвращенную сумму и причину отказа,
+// если текущий статус платежа входит в fromStatuses.
+func (r *paymentRepository) UpdateStatus(payment *domain.Payment, fromStatuses ...string) error {
+	return updatePaymentStatus(r.db, payment, fromStatuses...)
+}
+
+// ReserveRefund до обращения к провайдеру увеличивает refunded_amount оплаченного платежа,
+// если он не изменился с момента чтения. Так два параллельных частичных возврата
+// не вернут одни и те же деньги: второй получит ErrPaymentStatusChanged.
+func (r *paymentRepository) ReserveRefund(payment *domain.Payment, amount int64) error {
+	updatedAt := time.Now()
+	result := r.db.Model(&domain.Payment{}).
+		Where("id = ? AND status = ? AND refunded_amount = ?", payment.ID, domain.PaymentStatusCaptured, payment.RefundedAmount).
+		Updates(map[string]interface{}{
+			"refunded_amount": gorm.Expr("refunded_amount + ?", amount),
+			"updated_at":      updatedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrPaymentStatusChanged
+	}
+	payment.RefundedAmount += amount
+	payment.UpdatedAt = updatedAt
+	return nil
+}
+
+// ReleaseRefund снимает резерв ReserveRefund, если провайдер отклонил возврат.
+func (r *paymentRepository) ReleaseRefund(payment *domain.Payment, amount int64) error {
+	payment.UpdatedAt = time.Now()
+	err := r.db.Model(&domain.Payment{}).
+		Where("id = ? AND refunded_amount >= ?", payment.ID, amount).
+		Updates(map[string]interface{}{
+			"refunded_amount": gorm.Expr("refunded_amount - ?", amount),
+			"updated_at":      payment.UpdatedAt,
+		}).Error
+	if err != nil {
+		return err
+	}
+	payment.RefundedAmount -= amount
+	return nil
+}
+
+// ApplyEvent в одной транзакции записывает уведомление провайдера и применяет его статус.
+// Возвращает false, если уведомление уже обрабатывалось или статус платежа уже ушел
+// дальше (уведомления пришли не по порядку) — тогда событие записывается без изменения платежа.
+func (r *paymentRepository) ApplyEvent(event *domain.PaymentEvent, payment *domain.Payment, fromStatuses ...string) (bool, error) {
+	applied := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(event)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		applied = true
+
+		err := updatePaymentStatus(tx, payment, fromStatuses...)
+		if errors.Is(err, ErrPaymentStatusChanged) {
+			applied = false
+			return nil
+		}
+		return err
+	})
+	return applied, err
+}
+
+func updatePaymentStatus(tx *gorm.DB, payment *domain.Payment, fromStatuses ...string) error {
+	payment.UpdatedAt = time.Now()
+	result := tx.Model(&domain.Payment{}).
+		Where("id = ? AND status IN ?", payment.ID, fromStatuses).
+		Updates(map[string]interface{}{
+			"status":          payment.Status,
+			"refunded_amount": payment.RefundedAmount,
+			"failure_reason":  payment.FailureReason,
+			"updated_at":      payment.UpdatedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrPaymentStatusChanged
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"errors"
+
+	"BruhPro/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// ErrProfileVersionConflict — профиль изменился после того, как клиент его прочитал.
+var ErrProfileVersionConflict = errors.New("profile version conflict")
+
+// ProfileUpdate — изменения профиля роли. Профиль обновляется, только если его версия
+// все еще Version; после обновления она увеличивается на 1.
+type ProfileUpdate struct {
+	ID              string
+	Version         int64
+	Columns         map[string]interface{}
+	Specializations []string // nil — специализации не меняются
+	Changes         []domain.ProfileChange
+}
+
+// updateProfile в одной транзакции обновляет колонки профиля table с проверкой версии,
+// при необходимости заменяет связи со специализациями в linkTable и пишет историю.
+func updateProfile(db *gorm.DB, table, linkTable, linkColumn string, update ProfileUpdate) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		columns := map[string]interface{}{"version": gorm.Expr("version + 1"), "updated_at": gorm.Expr("NOW()")}
+		for column, value := range update.Columns {
+			columns[column] = value
+		}
+
+		result := tx.Table(table).Where("id = ? AND version = ?", update.ID, update.Version).Updates(columns)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrProfileVersionConflict
+		}
+
+		if update.Specializations != nil {
+			if err := tx.Exec("DELETE FROM "+linkTable+" WHERE "+linkColumn+" = ?", update.ID).Error; err != nil {
+				return err
+			}
+			for _, code := range update.Specializations {
+				err := tx.Exec("INSERT INTO "+linkTable+" ("+linkColumn+", specialization_code) VALUES (?, ?)", update.ID, code).Error
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		if len(update.Changes) > 0 {
+			return tx.Create(&update.Changes).Error
+		}
+		return nil
+	})
+}
+
+// listProfileChanges возвращает историю профиля роли, новые изменения первыми.
+func listProfileChanges(db *gorm.DB, accountID, role string, limit, offset int) ([]domain.ProfileChange, error) {
+	var changes []domain.ProfileChange
+	err := db.Where("account_id = ? AND role = ?", accountID, role).
+		Order("created_at DESC, field").
+		Limit(limit).
+		Offset(offset).
+		Find(&changes).Error
+	return changes, err
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"time"
+
+	"BruhPro/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ratingAverageExpr — средняя оценка профиля из rating_summaries; ключ сортировки по рейтингу.
+const ratingAverageExpr = "COALESCE(rating_summaries.sum_score::float8 / NULLIF(rating_summaries.count, 0), 0)"
+
+// ratingStatsColumns выбирает средние из rating_summaries в поля domain.RatingStats.
+// Запрос должен содержать LEFT JOIN rating_summaries для нужного профиля.
+const ratingStatsColumns = "COALESCE(rating_summaries.count, 0) AS rating_count, " +
+	"COALESCE(rating_summaries.sum_score::float8 / NULLIF(rating_summaries.count, 0), 0) AS rating_average, " +
+	"COALESCE(rating_summaries.sum_quality::float8 / NULLIF(rating_summaries.count, 0), 0) AS rating_quality, " +
+	"COALESCE(rating_summaries.sum_deadlines::float8 / NULLIF(rating_summaries.count, 0), 0) AS rating_deadlines, " +
+	"COALESCE(rating_summaries.sum_communication::float8 / NULLIF(rating_summaries.count, 0), 0) AS rating_communication"
+
+type RatingRepository interface {
+	Create(rating *domain.Rating) error
+	Update(rating *domain.Rating) error
+	SetReply(rating *domain.Rating) error
+	GetByID(id string) (*domain.Rating, error)
+	ExistsForOrder(orderID string) (bool, error)
+	ExistsForEnrollment(enrollmentID string) (bool, error)
+	ListByTarget(targetID, targetRole string, limit, offset int) ([]domain.Rating, error)
+}
+
+type ratingRepository struct {
+	db *gorm.DB
+}
+
+func NewRatingRepository(db *gorm.DB) RatingRepository {
+	return &ratingRepository{db}
+}
+
+// Create сохраняет оценку и добавляет ее в сводку профиля в одной транзакции.
+func (r *ratingRepository) Create(rating *domain.Rating) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(rating).Error; err != nil {
+			return err
+		}
+		return addToSummary(tx, rating.TargetID, rating.TargetRole, 1,
+			rating.Score, rating.Quality, rating.Deadlines, rating.Communication)
+	})
+}
+
+// Update сохраняет исправленную оценку и сдвигает суммы сводки на разницу с прежней.
+// Прежняя оценка читается в той же транзакции с блокировкой строки: два параллельных
+// исправления не посчитают разницу от одной и той же версии.
+func (r *ratingRepository) Update(rating *domain.Rating) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var previous domain.Rating
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&previous, "id = ?", rating.ID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(rating).Select("score", "quality", "deadlines", "communication", "review").
+			Updates(rating).Error; err != nil {
+			return err
+		}
+		return addToSummary(tx, previous.TargetID, previous.TargetRole, 0,
+			rating.Score-previous.Score,
+			rating.Quality-previous.Quality,
+			rating.Deadlines-previous.Deadlines,
+			rating.Communication-previous.Communication)
+	})
+}
+
+func (r *ratingRepository) SetReply(rating *domain.Rating) error {
+	return r.db.Model(rating).Select("reply", "replied_at").Updates(rating).Error
+}
+
+func (r *ratingRepository) GetByID(id string) (*domain.Rating, error) {
+	var rating domain.Rating
+	err := r.db.First(&rating, "id = ?", id).Error
+	return &rating, err
+}
+
+func (r *ratingRepository) ExistsForOrder(orderID string) (bool, error) {
+	var count int64
+	err := r.db.Model(&domain.Rating{}).Where("order_id = ?", orderID).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *ratingRepository) ExistsForEnrollment(enrollmentID string) (bool, error) {
+	var count int64
+	err := r.db.Model(&domain.Rating{}).Where("enrollment_id = ?", enrollmentID).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *ratingRepository) ListByTarget(targetID, targetRole string, limit, offset int) ([]domain.Rating, error) {
+	var ratings []domain.Rating
+	err := r.db.Where("target_id = ? AND target_role = ?", targetID, targetRole).
+		Order("created_at DESC").Limit(limit).Offset(offset).Find(&ratings).Error
+	return ratings, err
+}
+
+// addToSummary атомарно прибавляет значения к сводке профиля, создавая ее при первой оценке.
+func addToSummary(tx *gorm.DB, targetID, targetRole string, count int64, score, quality, deadlines, communication int) error {
+	summary := &domain.RatingSummary{
+		TargetID:         targetID,
+		TargetRole:       targetRole,
+		Count:            count,
+		SumScore:         int64(score),
+		SumQuality:       int64(quality),
+		SumDeadlines:     int64(deadlines),
+		SumCommunication: int64(communication),
+		UpdatedAt:        time.Now(),
+	}
+	return tx.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "target_id"}, {Name: "target_role"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"count":             gorm.Expr("rating_summaries.count + ?", count),
+			"sum_score":         gorm.Expr("rating_summaries.sum_score + ?", score),
+			"sum_quality":       gorm.Expr("rating_summaries.sum_quality + ?", quality),
+			"sum_deadlines":     gorm.Expr("rating_summaries.sum_deadlines + ?", deadlines),
+			"sum_communication": gorm.Expr("rating_summaries.sum_communication + ?", communication),
+			"updated_at":        summary.UpdatedAt,
+		}),
+	}).Create(summary).Error
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"BruhPro/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrResponseExists возвращается при повторном отклике исполнителя на тот же заказ.
+	ErrResponseExists = errors.New("response already exists")
+	// ErrResponseStatusChanged возвращается, если статус отклика изменился параллельным запросом.
+	ErrResponseStatusChanged = errors.New("response status has been changed concurrently")
+)
+
+type ResponseRepository interface {
+	Create(response *domain.Response) error
+	GetByID(id string) (*domain.Response, error)
+	ListByOrder(orderID string) ([]domain.Response, error)
+	ListByExecutor(executorID string) ([]domain.Response, error)
+	UpdateStatus(response *domain.Response, fromStatuses ...string) error
+	Accept(response *domain.Response, order *domain.Order, fromStatus string, change *domain.OrderStatusChange) error
+}
+
+type responseRepository struct {
+	db *gorm.DB
+}
+
+func NewResponseRepository(db *gorm.DB) ResponseRepository {
+	return &responseRepository{db}
+}
+
+// Create сохраняет отклик. Повторный отклик на заказ, в том числе параллельный,
+// отсекает уникальный индекс по заказу и исполнителю.
+func (r *responseRepository) Create(response *domain.Response) error {
+	result := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "order_id"}, {Name: "executor_id"}},
+		DoNothing: true,
+	}).Create(response)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrResponseExists
+	}
+	return nil
+}
+
+func (r *responseRepository) GetByID(id string) (*domain.Response, error) {
+	var response domain.Response
+	err := r.db.First(&response, "id = ?", id).Error
+	return &response, err
+}
+
+func (r *responseRepository) ListByOrder(orderID string) ([]domain.Response, error) {
+	var responses []domain.Response
+	err := r.db.Where("order_id = ?", orderID).Order("created_at").Find(&responses).Error
+	return responses, err
+}
+
+func (r *responseRepository) ListByExecutor(executorID string) ([]domain.Response, error) {
+	var responses []domain.Response
+	err := r.db.Where("executor_id = ?", executorID).Order("created_at DESC").Find(&responses).Error
+	return responses, err
+}
+
+// UpdateStatus сохраняет response.Status, если текущий статус входит в fromStatuses.
+func (r *responseRepository) UpdateStatus(response *domain.Response, fromStatuses ...string) error {
+	return updateResponseStatus(r.db, response, fromStatuses...)
+}
+
+// Accept в одной транзакции принимает отклик, переводит заказ в работу с назначенным
+// исполнителем и отклоняет остальные открытые отклики на заказ.
+func (r *responseRepository) Accept(response *domain.Response, order *domain.Order, fromStatus string, change *domain.OrderStatusChange) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := updateResponseStatus(tx, response, domain.ResponseStatusPending, domain.ResponseStatusShortlisted); err != nil {
+			return err
+		}
+
+		if err := transitionOrder(tx, order, fromStatus, change); err != nil {
+			return err
+		}
+
+		return tx.Model(&domain.Response{}).
+			Where("order_id = ? AND id <> ? AND status IN ?", order.ID, response.ID,
+				[]string{domain.ResponseStatusPending, domain.ResponseStatusShortlisted}).
+			Updates(map[string]interface{}{"status": domain.ResponseStatusDeclined, "updated_at": time.Now()}).Error
+	})
+}
+
+func updateResponseStatus(tx *gorm.DB, response *domain.Response, fromStatuses ...string) error {
+	response.UpdatedAt = time.Now()
+	result := tx.Model(&domain.Response{}).
+		Where("id = ? AND status IN ?", response.ID, fromStatuses).
+		Updates(map[string]interface{}{"status": response.Status, "updated_at": response.UpdatedAt})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrResponseStatusChanged
+	}
+	return nil
+}
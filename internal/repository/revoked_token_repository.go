@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"time"
+
+	"BruhPro/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RevokedTokenRepository — постоянное хранилище denylist отозванных токенов и сессий.
+type RevokedTokenRepository interface {
+	Add(token *domain.RevokedToken) error
+	ListActive() ([]domain.RevokedToken, error)
+	DeleteExpired() error
+}
+
+type revokedTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewRevokedTokenRepository(db *gorm.DB) RevokedTokenRepository {
+	return &revokedTokenRepository{db}
+}
+
+func (r *revokedTokenRepository) Add(token *domain.RevokedToken) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(token).Error
+}
+
+func (r *revokedTokenRepository) ListActive() ([]domain.RevokedToken, error) {
+	var tokens []domain.RevokedToken
+	err := r.db.Where("expires_at > ?", time.Now()).Find(&tokens).Error
+	return tokens, err
+}
+
+func (r *revokedTokenRepository) DeleteExpired() error {
+	return r.db.Where("expires_at <= ?", time.Now()).Delete(&domain.RevokedToken{}).Error
+}
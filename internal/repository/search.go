@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Сортировки публичного поиска профилей. Цена есть только у исполнителей.
+const (
+	SortRelevance = "relevance"
+	SortRating    = "rating"
+	SortPriceAsc  = "price_asc"
+	SortPriceDesc = "price_desc"
+	SortRecent    = "recent"
+)
+
+// SearchCursor — позиция последнего профиля на странице поиска.
+// Value хранит ключ сортировки relevance/rating/price_*, CreatedAt — ключ сортировки recent.
+type SearchCursor struct {
+	Sort      string    `json:"s"`
+	Value     float64   `json:"v,omitempty"`
+	CreatedAt time.Time `json:"t,omitempty"`
+	ID        string    `json:"id"`
+}
+
+// Полнотекстовый поиск. Для казахского в PostgreSQL нет стеммера, поэтому
+// запрос разбирается и русской конфигурацией, и simple — без стемминга.
+// search_vector в таблицах профилей собран так же (см. миграцию 018).
+// ts_headline не экранирует текст профиля, поэтому найденные слова он обрамляет
+// управляющими символами, а теги <mark> подставляет highlightSnippet после экранирования.
+const (
+	searchQueryJoin = "CROSS JOIN (SELECT websearch_to_tsquery('russian', @q) || websearch_to_tsquery('simple', @q) AS query) AS search"
+	searchMarkStart = "\x02"
+	searchMarkStop  = "\x03"
+	searchHeadline  = "StartSel=\"" + searchMarkStart + "\", StopSel=\"" + searchMarkStop + "\", MaxFragments=2, MaxWords=25, MinWords=8, FragmentDelimiter=\" … \""
+)
+
+var searchMarkReplacer = strings.NewReplacer(searchMarkStart, "<mark>", searchMarkStop, "</mark>")
+
+// searchRankExpr — релевантность профиля table запросу search.query.
+func searchRankExpr(table string) string {
+	return fmt.Sprintf("ts_rank_cd(%s.search_vector, search.query)::float8", table)
+}
+
+// applyTextSearch ограничивает выборку профилями table, подходящими под текст q.
+func applyTextSearch(query *gorm.DB, table, q string) *gorm.DB {
+	return query.
+		Joins(searchQueryJoin, sql.Named("q", q)).
+		Where(table + ".search_vector @@ search.query")
+}
+
+// searchColumns — релевантность search_rank и фрагмент search_snippet с подсветкой по тексту text.
+// Выбирается вместе с applyTextSearch.
+func searchColumns(table, text string) string {
+	return fmt.Sprintf("%s AS search_rank, ts_headline('russian', %s, search.query, '%s') AS search_snippet",
+		searchRankExpr(table), text, searchHeadline)
+}
+
+// highlightSnippet экранирует фрагмент из ts_headline для HTML и заменяет
+// разделители searchMarkStart/searchMarkStop на теги <mark>.
+func highlightSnippet(snippet string) string {
+	return searchMarkReplacer.Replace(html.EscapeString(snippet))
+}
+
+// applyKeyset сортирует выборку по key в направлении direction с id как вторым ключом
+// и пропускает записи до after включительно. byTime — key сравнивается с after.CreatedAt.
+func applyKeyset(query *gorm.DB, key, idColumn, direction string, byTime bool, after *SearchCursor) *gorm.DB {
+	if after != nil {
+		op := "<"
+		if direction == "ASC" {
+			op = ">"
+		}
+		var value interface{} = after.Value
+		if byTime {
+			value = after.CreatedAt
+		}
+		query = query.Where(fmt.Sprintf("(%s, %s) %s (?, ?)", key, idColumn, op), value, after.ID)
+	}
+	return query.Order(fmt.Sprintf("%s %s, %s %s", key, direction, idColumn, direction))
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"BruhPro/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// ErrRefreshTokenUsed возвращается, если токен уже был обменян или отозван к моменту ротации.
+var ErrRefreshTokenUsed = errors.New("refresh token already used")
+
+// SessionRepository хранит сессии и принадлежащие им refresh токены.
+type SessionRepository interface {
+	Create(session *domain.Session, token *domain.RefreshToken) error
+	GetByID(id string) (*domain.Session, error)
+	ListActive(accountID string) ([]domain.Session, error)
+	GetRefreshToken(tokenHash string) (*domain.RefreshToken, error)
+	Rotate(session *domain.Session, usedID string, next *domain.RefreshToken) error
+	Revoke(id string) error
+}
+
+type sessionRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) SessionRepository {
+	return &sessionRepository{db}
+}
+
+func (r *sessionRepository) Create(session *domain.Session, token *domain.RefreshToken) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(session).Error; err != nil {
+			return err
+		}
+		return tx.Create(token).Error
+	})
+}
+
+func (r *sessionRepository) GetByID(id string) (*domain.Session, error) {
+	var session domain.Session
+	err := r.db.First(&session, "id = ?", id).Error
+	return &session, err
+}
+
+func (r *sessionRepository) ListActive(accountID string) ([]domain.Session, error) {
+	var sessions []domain.Session
+	err := r.db.Where("account_id = ? AND revoked_at IS NULL", accountID).
+		Order("last_used_at DESC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+func (r *sessionRepository) GetRefreshToken(tokenHash string) (*domain.RefreshToken, error) {
+	var refreshToken domain.RefreshToken
+	err := r.db.Where("token_hash = ?", tokenHash).First(&refreshToken).Error
+	return &refreshToken, err
+}
+
+// Rotate помечает токен использованным, сохраняет следующий токен семьи и обновляет
+// данные сессии. Условие used_at IS NULL защищает от одновременного обмена одного токена.
+func (r *sessionRepository) Rotate(session *domain.Session, usedID string, next *domain.RefreshToken) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.RefreshToken{}).
+			Where("id = ? AND used_at IS NULL AND revoked_at IS NULL", usedID).
+			Update("used_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrRefreshTokenUsed
+		}
+
+		if err := tx.Create(next).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(session).Updates(map[string]interface{}{
+			"user_agent":        session.UserAgent,
+			"ip":                session.IP,
+			"access_expires_at": session.AccessExpiresAt,
+			"last_used_at":      session.LastUsedAt,
+		}).Error
+	})
+}
+
+// Revoke завершает сессию и отзывает все ее refresh токены.
+func (r *sessionRepository) Revoke(id string) error {
+	now := time.Now()
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.Session{}).
+			Where("id = ? AND revoked_at IS NULL", id).
+			Update("revoked_at", now).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&domain.RefreshToken{}).
+			Where("family_id = ? AND revoked_at IS NULL", id).
+			Update("revoked_at", now).Error
+	})
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"BruhPro/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// ErrSpecializationInUse — на специализацию ссылаются профили, заказы или курсы.
+var ErrSpecializationInUse = errors.New("specialization is in use")
+
+// SpecializationRepository хранит справочник специализаций исполнителей и коучей.
+type SpecializationRepository interface {
+	Create(specialization *domain.Specialization) error
+	GetByCode(code string) (*domain.Specialization, error)
+	List(role string, activeOnly bool) ([]domain.Specialization, error)
+	CountActive(role string, codes []string) (int64, error)
+	Update(specialization *domain.Specialization) error
+	Delete(code string) error
+}
+
+type specializationRepository struct {
+	db *gorm.DB
+}
+
+func NewSpecializationRepository(db *gorm.DB) SpecializationRepository {
+	return &specializationRepository{db}
+}
+
+func (r *specializationRepository) Create(specialization *domain.Specialization) error {
+	return r.db.Create(specialization).Error
+}
+
+func (r *specializationRepository) GetByCode(code string) (*domain.Specialization, error) {
+	var specialization domain.Specialization
+	err := r.db.First(&specialization, "code = ?", code).Error
+	return &specialization, err
+}
+
+// List возвращает специализации роли в порядке показа. Пустая role — специализации всех ролей.
+func (r *specializationRepository) List(role string, activeOnly bool) ([]domain.Specialization, error) {
+	query := r.db.Model(&domain.Specialization{})
+	if role != "" {
+		query = query.Where("role = ?", role)
+	}
+	if activeOnly {
+		query = query.Where("active")
+	}
+
+	var specializations []domain.Specialization
+	err := query.Order(specializationOrder).Find(&specializations).Error
+	return specializations, err
+}
+
+// CountActive считает, сколько из codes — включенные специализации роли.
+func (r *specializationRepository) CountActive(role string, codes []string) (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.Specialization{}).
+		Where("role = ? AND active AND code IN ?", role, codes).
+		Count(&count).Error
+	return count, err
+}
+
+// Update сохраняет названия, порядок и признак активности. Код и роль не меняются:
+// на них ссылаются профили, заказы и курсы.
+func (r *specializationRepository) Update(specialization *domain.Specialization) error {
+	return r.db.Model(specialization).
+		Select("name_ru", "name_kk", "position", "active").
+		Updates(specialization).Error
+}
+
+// Delete удаляет специализацию, на которую ничто не ссылается; иначе возвращает ErrSpecializationInUse.
+func (r *specializationRepository) Delete(code string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var used bool
+		err := tx.Raw(`SELECT EXISTS (SELECT 1 FROM executor_specializations WHERE specialization_code = @code)
+			OR EXISTS (SELECT 1 FROM coach_specializations WHERE specialization_code = @code)
+			OR EXISTS (SELECT 1 FROM orders WHERE specialization = @code)
+			OR EXISTS (SELECT 1 FROM courses WHERE specialization = @code)`, sql.Named("code", code)).
+			Scan(&used).Error
+		if err != nil {
+			return err
+		}
+		if used {
+			return ErrSpecializationInUse
+		}
+
+		return tx.Delete(&domain.Specialization{}, "code = ?", code).Error
+	})
+}
+
+// specializationOrder — порядок показа специализаций, в том числе в профилях.
+const specializationOrder = "position, code"
+
+// preloadSpecializations подгружает специализации профиля в порядке показа.
+func preloadSpecializations(db *gorm.DB) *gorm.DB {
+	return db.Order(specializationOrder)
+}
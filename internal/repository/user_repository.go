@@ -1,49 +0,0 @@
-package repository
-
-import (
-	"BuhPro+/internal/domain" // Обновлен импорт
-
-	"gorm.io/gorm"
-)
-
-type UserRepository interface {
-	Create(user *domain.User) error
-	GetByEmail(email string) (*domain.User, error)
-	GetByID(id string) (*domain.User, error)
-	CreateRefreshToken(token *domain.RefreshToken) error
-	GetRefreshToken(token string) (*domain.RefreshToken, error)
-}
-
-type userRepository struct {
-	db *gorm.DB
-}
-
-func NewUserRepository(db *gorm.DB) UserRepository {
-	return &userRepository{db}
-}
-
-func (r *userRepository) Create(user *domain.User) error {
-	return r.db.Create(user).Error
-}
-
-func (r *userRepository) GetByEmail(email string) (*domain.User, error) {
-	var user domain.User
-	err := r.db.Where("email = ?", email).First(&user).Error
-	return &user, err
-}
-
-func (r *userRepository) GetByID(id string) (*domain.User, error) {
-	var user domain.User
-	err := r.db.First(&user, "id = ?", id).Error
-	return &user, err
-}
-
-func (r *userRepository) CreateRefreshToken(token *domain.RefreshToken) error {
-	return r.db.Create(token).Error
-}
-
-func (r *userRepository) GetRefreshToken(token string) (*domain.RefreshToken, error) {
-	var refreshToken domain.RefreshToken
-	err := r.db.Where("token = ?", token).First(&refreshToken).Error
-	return &refreshToken, err
-}
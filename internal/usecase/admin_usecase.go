@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"time"
+
+	"BruhPro/internal/domain"
+	"BruhPro/internal/repository"
+
+	"github.com/sirupsen/logrus"
+)
+
+// AdminUsecase выдает роль администратора. Через HTTP API администратора создать нельзя:
+// роль выдает оператор командой cmd/admin с доступом к базе.
+type AdminUsecase struct {
+	accountRepo repository.AccountRepository
+	logger      *logrus.Logger
+}
+
+func NewAdminUsecase(accountRepo repository.AccountRepository, logger *logrus.Logger) *AdminUsecase {
+	return &AdminUsecase{accountRepo, logger}
+}
+
+// Provision создает аккаунт администратора или добавляет роль admin существующему аккаунту.
+// Для существующего аккаунта password должен совпадать с его паролем, как при регистрации
+// новой роли. Email нового аккаунта считается подтвержденным: его завел оператор.
+func (s *AdminUsecase) Provision(email, password string) (*domain.Account, error) {
+	s.logger.WithField("email", email).Info("Attempting to provision admin")
+
+	account, isNew, err := prepareAccount(s.accountRepo, s.logger, email, password, domain.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if isNew {
+		now := time.Now()
+		account.EmailVerifiedAt = &now
+	}
+
+	if err := s.accountRepo.AttachRole(account, domain.RoleAdmin, nil); err != nil {
+		s.logger.WithError(err).Error("Failed to attach admin role")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"audit":      true,
+		"account_id": account.ID,
+		"new":        isNew,
+	}).Info("Admin provisioned successfully")
+	return account, nil
+}
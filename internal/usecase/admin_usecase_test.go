@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"io"
+	"testing"
+
+	"BruhPro/internal/domain"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestProvisionAdmin(t *testing.T) {
+	auth, accounts, tokens := newTestAuthUsecase(t)
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	admins := NewAdminUsecase(accounts, logger)
+	client := ClientInfo{UserAgent: "test", IP: "127.0.0.1"}
+
+	account, err := admins.Provision("admin@example.com", testPassword)
+	if err != nil {
+		t.Fatalf("provision: %v", err)
+	}
+	if account.EmailVerifiedAt == nil {
+		t.Error("provisioned admin email is not verified")
+	}
+
+	// Новый администратор входит через /admin/login и продлевает сессию через общий /refresh
+	login, err := auth.Login("admin@example.com", testPassword, domain.RoleAdmin, client)
+	if err != nil {
+		t.Fatalf("admin login: %v", err)
+	}
+	refreshed, err := auth.Refresh(login.RefreshToken, client)
+	if err != nil {
+		t.Fatalf("admin refresh: %v", err)
+	}
+	if role := accessRole(t, tokens, refreshed.AccessToken); role != domain.RoleAdmin {
+		t.Errorf("refreshed role = %q, want %q", role, domain.RoleAdmin)
+	}
+
+	if _, err := admins.Provision("admin@example.com", testPassword); err == nil {
+		t.Error("admin provisioned twice")
+	}
+}
+
+func TestProvisionAdminForExistingAccount(t *testing.T) {
+	auth, accounts, _ := newTestAuthUsecase(t)
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	admins := NewAdminUsecase(accounts, logger)
+	addTestAccount(t, accounts, "account", "executor@example.com", domain.RoleExecutor)
+
+	if _, err := admins.Provision("executor@example.com", "Wr0ng!Passw0rd"); err == nil {
+		t.Fatal("admin role granted with a wrong password")
+	}
+	if _, err := auth.Login("executor@example.com", testPassword, domain.RoleAdmin, ClientInfo{}); err == nil {
+		t.Fatal("admin login succeeded before provisioning")
+	}
+
+	account, err := admins.Provision("executor@example.com", testPassword)
+	if err != nil {
+		t.Fatalf("provision: %v", err)
+	}
+	if account.ID != "account" {
+		t.Errorf("provisioned account = %q, want existing account", account.ID)
+	}
+	if _, err := auth.Login("executor@example.com", testPassword, domain.RoleAdmin, ClientInfo{}); err != nil {
+		t.Fatalf("admin login: %v", err)
+	}
+}
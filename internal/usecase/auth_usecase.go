@@ -2,142 +2,428 @@ package usecase
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
-	"BuhPro+/internal/domain"
-	"BuhPro+/internal/repository"
-	"BuhPro+/internal/utils" // Обновлен импорт
+	"BruhPro/internal/domain"
+	"BruhPro/internal/repository"
+	"BruhPro/internal/utils" // Обновлен импорт
 
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// TokenPair — результат успешного входа: пара токенов и все роли аккаунта.
+// Если у аккаунта включена 2FA, токены не выдаются: MFARequired выставлен,
+// а MFAToken нужно обменять на пару токенов вместе с кодом из приложения.
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	Roles        []string
+	MFARequired  bool
+	MFAToken     string
+}
+
+// ClientInfo — данные устройства, сохраняемые в сессии.
+type ClientInfo struct {
+	UserAgent string
+	IP        string
+}
+
+// AuthUsecase — единый поток регистрации, входа и обновления токенов для всех ролей.
+// CustomerUsecase, CoachUsecase и ExecutorUsecase делегируют ему работу с учетными данными.
 type AuthUsecase struct {
-	userRepo  repository.UserRepository
-	jwtSecret string
-	logger    *logrus.Logger
+	accountRepo  repository.AccountRepository
+	sessionRepo  repository.SessionRepository
+	mfaRepo      repository.MFARepository
+	denylist     *TokenDenylist
+	verification *EmailVerificationUsecase
+	tokens       *utils.JWTManager
+	logger       *logrus.Logger
 }
 
-func NewAuthUsecase(repo repository.UserRepository, secret string, logger *logrus.Logger) *AuthUsecase {
-	return &AuthUsecase{repo, secret, logger}
+func NewAuthUsecase(repo repository.AccountRepository, sessionRepo repository.SessionRepository, mfaRepo repository.MFARepository, denylist *TokenDenylist, verification *EmailVerificationUsecase, tokens *utils.JWTManager, logger *logrus.Logger) *AuthUsecase {
+	return &AuthUsecase{repo, sessionRepo, mfaRepo, denylist, verification, tokens, logger}
 }
 
 func (s *AuthUsecase) Register(email, password string) error {
+	return s.registerWithRole(email, password, domain.RoleUser, nil, func(string) {})
+}
+
+// registerWithRole создает аккаунт с профилем роли или добавляет профиль к существующему аккаунту.
+// profile должен быть указателем на профиль (или nil); его ID заполняется через setID.
+// Новый аккаунт создается неподтвержденным, и на email уходит ссылка подтверждения.
+func (s *AuthUsecase) registerWithRole(email, password, role string, profile interface{}, setID func(id string)) error {
 	s.logger.WithFields(logrus.Fields{
 		"email": email,
-	}).Info("Attempting to register user")
+		"role":  role,
+	}).Infof("Attempting to register %s", role)
+
+	account, isNew, err := prepareAccount(s.accountRepo, s.logger, email, password, role)
+	if err != nil {
+		return err
+	}
+	setID(account.ID)
+
+	if err := s.accountRepo.AttachRole(account, role, profile); err != nil {
+		s.logger.WithError(err).Errorf("Failed to create %s", role)
+		return err
+	}
+
+	// Ошибка отправки не отменяет регистрацию: письмо можно запросить повторно
+	if isNew {
+		_ = s.verification.SendVerification(account, role)
+	}
+
+	s.logger.Infof("%s registered successfully", role)
+	return nil
+}
 
-	_, err := s.userRepo.GetByEmail(email)
+// prepareAccount возвращает аккаунт, к которому можно добавить роль, и признак того, что он новый.
+// Если аккаунт с таким email уже существует, пароль должен совпадать с сохраненным:
+// так один человек может стать, например, и исполнителем, и коучем с одним логином.
+// Используется и при регистрации, и при выдаче роли администратора (AdminUsecase).
+func prepareAccount(accountRepo repository.AccountRepository, logger *logrus.Logger, email, password, role string) (*domain.Account, bool, error) {
+	existing, err := accountRepo.GetByEmail(email)
 	if err == nil {
-		s.logger.Warn("User already exists")
-		return errors.New("user already exists")
+		if err := bcrypt.CompareHashAndPassword([]byte(existing.PasswordHash), []byte(password)); err != nil {
+			logger.Warn("Account already exists")
+			return nil, false, errors.New("account with this email already exists")
+		}
+
+		roles, err := accountRepo.GetRoles(existing.ID)
+		if err != nil {
+			logger.WithError(err).Error("Failed to get account roles")
+			return nil, false, err
+		}
+		if hasRole(roles, role) {
+			logger.Warnf("%s already exists", role)
+			return nil, false, fmt.Errorf("%s with this email already exists", role)
+		}
+		return existing, false, nil
 	}
 
 	if !utils.IsPasswordComplex(password) {
-		s.logger.Warn("Password does not meet complexity requirements")
-		return errors.New("password must contain at least one uppercase letter, one lowercase letter, one number, and one special character")
+		logger.Warn("Password does not meet complexity requirements")
+		return nil, false, ErrPasswordTooWeak
 	}
 
 	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to hash password")
-		return err
+		logger.WithError(err).Error("Failed to hash password")
+		return nil, false, err
 	}
 
-	user := &domain.User{
+	return &domain.Account{
+		ID:           uuid.New().String(),
 		Email:        email,
 		PasswordHash: string(hashed),
-	}
-
-	if err := s.userRepo.Create(user); err != nil {
-		s.logger.WithError(err).Error("Failed to create user")
-		return err
-	}
-
-	s.logger.Info("User registered successfully")
-	return nil
+	}, true, nil
 }
 
-func (s *AuthUsecase) Login(email, password string) (string, string, error) {
+// Login проверяет учетные данные, открывает новую сессию и выдает токены для указанной роли.
+func (s *AuthUsecase) Login(email, password, role string, client ClientInfo) (*TokenPair, error) {
 	s.logger.WithFields(logrus.Fields{
 		"email": email,
-	}).Info("Attempting to login user")
+		"role":  role,
+	}).Info("Attempting to login")
 
-	user, err := s.userRepo.GetByEmail(email)
+	account, err := s.accountRepo.GetByEmail(email)
 	if err != nil {
 		s.logger.Warn("Invalid email or password")
-		return "", "", errors.New("invalid email or password")
+		return nil, errors.New("invalid email or password")
 	}
 
-	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+	if err := bcrypt.CompareHashAndPassword([]byte(account.PasswordHash), []byte(password)); err != nil {
 		s.logger.Warn("Invalid email or password")
-		return "", "", errors.New("invalid email or password")
+		return nil, errors.New("invalid email or password")
 	}
 
-	accessToken, err := utils.GenerateToken(user.ID, s.jwtSecret)
+	roles, err := s.accountRepo.GetRoles(account.ID)
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to generate access token")
-		return "", "", err
+		s.logger.WithError(err).Error("Failed to get account roles")
+		return nil, err
+	}
+	if !hasRole(roles, role) {
+		s.logger.Warnf("Account has no %s role", role)
+		return nil, fmt.Errorf("account is not registered as %s", role)
 	}
 
-	refreshToken, err := utils.GenerateRefreshToken()
-	if err != nil {
-		s.logger.WithError(err).Error("Failed to generate refresh token")
-		return "", "", err
+	if s.mfaEnabled(account.ID) {
+		challenge, err := s.tokens.GenerateMFAChallenge(account.ID, role, time.Now())
+		if err != nil {
+			s.logger.WithError(err).Error("Failed to generate MFA challenge")
+			return nil, err
+		}
+		s.logger.Info("Password accepted, waiting for second factor")
+		return &TokenPair{MFARequired: true, MFAToken: challenge}, nil
 	}
 
-	refreshTokenModel := &domain.RefreshToken{
-		ID:        uuid.New().String(),
-		UserID:    user.ID,
-		Token:     refreshToken,
-		ExpiresAt: time.Now().Add(7 * 24 * time.Hour),
+	return s.openSession(account.ID, role, roles, client, false)
+}
+
+// mfaEnabled сообщает, подтвердил ли аккаунт подключение TOTP.
+func (s *AuthUsecase) mfaEnabled(accountID string) bool {
+	mfa, err := s.mfaRepo.Get(accountID)
+	return err == nil && mfa.EnabledAt != nil
+}
+
+// openSession создает сессию и выдает для нее первую пару токенов.
+// mfa отмечает, что вход подтвержден вторым фактором; признак сохраняется при обновлении токенов.
+func (s *AuthUsecase) openSession(accountID, role string, roles []string, client ClientInfo, mfa bool) (*TokenPair, error) {
+	session := &domain.Session{
+		ID:         uuid.New().String(),
+		AccountID:  accountID,
+		Role:       role,
+		UserAgent:  client.UserAgent,
+		IP:         client.IP,
+		MFA:        mfa,
+		LastUsedAt: time.Now(),
+	}
+
+	tokens, refreshTokenModel, err := s.issueTokens(session)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := s.userRepo.CreateRefreshToken(refreshTokenModel); err != nil {
-		s.logger.WithError(err).Error("Failed to save refresh token")
-		return "", "", err
+	if err := s.sessionRepo.Create(session, refreshTokenModel); err != nil {
+		s.logger.WithError(err).Error("Failed to save session")
+		return nil, err
 	}
 
-	s.logger.Info("User logged in successfully")
-	return accessToken, refreshToken, nil
+	s.logger.Info("Logged in successfully")
+	tokens.Roles = roles
+	return tokens, nil
+}
+
+// RefreshToken обменивает refresh token на новую пару токенов для указанной роли.
+// Токен, выданный для другой роли того же аккаунта, не принимается.
+// Каждый refresh token одноразовый: повторное предъявление уже обмененного токена
+// считается признаком кражи, и вся сессия вместе с семьей токенов отзывается.
+func (s *AuthUsecase) RefreshToken(refreshToken, role string, client ClientInfo) (*TokenPair, error) {
+	return s.refresh(refreshToken, role, client)
+}
+
+// Refresh обменивает refresh token на новую пару токенов для роли, для которой он выдан.
+// Используется общим /refresh, через который обновляются сессии, открытые в /login с любой ролью.
+func (s *AuthUsecase) Refresh(refreshToken string, client ClientInfo) (*TokenPair, error) {
+	return s.refresh(refreshToken, "", client)
 }
 
-func (s *AuthUsecase) RefreshToken(refreshToken string) (string, error) {
-	s.logger.Info("Attempting to refresh token")
+// refresh выполняет обмен токена; пустая role означает роль, сохраненную в токене.
+func (s *AuthUsecase) refresh(refreshToken, role string, client ClientInfo) (*TokenPair, error) {
+	s.logger.WithField("role", role).Info("Attempting to refresh token")
 
-	token, err := s.userRepo.GetRefreshToken(refreshToken)
+	token, err := s.sessionRepo.GetRefreshToken(utils.HashToken(refreshToken))
 	if err != nil {
 		s.logger.WithError(err).Warn("Invalid refresh token")
-		return "", errors.New("invalid refresh token")
+		return nil, errors.New("invalid refresh token")
+	}
+
+	if token.RevokedAt != nil {
+		s.logger.Warn("Revoked refresh token presented")
+		return nil, errors.New("invalid refresh token")
+	}
+
+	if token.UsedAt != nil {
+		return nil, s.handleRefreshTokenReuse(token)
+	}
+
+	if role == "" {
+		role = token.Role
+	}
+	if token.Role != role {
+		s.logger.WithFields(logrus.Fields{
+			"account_id":   token.AccountID,
+			"token_role":   token.Role,
+			"request_role": role,
+		}).Warn("Refresh token presented for another role")
+		return nil, errors.New("invalid refresh token")
 	}
 
 	if time.Now().After(token.ExpiresAt) {
 		s.logger.Warn("Refresh token expired")
-		return "", errors.New("refresh token expired")
+		return nil, errors.New("refresh token expired")
 	}
 
-	user, err := s.userRepo.GetByID(token.UserID)
+	session, err := s.sessionRepo.GetByID(token.FamilyID)
+	if err != nil || session.RevokedAt != nil {
+		s.logger.Warn("Session not found or revoked")
+		return nil, errors.New("invalid refresh token")
+	}
+
+	roles, err := s.accountRepo.GetRoles(token.AccountID)
 	if err != nil {
-		s.logger.WithError(err).Error("User not found for refresh token")
-		return "", errors.New("user not found")
+		s.logger.WithError(err).Error("Failed to get account roles")
+		return nil, err
 	}
+	if !hasRole(roles, role) {
+		s.logger.Warnf("Account has no %s role", role)
+		return nil, fmt.Errorf("account is not registered as %s", role)
+	}
+
+	session.UserAgent = client.UserAgent
+	session.IP = client.IP
+	session.LastUsedAt = time.Now()
 
-	accessToken, err := utils.GenerateToken(user.ID, s.jwtSecret)
+	tokens, next, err := s.issueTokens(session)
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to generate new access token")
-		return "", err
+		return nil, err
+	}
+
+	if err := s.sessionRepo.Rotate(session, token.ID, next); err != nil {
+		if errors.Is(err, repository.ErrRefreshTokenUsed) {
+			// Токен успели обменять параллельным запросом
+			return nil, s.handleRefreshTokenReuse(token)
+		}
+		s.logger.WithError(err).Error("Failed to rotate refresh token")
+		return nil, err
 	}
 
 	s.logger.Info("Token refreshed successfully")
-	return accessToken, nil
+	tokens.Roles = roles
+	return tokens, nil
+}
+
+// handleRefreshTokenReuse отзывает сессию, к которой принадлежит повторно предъявленный токен.
+func (s *AuthUsecase) handleRefreshTokenReuse(token *domain.RefreshToken) error {
+	s.logger.WithFields(logrus.Fields{
+		"account_id": token.AccountID,
+		"family_id":  token.FamilyID,
+		"token_id":   token.ID,
+	}).Warn("Refresh token reuse detected: possible token theft, revoking token family")
+
+	session, err := s.sessionRepo.GetByID(token.FamilyID)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get session")
+		return err
+	}
+	if err := s.revokeSession(session); err != nil {
+		return err
+	}
+	return errors.New("refresh token reuse detected, please login again")
+}
+
+// issueTokens выпускает access token и новый refresh token сессии.
+// Refresh token возвращается клиенту в открытом виде, а в модели сохраняется только его хеш.
+func (s *AuthUsecase) issueTokens(session *domain.Session) (*TokenPair, *domain.RefreshToken, error) {
+	accessToken, err := s.tokens.GenerateToken(session.AccountID, session.Role, session.ID, session.MFA)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to generate access token")
+		return nil, nil, err
+	}
+	session.AccessExpiresAt = accessToken.ExpiresAt
+
+	refreshToken, err := utils.GenerateRefreshToken()
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to generate refresh token")
+		return nil, nil, err
+	}
+
+	refreshTokenModel := &domain.RefreshToken{
+		ID:        uuid.New().String(),
+		AccountID: session.AccountID,
+		Role:      session.Role,
+		FamilyID:  session.ID,
+		TokenHash: utils.HashToken(refreshToken),
+		ExpiresAt: time.Now().Add(7 * 24 * time.Hour),
+	}
+
+	return &TokenPair{AccessToken: accessToken.Token, RefreshToken: refreshToken}, refreshTokenModel, nil
+}
+
+// Logout завершает текущую сессию; ее access token перестает приниматься сразу.
+func (s *AuthUsecase) Logout(accountID, sessionID string) error {
+	s.logger.WithField("account_id", accountID).Info("Attempting to logout")
+	return s.RevokeSession(accountID, sessionID)
 }
 
-func (s *AuthUsecase) GetUserByID(id string) (*domain.User, error) {
-	user, err := s.userRepo.GetByID(id)
+func (s *AuthUsecase) ListSessions(accountID string) ([]domain.Session, error) {
+	sessions, err := s.sessionRepo.ListActive(accountID)
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get user by ID")
+		s.logger.WithError(err).Error("Failed to list sessions")
 		return nil, err
 	}
-	return user, nil
+	return sessions, nil
+}
+
+// RevokeSession завершает сессию аккаунта, в том числе открытую на другом устройстве.
+func (s *AuthUsecase) RevokeSession(accountID, sessionID string) error {
+	session, err := s.sessionRepo.GetByID(sessionID)
+	if err != nil || session.AccountID != accountID {
+		s.logger.Warn("Session not found")
+		return errors.New("session not found")
+	}
+
+	if err := s.revokeSession(session); err != nil {
+		return err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"account_id": accountID,
+		"session_id": sessionID,
+	}).Info("Session revoked")
+	return nil
+}
+
+// RevokeAllSessions завершает все сессии аккаунта, кроме exceptSessionID (может быть пустым).
+func (s *AuthUsecase) RevokeAllSessions(accountID, exceptSessionID string) error {
+	sessions, err := s.sessionRepo.ListActive(accountID)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to list sessions")
+		return err
+	}
+
+	for i := range sessions {
+		if sessions[i].ID == exceptSessionID {
+			continue
+		}
+		if err := s.revokeSession(&sessions[i]); err != nil {
+			return err
+		}
+	}
+
+	s.logger.WithField("account_id", accountID).Info("Sessions revoked")
+	return nil
+}
+
+// revokeSession отзывает refresh токены сессии и добавляет ее ID в denylist,
+// чтобы уже выданные access token перестали приниматься до истечения срока.
+func (s *AuthUsecase) revokeSession(session *domain.Session) error {
+	if err := s.sessionRepo.Revoke(session.ID); err != nil {
+		s.logger.WithError(err).Error("Failed to revoke session")
+		return err
+	}
+
+	if time.Now().Before(session.AccessExpiresAt) {
+		if err := s.denylist.Revoke(session.ID, session.AccessExpiresAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *AuthUsecase) GetAccountByID(id string) (*domain.Account, []string, error) {
+	account, err := s.accountRepo.GetByID(id)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get account by ID")
+		return nil, nil, err
+	}
+
+	roles, err := s.accountRepo.GetRoles(id)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get account roles")
+		return nil, nil, err
+	}
+	return account, roles, nil
+}
+
+func hasRole(roles []string, role string) bool {
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
 }
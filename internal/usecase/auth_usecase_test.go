@@ -0,0 +1,221 @@
+package usecase
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"BruhPro/internal/domain"
+	"BruhPro/internal/repository"
+	"BruhPro/internal/utils"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// memoryAccountRepository хранит аккаунты и их роли в памяти.
+type memoryAccountRepository struct {
+	accounts map[string]*domain.Account
+	roles    map[string][]string
+}
+
+func newMemoryAccountRepository() *memoryAccountRepository {
+	return &memoryAccountRepository{accounts: map[string]*domain.Account{}, roles: map[string][]string{}}
+}
+
+func (r *memoryAccountRepository) GetByEmail(email string) (*domain.Account, error) {
+	for _, account := range r.accounts {
+		if account.Email == email {
+			copied := *account
+			return &copied, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func (r *memoryAccountRepository) GetByID(id string) (*domain.Account, error) {
+	account, ok := r.accounts[id]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	copied := *account
+	return &copied, nil
+}
+
+func (r *memoryAccountRepository) GetRoles(accountID string) ([]string, error) {
+	return append([]string(nil), r.roles[accountID]...), nil
+}
+
+func (r *memoryAccountRepository) AttachRole(account *domain.Account, role string, profile interface{}) error {
+	if _, ok := r.accounts[account.ID]; !ok {
+		copied := *account
+		r.accounts[account.ID] = &copied
+	}
+	if hasRole(r.roles[account.ID], role) {
+		return errors.New("role already attached")
+	}
+	r.roles[account.ID] = append(r.roles[account.ID], role)
+	return nil
+}
+
+func (r *memoryAccountRepository) UpdatePassword(id, passwordHash string) error {
+	r.accounts[id].PasswordHash = passwordHash
+	return nil
+}
+
+// memorySessionRepository повторяет условие Rotate: токен обменивается только один раз.
+type memorySessionRepository struct {
+	sessions map[string]*domain.Session
+	tokens   map[string]*domain.RefreshToken
+}
+
+func newMemorySessionRepository() *memorySessionRepository {
+	return &memorySessionRepository{sessions: map[string]*domain.Session{}, tokens: map[string]*domain.RefreshToken{}}
+}
+
+func (r *memorySessionRepository) Create(session *domain.Session, token *domain.RefreshToken) error {
+	copied := *session
+	r.sessions[session.ID] = &copied
+	r.tokens[token.ID] = token
+	return nil
+}
+
+func (r *memorySessionRepository) GetByID(id string) (*domain.Session, error) {
+	session, ok := r.sessions[id]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	copied := *session
+	return &copied, nil
+}
+
+func (r *memorySessionRepository) ListActive(accountID string) ([]domain.Session, error) {
+	var sessions []domain.Session
+	for _, session := range r.sessions {
+		if session.AccountID == accountID && session.RevokedAt == nil {
+			sessions = append(sessions, *session)
+		}
+	}
+	return sessions, nil
+}
+
+func (r *memorySessionRepository) GetRefreshToken(tokenHash string) (*domain.RefreshToken, error) {
+	for _, token := range r.tokens {
+		if token.TokenHash == tokenHash {
+			copied := *token
+			return &copied, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func (r *memorySessionRepository) Rotate(session *domain.Session, usedID string, next *domain.RefreshToken) error {
+	used := r.tokens[usedID]
+	if used.UsedAt != nil || used.RevokedAt != nil {
+		return repository.ErrRefreshTokenUsed
+	}
+	now := time.Now()
+	used.UsedAt = &now
+	r.tokens[next.ID] = next
+	copied := *session
+	r.sessions[session.ID] = &copied
+	return nil
+}
+
+func (r *memorySessionRepository) Revoke(id string) error {
+	now := time.Now()
+	r.sessions[id].RevokedAt = &now
+	for _, token := range r.tokens {
+		if token.FamilyID == id && token.RevokedAt == nil {
+			token.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+func newTestJWTManager(t *testing.T) *utils.JWTManager {
+	t.Helper()
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := &utils.JWTKey{ID: "test", Method: jwt.SigningMethodEdDSA, Private: private, Public: public}
+	manager, err := utils.NewJWTManager([]*utils.JWTKey{key}, "test", "buhpro-test", "buhpro-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return manager
+}
+
+func newTestAuthUsecase(t *testing.T) (*AuthUsecase, *memoryAccountRepository, *utils.JWTManager) {
+	t.Helper()
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	accounts := newMemoryAccountRepository()
+	tokens := newTestJWTManager(t)
+	auth := NewAuthUsecase(accounts, newMemorySessionRepository(), newMemoryMFARepository(), nil, nil, tokens, logger)
+	return auth, accounts, tokens
+}
+
+// addTestAccount создает аккаунт с паролем testPassword и ролями roles.
+func addTestAccount(t *testing.T, accounts *memoryAccountRepository, id, email string, roles ...string) {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte(testPassword), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	account := &domain.Account{ID: id, Email: email, PasswordHash: string(hash)}
+	for _, role := range roles {
+		if err := accounts.AttachRole(account, role, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+const testPassword = "Str0ng!Passw0rd"
+
+func accessRole(t *testing.T, tokens *utils.JWTManager, accessToken string) string {
+	t.Helper()
+	claims, err := tokens.ParseAccessToken(accessToken)
+	if err != nil {
+		t.Fatalf("parse access token: %v", err)
+	}
+	role, _ := claims["role"].(string)
+	return role
+}
+
+func TestRefreshAfterCustomerLogin(t *testing.T) {
+	auth, accounts, tokens := newTestAuthUsecase(t)
+	addTestAccount(t, accounts, "account", "customer@example.com", domain.RoleCustomer)
+	client := ClientInfo{UserAgent: "test", IP: "127.0.0.1"}
+
+	login, err := auth.Login("customer@example.com", testPassword, domain.RoleCustomer, client)
+	if err != nil {
+		t.Fatalf("login: %v", err)
+	}
+
+	// Общий /refresh берет роль из токена
+	refreshed, err := auth.Refresh(login.RefreshToken, client)
+	if err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	if role := accessRole(t, tokens, refreshed.AccessToken); role != domain.RoleCustomer {
+		t.Errorf("refreshed role = %q, want %q", role, domain.RoleCustomer)
+	}
+
+	// /customer/refresh принимает следующий токен семьи
+	again, err := auth.RefreshToken(refreshed.RefreshToken, domain.RoleCustomer, client)
+	if err != nil {
+		t.Fatalf("customer refresh: %v", err)
+	}
+
+	// /executor/refresh токен клиента не принимает
+	if _, err := auth.RefreshToken(again.RefreshToken, domain.RoleExecutor, client); err == nil {
+		t.Fatal("customer token accepted by executor refresh")
+	}
+}
@@ -1,144 +1,124 @@
 package usecase
 
 import (
-	"errors"
-	"time"
+	"BruhPro/internal/domain"
+	"BruhPro/internal/repository"
 
-	"BuhPro+/internal/domain"
-	"BuhPro+/internal/repository"
-	"BuhPro+/internal/utils" // Обновлен импорт
-
-	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
-	"golang.org/x/crypto/bcrypt"
 )
 
 type CoachUsecase struct {
-	coachRepo repository.CoachRepository
-	jwtSecret string
-	logger    *logrus.Logger
+	coachRepo       repository.CoachRepository
+	auth            *AuthUsecase
+	specializations *SpecializationUsecase
+	logger          *logrus.Logger
 }
 
-func NewCoachUsecase(repo repository.CoachRepository, secret string, logger *logrus.Logger) *CoachUsecase {
-	return &CoachUsecase{repo, secret, logger}
+func NewCoachUsecase(repo repository.CoachRepository, auth *AuthUsecase, specializations *SpecializationUsecase, logger *logrus.Logger) *CoachUsecase {
+	return &CoachUsecase{repo, auth, specializations, logger}
 }
 
-// internal/usecase/coach_usecase.go
-// ... (остальная часть файла)
-
-func (s *CoachUsecase) RegisterCoach(coach *domain.Coach) error { // Изменена сигнатура
-	s.logger.WithFields(logrus.Fields{
-		"email": coach.Email,
-	}).Info("Attempting to register coach")
-
-	_, err := s.coachRepo.GetByEmail(coach.Email)
-	if err == nil {
-		s.logger.Warn("Coach already exists")
-		return errors.New("coach with this email already exists")
-	}
-
-	if !utils.IsPasswordComplex(coach.PasswordHash) {
-		s.logger.Warn("Password does not meet complexity requirements")
-		return errors.New("password must contain at least one uppercase letter, one lowercase letter, one number, and one special character")
-	}
-
-	hashed, err := bcrypt.GenerateFromPassword([]byte(coach.PasswordHash), bcrypt.DefaultCost)
-	if err != nil {
-		s.logger.WithError(err).Error("Failed to hash password")
-		return err
-	}
-	coach.PasswordHash = string(hashed) // Хешируем пароль
-
-	if err := s.coachRepo.Create(coach); err != nil {
-		s.logger.WithError(err).Error("Failed to create coach")
+// RegisterCoach создает аккаунт с профилем коуча или добавляет профиль к уже существующему аккаунту.
+func (s *CoachUsecase) RegisterCoach(email, password string, coach *domain.Coach) error {
+	if err := s.specializations.Validate(domain.RoleCoach, specializationCodes(coach.Specializations)...); err != nil {
 		return err
 	}
-
-	s.logger.Info("Coach registered successfully")
-	return nil
+	return s.auth.registerWithRole(email, password, domain.RoleCoach, coach, func(id string) { coach.ID = id })
 }
 
-// ... (остальная часть файла)
+func (s *CoachUsecase) LoginCoach(email, password string, client ClientInfo) (*TokenPair, error) {
+	return s.auth.Login(email, password, domain.RoleCoach, client)
+}
 
-func (s *CoachUsecase) LoginCoach(email, password string) (string, string, error) {
-	s.logger.WithFields(logrus.Fields{
-		"email": email,
-	}).Info("Attempting to login coach")
+func (s *CoachUsecase) RefreshCoachToken(refreshToken string, client ClientInfo) (*TokenPair, error) {
+	return s.auth.RefreshToken(refreshToken, domain.RoleCoach, client)
+}
 
-	coach, err := s.coachRepo.GetByEmail(email)
+func (s *CoachUsecase) GetCoachByID(id string) (*domain.Coach, error) {
+	coach, err := s.coachRepo.GetByID(id)
 	if err != nil {
-		s.logger.Warn("Invalid email or password for coach login")
-		return "", "", errors.New("invalid email or password")
-	}
-
-	if err := bcrypt.CompareHashAndPassword([]byte(coach.PasswordHash), []byte(password)); err != nil {
-		s.logger.Warn("Invalid email or password for coach login")
-		return "", "", errors.New("invalid email or password")
+		s.logger.WithError(err).Error("Failed to get coach by ID")
+		return nil, err
 	}
+	return coach, nil
+}
 
-	accessToken, err := utils.GenerateToken(coach.ID, s.jwtSecret)
+// SearchCoaches возвращает страницу публичного поиска коучей и курсор следующей страницы.
+// Пустой курсор в ответе означает, что страниц больше нет.
+func (s *CoachUsecase) SearchCoaches(filter repository.CoachFilter, cursor string) ([]domain.Coach, string, error) {
+	filter.Sort = searchSort(filter.Sort, filter.Query)
+	after, err := decodeSearchCursor(cursor, filter.Sort)
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to generate access token for coach")
-		return "", "", err
+		return nil, "", err
 	}
+	filter.After = after
+	filter.VerifiedOnly = true // в публичный каталог попадают только профили с подтвержденным email
+	limit := pageLimit(filter.Limit)
+	filter.Limit = limit + 1 // лишняя запись показывает, есть ли следующая страница
 
-	refreshToken, err := utils.GenerateRefreshToken()
+	coaches, err := s.coachRepo.Search(filter)
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to generate refresh token for coach")
-		return "", "", err
+		s.logger.WithError(err).Error("Failed to search coaches")
+		return nil, "", err
 	}
-
-	refreshTokenModel := &domain.RefreshToken{
-		ID:        uuid.New().String(),
-		UserID:    coach.ID,
-		Token:     refreshToken,
-		ExpiresAt: time.Now().Add(7 * 24 * time.Hour),
+	if len(coaches) <= limit {
+		return coaches, "", nil
 	}
 
-	if err := s.coachRepo.CreateRefreshToken(refreshTokenModel); err != nil {
-		s.logger.WithError(err).Error("Failed to save refresh token for coach")
-		return "", "", err
+	coaches = coaches[:limit]
+	last := &coaches[limit-1]
+	next := repository.SearchCursor{Sort: filter.Sort, ID: last.ID, CreatedAt: last.CreatedAt}
+	switch filter.Sort {
+	case repository.SortRelevance:
+		next.Value = last.SearchRank
+	case repository.SortRating:
+		next.Value = last.Rating.Average
 	}
-
-	s.logger.Info("Coach logged in successfully")
-	return accessToken, refreshToken, nil
+	return coaches, encodeSearchCursor(next), nil
 }
 
-func (s *CoachUsecase) RefreshCoachToken(refreshToken string) (string, error) {
-	s.logger.Info("Attempting to refresh coach token")
-
-	token, err := s.coachRepo.GetRefreshToken(refreshToken)
+// UpdateCoachProfile меняет переданные поля профиля коуча, если его версия все еще version,
+// и возвращает профиль после изменения.
+func (s *CoachUsecase) UpdateCoachProfile(id string, version int64, patch CoachPatch) (*domain.Coach, error) {
+	coach, err := s.coachRepo.GetByID(id)
 	if err != nil {
-		s.logger.WithError(err).Warn("Invalid refresh token for coach")
-		return "", errors.New("invalid refresh token")
+		return nil, ErrProfileNotFound
 	}
-
-	if time.Now().After(token.ExpiresAt) {
-		s.logger.Warn("Refresh token expired for coach")
-		return "", errors.New("refresh token expired")
+	if coach.Version != version {
+		return nil, ErrProfileVersionConflict
 	}
 
-	coach, err := s.coachRepo.GetByID(token.UserID)
-	if err != nil {
-		s.logger.WithError(err).Error("Coach not found for refresh token")
-		return "", errors.New("coach not found")
+	edit := newProfileEdit(id, domain.RoleCoach, version)
+	setField(edit, "name", &coach.Name, patch.Name)
+	setField(edit, "surname", &coach.Surname, patch.Surname)
+	setField(edit, "phone_number", &coach.PhoneNumber, patch.PhoneNumber)
+	setField(edit, "exp_coach", &coach.ExpCoach, patch.ExpCoach)
+	setField(edit, "education_certificates", &coach.EducationCertificates, patch.EducationCertificates)
+	setField(edit, "achievements_experience", &coach.AchievementsExperience, patch.AchievementsExperience)
+	setField(edit, "methodology", &coach.Methodology, patch.Methodology)
+	setField(edit, "about_coach", &coach.AboutCoach, patch.AboutCoach)
+	if added := edit.setSpecializations(coach.Specializations, patch.Specializations); len(added) > 0 {
+		if err := s.specializations.Validate(domain.RoleCoach, added...); err != nil {
+			return nil, err
+		}
 	}
-
-	accessToken, err := utils.GenerateToken(coach.ID, s.jwtSecret)
-	if err != nil {
-		s.logger.WithError(err).Error("Failed to generate new access token for coach")
-		return "", err
+	if edit.empty() {
+		return coach, nil
 	}
 
-	s.logger.Info("Coach token refreshed successfully")
-	return accessToken, nil
+	if err := s.coachRepo.Update(edit.update()); err != nil {
+		s.logger.WithError(err).Warn("Failed to update coach profile")
+		return nil, profileUpdateError(err)
+	}
+	return s.GetCoachByID(id)
 }
 
-func (s *CoachUsecase) GetCoachByID(id string) (*domain.Coach, error) {
-	coach, err := s.coachRepo.GetByID(id)
+// ListCoachProfileChanges возвращает историю изменений профиля коуча, новые первыми.
+func (s *CoachUsecase) ListCoachProfileChanges(id string, limit, offset int) ([]domain.ProfileChange, error) {
+	changes, err := s.coachRepo.ListChanges(id, pageLimit(limit), offset)
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get coach by ID")
+		s.logger.WithError(err).Error("Failed to list coach profile changes")
 		return nil, err
 	}
-	return coach, nil
+	return changes, nil
 }
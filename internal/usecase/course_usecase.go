@@ -0,0 +1,381 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"BruhPro/internal/domain"
+	"BruhPro/internal/repository"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrCourseNotFound  = errors.New("course not found")
+	ErrCourseForbidden = errors.New("course belongs to another coach")
+	ErrNotEnrolled     = errors.New("you are not enrolled in this course")
+	ErrCourseNotPaid   = errors.New("course access opens after payment")
+)
+
+// CourseProgress — прогресс прохождения курса.
+type CourseProgress struct {
+	Enrollment       *domain.CourseEnrollment
+	CompletedLessons []domain.LessonProgress
+	TotalLessons     int
+}
+
+// CourseUsecase управляет курсами коучей, записью на курсы и прогрессом.
+type CourseUsecase struct {
+	courseRepo      repository.CourseRepository
+	enrollmentRepo  repository.EnrollmentRepository
+	specializations *SpecializationUsecase
+	logger          *logrus.Logger
+}
+
+func NewCourseUsecase(courseRepo repository.CourseRepository, enrollmentRepo repository.EnrollmentRepository, specializations *SpecializationUsecase, logger *logrus.Logger) *CourseUsecase {
+	return &CourseUsecase{courseRepo, enrollmentRepo, specializations, logger}
+}
+
+// CreateCourse создает черновик курса.
+func (s *CourseUsecase) CreateCourse(coachID string, course *domain.Course) error {
+	s.logger.WithField("coach_id", coachID).Info("Attempting to create course")
+
+	if err := s.validateCourse(course); err != nil {
+		s.logger.WithError(err).Warn("Invalid course")
+		return err
+	}
+
+	course.ID = ""
+	course.CoachID = coachID
+	course.Status = domain.CourseStatusDraft
+	course.PublishedAt = nil
+	course.Modules = nil
+
+	if err := s.courseRepo.Create(course); err != nil {
+		s.logger.WithError(err).Error("Failed to create course")
+		return err
+	}
+
+	s.logger.WithField("course_id", course.ID).Info("Course created successfully")
+	return nil
+}
+
+// UpdateCourse изменяет описание и цену своего курса.
+func (s *CourseUsecase) UpdateCourse(coachID, id string, changes *domain.Course) (*domain.Course, error) {
+	course, err := s.ownCourse(coachID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validateCourse(changes); err != nil {
+		s.logger.WithError(err).Warn("Invalid course")
+		return nil, err
+	}
+
+	course.Title = changes.Title
+	course.Description = changes.Description
+	course.Specialization = changes.Specialization
+	course.Price = changes.Price
+
+	if err := s.courseRepo.Update(course); err != nil {
+		s.logger.WithError(err).Error("Failed to update course")
+		return nil, err
+	}
+
+	s.logger.WithField("course_id", id).Info("Course updated successfully")
+	return course, nil
+}
+
+// Publish выводит курс в каталог. Курс без уроков опубликовать нельзя.
+func (s *CourseUsecase) Publish(coachID, id string) (*domain.Course, error) {
+	if _, err := s.ownCourse(coachID, id); err != nil {
+		return nil, err
+	}
+
+	course, err := s.courseRepo.GetWithContent(id)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to load course content")
+		return nil, err
+	}
+	if countLessons(course) == 0 {
+		return nil, errors.New("course must contain at least one lesson to be published")
+	}
+
+	now := time.Now()
+	course.Status = domain.CourseStatusPublished
+	course.PublishedAt = &now
+
+	if err := s.courseRepo.Update(course); err != nil {
+		s.logger.WithError(err).Error("Failed to publish course")
+		return nil, err
+	}
+
+	s.logger.WithField("course_id", id).Info("Course published")
+	return course, nil
+}
+
+// Unpublish убирает курс из каталога. Записавшиеся сохраняют доступ к урокам.
+func (s *CourseUsecase) Unpublish(coachID, id string) (*domain.Course, error) {
+	course, err := s.ownCourse(coachID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	course.Status = domain.CourseStatusDraft
+	if err := s.courseRepo.Update(course); err != nil {
+		s.logger.WithError(err).Error("Failed to unpublish course")
+		return nil, err
+	}
+
+	s.logger.WithField("course_id", id).Info("Course unpublished")
+	return course, nil
+}
+
+// AddModule добавляет раздел в свой курс.
+func (s *CourseUsecase) AddModule(coachID, courseID string, module *domain.CourseModule) error {
+	if _, err := s.ownCourse(coachID, courseID); err != nil {
+		return err
+	}
+
+	module.ID = ""
+	module.CourseID = courseID
+	module.Lessons = nil
+
+	if err := s.courseRepo.CreateModule(module); err != nil {
+		s.logger.WithError(err).Error("Failed to create course module")
+		return err
+	}
+	return nil
+}
+
+// AddLesson добавляет урок в раздел своего курса.
+func (s *CourseUsecase) AddLesson(coachID, courseID, moduleID string, lesson *domain.Lesson) error {
+	if _, err := s.ownCourse(coachID, courseID); err != nil {
+		return err
+	}
+
+	module, err := s.courseRepo.GetModule(moduleID)
+	if err != nil || module.CourseID != courseID {
+		s.logger.Warn("Course module not found")
+		return errors.New("course module not found")
+	}
+
+	lesson.ID = ""
+	lesson.ModuleID = moduleID
+	lesson.CourseID = courseID
+	for i := range lesson.Attachments {
+		lesson.Attachments[i].ID = ""
+	}
+
+	if err := s.courseRepo.CreateLesson(lesson); err != nil {
+		s.logger.WithError(err).Error("Failed to create lesson")
+		return err
+	}
+	return nil
+}
+
+// ListCatalog возвращает опубликованные курсы.
+func (s *CourseUsecase) ListCatalog(filter repository.CourseFilter) ([]domain.Course, error) {
+	filter.Status = domain.CourseStatusPublished
+	return s.list(filter)
+}
+
+// ListByCoach возвращает все курсы коуча, включая черновики.
+func (s *CourseUsecase) ListByCoach(coachID string, filter repository.CourseFilter) ([]domain.Course, error) {
+	filter.CoachID = coachID
+	filter.Status = ""
+	return s.list(filter)
+}
+
+func (s *CourseUsecase) list(filter repository.CourseFilter) ([]domain.Course, error) {
+	if filter.Limit <= 0 || filter.Limit > maxPageSize {
+		filter.Limit = defaultPageSize
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	courses, err := s.courseRepo.List(filter)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to list courses")
+		return nil, err
+	}
+	return courses, nil
+}
+
+// GetCourse возвращает курс с программой. Черновик виден только своему коучу.
+func (s *CourseUsecase) GetCourse(viewerID, id string) (*domain.Course, error) {
+	course, err := s.courseRepo.GetWithContent(id)
+	if err != nil {
+		s.logger.WithError(err).Warn("Course not found")
+		return nil, ErrCourseNotFound
+	}
+
+	if course.Status != domain.CourseStatusPublished && course.CoachID != viewerID {
+		return nil, ErrCourseNotFound
+	}
+	return course, nil
+}
+
+// GetLesson возвращает содержимое урока коучу курса или записавшемуся с активной записью.
+func (s *CourseUsecase) GetLesson(viewerID, courseID, lessonID string) (*domain.Lesson, error) {
+	lesson, err := s.courseRepo.GetLesson(lessonID)
+	if err != nil || lesson.CourseID != courseID {
+		s.logger.Warn("Lesson not found")
+		return nil, errors.New("lesson not found")
+	}
+
+	course, err := s.courseRepo.GetByID(courseID)
+	if err != nil {
+		return nil, ErrCourseNotFound
+	}
+	if course.CoachID == viewerID {
+		return lesson, nil
+	}
+
+	if _, err := s.activeEnrollment(viewerID, courseID); err != nil {
+		return nil, err
+	}
+	return lesson, nil
+}
+
+// Enroll записывает клиента или исполнителя на опубликованный курс.
+// На бесплатный курс доступ открывается сразу, на платный — после оплаты.
+func (s *CourseUsecase) Enroll(accountID, role, courseID string) (*domain.CourseEnrollment, error) {
+	s.logger.WithFields(logrus.Fields{
+		"account_id": accountID,
+		"course_id":  courseID,
+	}).Info("Attempting to enroll in course")
+
+	course, err := s.courseRepo.GetByID(courseID)
+	if err != nil || course.Status != domain.CourseStatusPublished {
+		s.logger.Warn("Course not found or not published")
+		return nil, ErrCourseNotFound
+	}
+
+	if _, err := s.enrollmentRepo.Get(courseID, accountID); err == nil {
+		s.logger.Warn("Already enrolled")
+		return nil, errors.New("you are already enrolled in this course")
+	}
+
+	enrollment := &domain.CourseEnrollment{
+		CourseID:  courseID,
+		AccountID: accountID,
+		Role:      role,
+		Status:    domain.EnrollmentStatusActive,
+	}
+	if course.Price > 0 {
+		enrollment.Status = domain.EnrollmentStatusPendingPayment
+	}
+
+	if err := s.enrollmentRepo.Create(enrollment); err != nil {
+		s.logger.WithError(err).Error("Failed to create enrollment")
+		return nil, err
+	}
+
+	s.logger.WithField("enrollment_id", enrollment.ID).Info("Enrolled in course successfully")
+	return enrollment, nil
+}
+
+func (s *CourseUsecase) ListEnrollments(accountID string) ([]domain.CourseEnrollment, error) {
+	enrollments, err := s.enrollmentRepo.ListByAccount(accountID)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to list enrollments")
+		return nil, err
+	}
+	return enrollments, nil
+}
+
+// CompleteLesson отмечает урок курса пройденным.
+func (s *CourseUsecase) CompleteLesson(accountID, courseID, lessonID string) error {
+	enrollment, err := s.activeEnrollment(accountID, courseID)
+	if err != nil {
+		return err
+	}
+
+	lesson, err := s.courseRepo.GetLesson(lessonID)
+	if err != nil || lesson.CourseID != courseID {
+		s.logger.Warn("Lesson not found")
+		return errors.New("lesson not found")
+	}
+
+	progress := &domain.LessonProgress{
+		EnrollmentID: enrollment.ID,
+		LessonID:     lessonID,
+		CompletedAt:  time.Now(),
+	}
+	if err := s.enrollmentRepo.CompleteLesson(progress); err != nil {
+		s.logger.WithError(err).Error("Failed to save lesson progress")
+		return err
+	}
+	return nil
+}
+
+// Progress возвращает пройденные уроки курса и их общее число.
+func (s *CourseUsecase) Progress(accountID, courseID string) (*CourseProgress, error) {
+	enrollment, err := s.enrollmentRepo.Get(courseID, accountID)
+	if err != nil {
+		return nil, ErrNotEnrolled
+	}
+
+	course, err := s.courseRepo.GetWithContent(courseID)
+	if err != nil {
+		return nil, ErrCourseNotFound
+	}
+
+	completed, err := s.enrollmentRepo.ListProgress(enrollment.ID)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to load lesson progress")
+		return nil, err
+	}
+
+	return &CourseProgress{
+		Enrollment:       enrollment,
+		CompletedLessons: completed,
+		TotalLessons:     countLessons(course),
+	}, nil
+}
+
+func (s *CourseUsecase) activeEnrollment(accountID, courseID string) (*domain.CourseEnrollment, error) {
+	enrollment, err := s.enrollmentRepo.Get(courseID, accountID)
+	if err != nil {
+		return nil, ErrNotEnrolled
+	}
+	if enrollment.Status != domain.EnrollmentStatusActive {
+		return nil, ErrCourseNotPaid
+	}
+	return enrollment, nil
+}
+
+// ownCourse загружает курс и проверяет, что он принадлежит коучу.
+func (s *CourseUsecase) ownCourse(coachID, id string) (*domain.Course, error) {
+	course, err := s.courseRepo.GetByID(id)
+	if err != nil {
+		s.logger.WithError(err).Warn("Course not found")
+		return nil, ErrCourseNotFound
+	}
+
+	if course.CoachID != coachID {
+		s.logger.Warn("Course belongs to another coach")
+		return nil, ErrCourseForbidden
+	}
+	return course, nil
+}
+
+func (s *CourseUsecase) validateCourse(course *domain.Course) error {
+	if err := s.specializations.Validate(domain.RoleCoach, course.Specialization); err != nil {
+		return err
+	}
+	if course.Price < 0 {
+		return errors.New("price must not be negative")
+	}
+	return nil
+}
+
+func countLessons(course *domain.Course) int {
+	total := 0
+	for _, module := range course.Modules {
+		total += len(module.Lessons)
+	}
+	return total
+}
@@ -1,143 +1,79 @@
 package usecase
 
 import (
-	"errors"
-	"time"
+	"BruhPro/internal/domain"
+	"BruhPro/internal/repository"
 
-	"BuhPro+/internal/domain"
-	"BuhPro+/internal/repository"
-	"BuhPro+/internal/utils" // Обновлен импорт
-
-	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
-	"golang.org/x/crypto/bcrypt"
 )
 
 type CustomerUsecase struct {
 	customerRepo repository.CustomerRepository
-	jwtSecret    string
+	auth         *AuthUsecase
 	logger       *logrus.Logger
 }
 
-func NewCustomerUsecase(repo repository.CustomerRepository, secret string, logger *logrus.Logger) *CustomerUsecase {
-	return &CustomerUsecase{repo, secret, logger}
+func NewCustomerUsecase(repo repository.CustomerRepository, auth *AuthUsecase, logger *logrus.Logger) *CustomerUsecase {
+	return &CustomerUsecase{repo, auth, logger}
 }
 
-// internal/usecase/customer_usecase.go
-// ... (остальная часть файла)
-
-func (s *CustomerUsecase) RegisterCustomer(customer *domain.Customer) error { // Изменена сигнатура
-	s.logger.WithFields(logrus.Fields{
-		"email": customer.Email,
-	}).Info("Attempting to register customer")
-
-	_, err := s.customerRepo.GetByEmail(customer.Email)
-	if err == nil {
-		s.logger.Warn("Customer already exists")
-		return errors.New("customer with this email already exists")
-	}
-
-	if !utils.IsPasswordComplex(customer.PasswordHash) { // Проверяем переданный пароль
-		s.logger.Warn("Password does not meet complexity requirements")
-		return errors.New("password must contain at least one uppercase letter, one lowercase letter, one number, and one special character")
-	}
-
-	hashed, err := bcrypt.GenerateFromPassword([]byte(customer.PasswordHash), bcrypt.DefaultCost)
-	if err != nil {
-		s.logger.WithError(err).Error("Failed to hash password")
-		return err
-	}
-	customer.PasswordHash = string(hashed) // Хешируем пароль и сохраняем в модели
-
-	if err := s.customerRepo.Create(customer); err != nil {
-		s.logger.WithError(err).Error("Failed to create customer")
-		return err
-	}
-
-	s.logger.Info("Customer registered successfully")
-	return nil
+// RegisterCustomer создает аккаунт с профилем клиента или добавляет профиль к уже существующему аккаунту.
+func (s *CustomerUsecase) RegisterCustomer(email, password string, customer *domain.Customer) error {
+	return s.auth.registerWithRole(email, password, domain.RoleCustomer, customer, func(id string) { customer.ID = id })
 }
 
-// ... (остальная часть файла)
-func (s *CustomerUsecase) LoginCustomer(email, password string) (string, string, error) {
-	s.logger.WithFields(logrus.Fields{
-		"email": email,
-	}).Info("Attempting to login customer")
-
-	customer, err := s.customerRepo.GetByEmail(email)
-	if err != nil {
-		s.logger.Warn("Invalid email or password for customer login")
-		return "", "", errors.New("invalid email or password")
-	}
-
-	if err := bcrypt.CompareHashAndPassword([]byte(customer.PasswordHash), []byte(password)); err != nil {
-		s.logger.Warn("Invalid email or password for customer login")
-		return "", "", errors.New("invalid email or password")
-	}
+func (s *CustomerUsecase) LoginCustomer(email, password string, client ClientInfo) (*TokenPair, error) {
+	return s.auth.Login(email, password, domain.RoleCustomer, client)
+}
 
-	accessToken, err := utils.GenerateToken(customer.ID, s.jwtSecret)
-	if err != nil {
-		s.logger.WithError(err).Error("Failed to generate access token for customer")
-		return "", "", err
-	}
+func (s *CustomerUsecase) RefreshCustomerToken(refreshToken string, client ClientInfo) (*TokenPair, error) {
+	return s.auth.RefreshToken(refreshToken, domain.RoleCustomer, client)
+}
 
-	refreshToken, err := utils.GenerateRefreshToken()
+func (s *CustomerUsecase) GetCustomerByID(id string) (*domain.Customer, error) {
+	customer, err := s.customerRepo.GetByID(id)
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to generate refresh token for customer")
-		return "", "", err
-	}
-
-	refreshTokenModel := &domain.RefreshToken{
-		ID:        uuid.New().String(),
-		UserID:    customer.ID,
-		Token:     refreshToken,
-		ExpiresAt: time.Now().Add(7 * 24 * time.Hour),
-	}
-
-	if err := s.customerRepo.CreateRefreshToken(refreshTokenModel); err != nil {
-		s.logger.WithError(err).Error("Failed to save refresh token for customer")
-		return "", "", err
+		s.logger.WithError(err).Error("Failed to get customer by ID")
+		return nil, err
 	}
-
-	s.logger.Info("Customer logged in successfully")
-	return accessToken, refreshToken, nil
+	return customer, nil
 }
 
-func (s *CustomerUsecase) RefreshCustomerToken(refreshToken string) (string, error) {
-	s.logger.Info("Attempting to refresh customer token")
-
-	token, err := s.customerRepo.GetRefreshToken(refreshToken)
+// UpdateCustomerProfile меняет переданные поля профиля клиента, если его версия все еще version,
+// и возвращает профиль после изменения.
+func (s *CustomerUsecase) UpdateCustomerProfile(id string, version int64, patch CustomerPatch) (*domain.Customer, error) {
+	customer, err := s.customerRepo.GetByID(id)
 	if err != nil {
-		s.logger.WithError(err).Warn("Invalid refresh token for customer")
-		return "", errors.New("invalid refresh token")
+		return nil, ErrProfileNotFound
 	}
-
-	if time.Now().After(token.ExpiresAt) {
-		s.logger.Warn("Refresh token expired for customer")
-		return "", errors.New("refresh token expired")
+	if customer.Version != version {
+		return nil, ErrProfileVersionConflict
 	}
 
-	customer, err := s.customerRepo.GetByID(token.UserID)
-	if err != nil {
-		s.logger.WithError(err).Error("Customer not found for refresh token")
-		return "", errors.New("customer not found")
+	edit := newProfileEdit(id, domain.RoleCustomer, version)
+	setField(edit, "company_name", &customer.CompanyName, patch.CompanyName)
+	setField(edit, "name", &customer.Name, patch.Name)
+	setField(edit, "job_position", &customer.JobPosition, patch.JobPosition)
+	setField(edit, "phone_number", &customer.PhoneNumber, patch.PhoneNumber)
+	setField(edit, "address", &customer.Address, patch.Address)
+	setField(edit, "work_description", &customer.WorkDescription, patch.WorkDescription)
+	if edit.empty() {
+		return customer, nil
 	}
 
-	accessToken, err := utils.GenerateToken(customer.ID, s.jwtSecret)
-	if err != nil {
-		s.logger.WithError(err).Error("Failed to generate new access token for customer")
-		return "", err
+	if err := s.customerRepo.Update(edit.update()); err != nil {
+		s.logger.WithError(err).Warn("Failed to update customer profile")
+		return nil, profileUpdateError(err)
 	}
-
-	s.logger.Info("Customer token refreshed successfully")
-	return accessToken, nil
+	return s.GetCustomerByID(id)
 }
 
-func (s *CustomerUsecase) GetCustomerByID(id string) (*domain.Customer, error) {
-	customer, err := s.customerRepo.GetByID(id)
+// ListCustomerProfileChanges возвращает историю изменений профиля клиента, новые первыми.
+func (s *CustomerUsecase) ListCustomerProfileChanges(id string, limit, offset int) ([]domain.ProfileChange, error) {
+	changes, err := s.customerRepo.ListChanges(id, pageLimit(limit), offset)
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get customer by ID")
+		s.logger.WithError(err).Error("Failed to list customer profile changes")
 		return nil, err
 	}
-	return customer, nil
+	return changes, nil
 }
@@ -0,0 +1,189 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"BruhPro/internal/domain"
+	"BruhPro/internal/mailer"
+	"BruhPro/internal/repository"
+	"BruhPro/internal/utils"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	emailChangeTTL       = 24 * time.Hour
+	emailChangeRevertTTL = 7 * 24 * time.Hour
+)
+
+// ErrEmailNotChanged возвращается, если новый email совпадает с текущим.
+var ErrEmailNotChanged = errors.New("new email must differ from the current one")
+
+// EmailChangeUsecase меняет email аккаунта: новый адрес сначала подтверждается ссылкой,
+// а старый получает уведомление со ссылкой отмены на случай, если аккаунт угнали.
+type EmailChangeUsecase struct {
+	accountRepo repository.AccountRepository
+	changeRepo  repository.EmailChangeRepository
+	auth        *AuthUsecase
+	mailer      mailer.Mailer
+	baseURL     string
+	logger      *logrus.Logger
+}
+
+func NewEmailChangeUsecase(accountRepo repository.AccountRepository, changeRepo repository.EmailChangeRepository, auth *AuthUsecase, m mailer.Mailer, baseURL string, logger *logrus.Logger) *EmailChangeUsecase {
+	return &EmailChangeUsecase{accountRepo, changeRepo, auth, m, baseURL, logger}
+}
+
+// RequestChange проверяет текущий пароль и отправляет на новый адрес ссылку подтверждения.
+// До подтверждения email аккаунта не меняется.
+func (s *EmailChangeUsecase) RequestChange(accountID, role, password, newEmail string) error {
+	account, err := s.accountRepo.GetByID(accountID)
+	if err != nil {
+		s.logger.WithError(err).Warn("Account not found")
+		return errors.New("account not found")
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(account.PasswordHash), []byte(password)); err != nil {
+		s.logger.WithField("account_id", accountID).Warn("Wrong current password on email change")
+		return ErrWrongCurrentPassword
+	}
+	if newEmail == account.Email {
+		return ErrEmailNotChanged
+	}
+	if _, err := s.accountRepo.GetByEmail(newEmail); err == nil {
+		s.logger.Warn("Email change requested to an address in use")
+		return repository.ErrEmailInUse
+	}
+
+	token, err := utils.GenerateSecureToken()
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to generate email change token")
+		return err
+	}
+
+	change := &domain.EmailChange{
+		AccountID:        accountID,
+		Role:             role,
+		OldEmail:         account.Email,
+		NewEmail:         newEmail,
+		ConfirmTokenHash: utils.HashToken(token),
+		ExpiresAt:        time.Now().Add(emailChangeTTL),
+	}
+	if err := s.changeRepo.Create(change); err != nil {
+		s.logger.WithError(err).Error("Failed to save email change")
+		return err
+	}
+
+	link := fmt.Sprintf("%s/%s/email/change/confirm?token=%s", s.baseURL, role, token)
+	msg := mailer.Message{
+		To:      newEmail,
+		Subject: "Подтверждение нового email в BuhPro",
+		Body: fmt.Sprintf("Здравствуйте!\n\nЧтобы указать этот адрес для входа в BuhPro, перейдите по ссылке:\n%s\n\n"+
+			"Ссылка действует %d ч. Если вы не меняли email, просто проигнорируйте это письмо.\n", link, int(emailChangeTTL.Hours())),
+	}
+	if err := s.mailer.Send(msg); err != nil {
+		s.logger.WithError(err).Error("Failed to send email change confirmation")
+		return err
+	}
+
+	s.logger.WithField("account_id", accountID).Info("Email change requested")
+	return nil
+}
+
+// ConfirmChange меняет email по ссылке из письма на новый адрес
+// и отправляет на старый адрес уведомление со ссылкой отмены.
+func (s *EmailChangeUsecase) ConfirmChange(token string) error {
+	change, err := s.changeRepo.GetByConfirmTokenHash(utils.HashToken(token))
+	if err != nil {
+		s.logger.Warn("Invalid email change token")
+		return repository.ErrEmailChangeInvalid
+	}
+
+	if change.ConfirmedAt != nil || change.CanceledAt != nil || time.Now().After(change.ExpiresAt) {
+		s.logger.Warn("Email change token used or expired")
+		return repository.ErrEmailChangeInvalid
+	}
+
+	revertToken, err := utils.GenerateSecureToken()
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to generate email change revert token")
+		return err
+	}
+
+	if err := s.changeRepo.Confirm(change, utils.HashToken(revertToken), time.Now().Add(emailChangeRevertTTL)); err != nil {
+		s.logger.WithError(err).Warn("Failed to confirm email change")
+		return err
+	}
+
+	link := fmt.Sprintf("%s/%s/email/change/revert?token=%s", s.baseURL, change.Role, revertToken)
+	msg := mailer.Message{
+		To:      change.OldEmail,
+		Subject: "Email аккаунта BuhPro изменен",
+		Body: fmt.Sprintf("Здравствуйте!\n\nАдрес для входа в BuhPro изменен на %s.\n\n"+
+			"Если это были не вы, верните прежний адрес по ссылке — все сеансы аккаунта будут завершены, "+
+			"а пароль придется задать заново:\n%s\n\n"+
+			"Ссылка действует %d дн.\n", change.NewEmail, link, int(emailChangeRevertTTL.Hours()/24)),
+	}
+	if err := s.mailer.Send(msg); err != nil {
+		s.logger.WithError(err).Error("Failed to send email change notice")
+	}
+
+	s.logger.WithField("account_id", change.AccountID).Info("Email changed successfully")
+	return nil
+}
+
+// RevertChange возвращает прежний email по ссылке из уведомления и завершает все сессии аккаунта:
+// тот, кто сменил адрес, не должен сохранить доступ. Пароль он мог знать, поэтому пароль
+// сбрасывается, а на прежний адрес уходит ссылка, чтобы задать новый.
+func (s *EmailChangeUsecase) RevertChange(token string) error {
+	change, err := s.changeRepo.GetByRevertTokenHash(utils.HashToken(token))
+	if err != nil {
+		s.logger.Warn("Invalid email change revert token")
+		return repository.ErrEmailChangeInvalid
+	}
+
+	if change.RevertedAt != nil || change.RevertExpiresAt == nil || time.Now().After(*change.RevertExpiresAt) {
+		s.logger.Warn("Email change revert token used or expired")
+		return repository.ErrEmailChangeInvalid
+	}
+
+	resetToken, err := utils.GenerateSecureToken()
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to generate reset token")
+		return err
+	}
+
+	reset := &domain.PasswordResetToken{
+		AccountID: change.AccountID,
+		Role:      change.Role,
+		TokenHash: utils.HashToken(resetToken),
+		ExpiresAt: time.Now().Add(passwordResetTTL),
+	}
+	if err := s.changeRepo.Revert(change, reset); err != nil {
+		s.logger.WithError(err).Warn("Failed to revert email change")
+		return err
+	}
+
+	if err := s.auth.RevokeAllSessions(change.AccountID, ""); err != nil {
+		return err
+	}
+
+	link := fmt.Sprintf("%s/%s/password/reset?token=%s", s.baseURL, change.Role, resetToken)
+	msg := mailer.Message{
+		To:      change.OldEmail,
+		Subject: "Задайте новый пароль BuhPro",
+		Body: fmt.Sprintf("Здравствуйте!\n\nАдрес для входа в BuhPro снова %s, все сеансы аккаунта завершены.\n"+
+			"Прежний пароль больше не действует: задайте новый по ссылке:\n%s\n\n"+
+			"Ссылка действует %d мин. Если вы подключали двухфакторную аутентификацию после смены адреса, "+
+			"подключите ее заново.\n", change.OldEmail, link, int(passwordResetTTL.Minutes())),
+	}
+	if err := s.mailer.Send(msg); err != nil {
+		s.logger.WithError(err).Error("Failed to send password reset email after email change revert")
+	}
+
+	s.logger.WithField("account_id", change.AccountID).Info("Email change reverted")
+	return nil
+}
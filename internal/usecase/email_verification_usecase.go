@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"BruhPro/internal/domain"
+	"BruhPro/internal/mailer"
+	"BruhPro/internal/repository"
+	"BruhPro/internal/utils"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrEmailNotVerified возвращается действиями, недоступными до подтверждения email.
+var ErrEmailNotVerified = errors.New("email is not verified")
+
+const (
+	emailVerificationTTL            = 24 * time.Hour
+	emailVerificationResendCooldown = 1 * time.Minute
+)
+
+// EmailVerificationUsecase отправляет и проверяет ссылки подтверждения email.
+type EmailVerificationUsecase struct {
+	accountRepo      repository.AccountRepository
+	verificationRepo repository.EmailVerificationRepository
+	mailer           mailer.Mailer
+	baseURL          string
+	logger           *logrus.Logger
+}
+
+func NewEmailVerificationUsecase(accountRepo repository.AccountRepository, verificationRepo repository.EmailVerificationRepository, m mailer.Mailer, baseURL string, logger *logrus.Logger) *EmailVerificationUsecase {
+	return &EmailVerificationUsecase{accountRepo, verificationRepo, m, baseURL, logger}
+}
+
+// SendVerification выдает новый токен и отправляет письмо со ссылкой подтверждения.
+func (s *EmailVerificationUsecase) SendVerification(account *domain.Account, role string) error {
+	token, err := utils.GenerateSecureToken()
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to generate verification token")
+		return err
+	}
+
+	verificationToken := &domain.EmailVerificationToken{
+		AccountID: account.ID,
+		Email:     account.Email,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: time.Now().Add(emailVerificationTTL),
+	}
+	if err := s.verificationRepo.Create(verificationToken); err != nil {
+		s.logger.WithError(err).Error("Failed to save verification token")
+		return err
+	}
+
+	link := fmt.Sprintf("%s/%s/email/verify?token=%s", s.baseURL, role, token)
+	msg := mailer.Message{
+		To:      account.Email,
+		Subject: "Подтверждение email в BuhPro",
+		Body: fmt.Sprintf("Здравствуйте!\n\nПодтвердите адрес электронной почты, перейдя по ссылке:\n%s\n\n"+
+			"Ссылка действует %d ч.\n", link, int(emailVerificationTTL.Hours())),
+	}
+	if err := s.mailer.Send(msg); err != nil {
+		s.logger.WithError(err).Error("Failed to send verification email")
+		return err
+	}
+
+	s.logger.WithField("account_id", account.ID).Info("Verification email sent")
+	return nil
+}
+
+// VerifyEmail подтверждает email по токену из письма.
+func (s *EmailVerificationUsecase) VerifyEmail(token string) error {
+	s.logger.Info("Attempting to verify email")
+
+	verificationToken, err := s.verificationRepo.GetByTokenHash(utils.HashToken(token))
+	if err != nil {
+		s.logger.Warn("Invalid verification token")
+		return repository.ErrVerificationTokenInvalid
+	}
+
+	if verificationToken.UsedAt != nil || time.Now().After(verificationToken.ExpiresAt) {
+		s.logger.Warn("Verification token used or expired")
+		return repository.ErrVerificationTokenInvalid
+	}
+
+	if err := s.verificationRepo.Consume(verificationToken); err != nil {
+		s.logger.WithError(err).Warn("Failed to consume verification token")
+		return err
+	}
+
+	s.logger.WithField("account_id", verificationToken.AccountID).Info("Email verified successfully")
+	return nil
+}
+
+// ResendVerification повторно отправляет письмо, но не чаще раза в emailVerificationResendCooldown.
+func (s *EmailVerificationUsecase) ResendVerification(accountID, role string) error {
+	account, err := s.accountRepo.GetByID(accountID)
+	if err != nil {
+		s.logger.WithError(err).Warn("Account not found")
+		return errors.New("account not found")
+	}
+
+	if account.EmailVerifiedAt != nil {
+		return errors.New("email is already verified")
+	}
+
+	if latest, err := s.verificationRepo.GetLatest(accountID); err == nil &&
+		time.Since(latest.CreatedAt) < emailVerificationResendCooldown {
+		s.logger.Warn("Verification resend requested too often")
+		return errors.New("verification email was sent recently, please try again later")
+	}
+
+	return s.SendVerification(account, role)
+}
+
+// RequireVerifiedEmail возвращает ErrEmailNotVerified, если email аккаунта не подтвержден.
+func (s *EmailVerificationUsecase) RequireVerifiedEmail(accountID string) error {
+	account, err := s.accountRepo.GetByID(accountID)
+	if err != nil {
+		s.logger.WithError(err).Warn("Account not found")
+		return errors.New("account not found")
+	}
+
+	if account.EmailVerifiedAt == nil {
+		return ErrEmailNotVerified
+	}
+	return nil
+}
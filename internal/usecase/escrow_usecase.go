@@ -0,0 +1,397 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"BruhPro/internal/domain"
+	"BruhPro/internal/ledger"
+	"BruhPro/internal/repository"
+
+	"github.com/sirupsen/logrus"
+)
+
+// SystemActor — автор действий, которые платформа выполняет сама, например автоприемки этапа.
+const SystemActor = "system"
+
+// releaseBatchSize — сколько просроченных этапов принимается за один проход фоновой задачи.
+const releaseBatchSize = 100
+
+var (
+	ErrMilestoneNotFound         = errors.New("milestone not found")
+	ErrInvalidMilestoneOperation = errors.New("operation is not allowed in the current milestone status")
+)
+
+// EscrowUsecase ведет этапы заказа: клиент оплачивает этап на эскроу платформы,
+// исполнитель сдает работу, клиент принимает ее — или этап принимается автоматически,
+// если клиент не ответил за autoReleaseAfter. При выплате удерживается комиссия платформы.
+type EscrowUsecase struct {
+	milestoneRepo    repository.MilestoneRepository
+	orderRepo        repository.OrderRepository
+	payments         *PaymentUsecase
+	ledger           *ledger.Ledger
+	commissionBps    int64 // комиссия в базисных пунктах: 1000 = 10%
+	autoReleaseAfter time.Duration
+	now              func() time.Time
+	logger           *logrus.Logger
+}
+
+func NewEscrowUsecase(
+	milestoneRepo repository.MilestoneRepository,
+	orderRepo repository.OrderRepository,
+	payments *PaymentUsecase,
+	journal *ledger.Ledger,
+	commissionBps int64,
+	autoReleaseAfter time.Duration,
+	logger *logrus.Logger,
+) *EscrowUsecase {
+	return &EscrowUsecase{
+		milestoneRepo:    milestoneRepo,
+		orderRepo:        orderRepo,
+		payments:         payments,
+		ledger:           journal,
+		commissionBps:    commissionBps,
+		autoReleaseAfter: autoReleaseAfter,
+		now:              time.Now,
+		logger:           logger,
+	}
+}
+
+// WithClock подменяет источник времени (для тестов).
+func (s *EscrowUsecase) WithClock(now func() time.Time) *EscrowUsecase {
+	s.now = now
+	return s
+}
+
+// AddMilestone добавляет этап в заказ, который находится в работе.
+func (s *EscrowUsecase) AddMilestone(customerID, orderID string, milestone *domain.Milestone) error {
+	order, err := s.customerOrder(customerID, orderID)
+	if err != nil {
+		return err
+	}
+	if order.Status != domain.OrderStatusInProgress {
+		return errors.New("milestones can be added only to an order in progress")
+	}
+
+	milestone.ID = ""
+	milestone.OrderID = orderID
+	milestone.Status = domain.MilestoneStatusPending
+	milestone.PaymentID = nil
+
+	if err := s.milestoneRepo.Create(milestone); err != nil {
+		s.logger.WithError(err).Error("Failed to create milestone")
+		return err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"order_id":     orderID,
+		"milestone_id": milestone.ID,
+	}).Info("Milestone created successfully")
+	return nil
+}
+
+// ListMilestones возвращает этапы заказа его участникам.
+func (s *EscrowUsecase) ListMilestones(viewerID, viewerRole, orderID string) ([]domain.Milestone, error) {
+	order, err := s.orderRepo.GetByID(orderID)
+	if err != nil || !canViewOrder(order, viewerID, viewerRole) {
+		return nil, ErrOrderNotFound
+	}
+
+	milestones, err := s.milestoneRepo.ListByOrder(orderID)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to list milestones")
+		return nil, err
+	}
+	return milestones, nil
+}
+
+// Fund начинает оплату этапа. Этап переходит в funded, когда платеж списан (см. HandlePayment).
+func (s *EscrowUsecase) Fund(customerID, orderID, milestoneID string) (*domain.Payment, error) {
+	order, err := s.customerOrder(customerID, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != domain.OrderStatusInProgress {
+		return nil, errors.New("milestones can be funded only while the order is in progress")
+	}
+
+	milestone, err := s.orderMilestone(orderID, milestoneID)
+	if err != nil {
+		return nil, err
+	}
+	if milestone.Status != domain.MilestoneStatusPending {
+		return nil, ErrAlreadyPaid
+	}
+
+	return s.payments.PayMilestone(customerID, order, milestone)
+}
+
+// HandlePayment — хук PaymentUsecase: зачисляет списанный платеж на эскроу этапа
+// и отмечает возврат. Деньги за этап, отмененный до оплаты, сразу возвращаются.
+func (s *EscrowUsecase) HandlePayment(payment *domain.Payment) error {
+	if payment.Purpose != domain.PaymentPurposeOrder || payment.MilestoneID == nil {
+		return nil
+	}
+
+	milestone, err := s.milestoneRepo.GetByID(*payment.MilestoneID)
+	if err != nil {
+		return ErrMilestoneNotFound
+	}
+
+	switch payment.Status {
+	case domain.PaymentStatusCaptured:
+		if milestone.Status == domain.MilestoneStatusCancelled {
+			s.logger.WithField("milestone_id", milestone.ID).Warn("Payment captured for cancelled milestone, refunding")
+			_, err := s.payments.Refund(payment.ID, 0)
+			return err
+		}
+
+		milestone.Status = domain.MilestoneStatusFunded
+		milestone.PaymentID = &payment.ID
+		if err := s.milestoneRepo.UpdateStatus(milestone, domain.MilestoneStatusPending); err != nil {
+			return err
+		}
+		s.logger.WithField("milestone_id", milestone.ID).Info("Milestone funded into escrow")
+
+	case domain.PaymentStatusRefunded:
+		milestone.Status = domain.MilestoneStatusRefunded
+		milestone.AutoReleaseAt = nil
+		err := s.milestoneRepo.UpdateStatus(milestone, domain.MilestoneStatusFunded, domain.MilestoneStatusSubmitted)
+		if err != nil && !errors.Is(err, repository.ErrMilestoneStatusChanged) {
+			return err
+		}
+		s.logger.WithField("milestone_id", milestone.ID).Info("Milestone refunded")
+	}
+	return nil
+}
+
+// Submit отмечает этап сданным исполнителем и запускает срок автоприемки.
+func (s *EscrowUsecase) Submit(executorID, orderID, milestoneID string) (*domain.Milestone, error) {
+	order, err := s.orderRepo.GetByID(orderID)
+	if err != nil || order.ExecutorID == nil || *order.ExecutorID != executorID {
+		return nil, ErrOrderNotFound
+	}
+	if order.Status != domain.OrderStatusInProgress && order.Status != domain.OrderStatusOnReview {
+		return nil, ErrInvalidMilestoneOperation
+	}
+
+	milestone, err := s.orderMilestone(orderID, milestoneID)
+	if err != nil {
+		return nil, err
+	}
+	if milestone.Status != domain.MilestoneStatusFunded {
+		return nil, ErrInvalidMilestoneOperation
+	}
+
+	now := s.now()
+	autoRelease := now.Add(s.autoReleaseAfter)
+	milestone.Status = domain.MilestoneStatusSubmitted
+	milestone.SubmittedAt = &now
+	milestone.AutoReleaseAt = &autoRelease
+
+	if err := s.milestoneRepo.UpdateStatus(milestone, domain.MilestoneStatusFunded); err != nil {
+		s.logger.WithError(err).Error("Failed to submit milestone")
+		return nil, err
+	}
+
+	s.logger.WithField("milestone_id", milestoneID).Info("Milestone submitted")
+	return milestone, nil
+}
+
+// Approve принимает этап и выплачивает его исполнителю. Принять можно и до сдачи.
+func (s *EscrowUsecase) Approve(customerID, orderID, milestoneID string) (*domain.Milestone, error) {
+	order, err := s.customerOrder(customerID, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	milestone, err := s.orderMilestone(orderID, milestoneID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.release(order, milestone, customerID); err != nil {
+		return nil, err
+	}
+	return milestone, nil
+}
+
+// RequestChanges возвращает сданный этап исполнителю на доработку и останавливает срок автоприемки.
+func (s *EscrowUsecase) RequestChanges(customerID, orderID, milestoneID string) (*domain.Milestone, error) {
+	if _, err := s.customerOrder(customerID, orderID); err != nil {
+		return nil, err
+	}
+
+	milestone, err := s.orderMilestone(orderID, milestoneID)
+	if err != nil {
+		return nil, err
+	}
+	if milestone.Status != domain.MilestoneStatusSubmitted {
+		return nil, ErrInvalidMilestoneOperation
+	}
+
+	milestone.Status = domain.MilestoneStatusFunded
+	milestone.SubmittedAt = nil
+	milestone.AutoReleaseAt = nil
+	if err := s.milestoneRepo.UpdateStatus(milestone, domain.MilestoneStatusSubmitted); err != nil {
+		s.logger.WithError(err).Error("Failed to return milestone for changes")
+		return nil, err
+	}
+
+	s.logger.WithField("milestone_id", milestoneID).Info("Milestone returned for changes")
+	return milestone, nil
+}
+
+// release выплачивает этап исполнителю за вычетом комиссии платформы и проводит выплату по журналу.
+func (s *EscrowUsecase) release(order *domain.Order, milestone *domain.Milestone, actorID string) error {
+	if milestone.Status != domain.MilestoneStatusFunded && milestone.Status != domain.MilestoneStatusSubmitted {
+		return ErrInvalidMilestoneOperation
+	}
+	if order.ExecutorID == nil {
+		return errors.New("order has no assigned executor")
+	}
+
+	now := s.now()
+	milestone.Commission = (milestone.Amount*s.commissionBps + 5000) / 10000
+	milestone.Payout = milestone.Amount - milestone.Commission
+	milestone.Status = domain.MilestoneStatusReleased
+	milestone.AutoReleaseAt = nil
+	milestone.ReleasedAt = &now
+	milestone.ReleasedBy = actorID
+
+	if err := s.milestoneRepo.UpdateStatus(milestone, domain.MilestoneStatusFunded, domain.MilestoneStatusSubmitted); err != nil {
+		s.logger.WithError(err).Error("Failed to release milestone")
+		return err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"milestone_id": milestone.ID,
+		"actor_id":     actorID,
+		"payout":       milestone.Payout,
+		"commission":   milestone.Commission,
+	}).Info("Milestone released to executor")
+
+	// Если журнал недоступен, проводку повторит PostPendingReleases
+	_ = s.postRelease(order, milestone)
+	return nil
+}
+
+// postRelease проводит выплату этапа в журнале. Повторное проведение безопасно.
+func (s *EscrowUsecase) postRelease(order *domain.Order, milestone *domain.Milestone) error {
+	if err := s.ledger.MilestoneReleased(milestone.ID, order.ID, *order.ExecutorID, milestone.Payout, milestone.Commission); err != nil {
+		s.logger.WithError(err).WithField("milestone_id", milestone.ID).Error("Failed to post milestone release to ledger")
+		return err
+	}
+	return nil
+}
+
+// PostPendingReleases проводит в журнале выплаты этапов, которые не удалось провести
+// сразу после выплаты: иначе эскроу заказа так и числило бы выплаченные деньги.
+func (s *EscrowUsecase) PostPendingReleases() (int, error) {
+	milestones, err := s.milestoneRepo.ListReleasedUnposted(releaseBatchSize)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to list milestones without ledger posting")
+		return 0, err
+	}
+
+	posted := 0
+	for i := range milestones {
+		order, err := s.orderRepo.GetByID(milestones[i].OrderID)
+		if err != nil || order.ExecutorID == nil {
+			s.logger.WithField("milestone_id", milestones[i].ID).Error("Cannot post milestone release: order has no executor")
+			continue
+		}
+		if err := s.postRelease(order, &milestones[i]); err == nil {
+			posted++
+		}
+	}
+	return posted, nil
+}
+
+// ReleaseDue принимает сданные этапы, по которым клиент не ответил в срок.
+// Этапы заказов в споре не принимаются автоматически: репозиторий их не выбирает,
+// а статус заказа перепроверяется на случай спора, открытого после выборки.
+func (s *EscrowUsecase) ReleaseDue() (int, error) {
+	milestones, err := s.milestoneRepo.ListDueForRelease(s.now(), releaseBatchSize)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to list milestones due for release")
+		return 0, err
+	}
+
+	released := 0
+	for i := range milestones {
+		order, err := s.orderRepo.GetByID(milestones[i].OrderID)
+		if err != nil || order.Status == domain.OrderStatusDisputed {
+			continue
+		}
+		if err := s.release(order, &milestones[i], SystemActor); err == nil {
+			released++
+		}
+	}
+	return released, nil
+}
+
+// StartAutoRelease запускает в фоне проверку просроченных этапов и досылку проводок
+// по выплатам с интервалом interval.
+func (s *EscrowUsecase) StartAutoRelease(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			_, _ = s.ReleaseDue()
+			_, _ = s.PostPendingReleases()
+		}
+	}()
+}
+
+// HandleOrderTransition — хук OrderUsecase: при завершении заказа выплачивает
+// оплаченные этапы, при отмене возвращает клиенту деньги с эскроу и отменяет неоплаченные.
+func (s *EscrowUsecase) HandleOrderTransition(order *domain.Order, change *domain.OrderStatusChange) error {
+	if change.ToStatus != domain.OrderStatusCompleted && change.ToStatus != domain.OrderStatusCancelled {
+		return nil
+	}
+
+	milestones, err := s.milestoneRepo.ListByOrder(order.ID)
+	if err != nil {
+		return err
+	}
+
+	var failed error
+	for i := range milestones {
+		milestone := &milestones[i]
+		err = nil
+		switch {
+		case milestone.Status == domain.MilestoneStatusPending:
+			milestone.Status = domain.MilestoneStatusCancelled
+			err = s.milestoneRepo.UpdateStatus(milestone, domain.MilestoneStatusPending)
+		case milestone.Status != domain.MilestoneStatusFunded && milestone.Status != domain.MilestoneStatusSubmitted:
+			continue
+		case change.ToStatus == domain.OrderStatusCompleted:
+			err = s.release(order, milestone, change.ActorID)
+		case milestone.PaymentID != nil:
+			err = s.payments.Cancel(*milestone.PaymentID, "order cancelled")
+		}
+		if err != nil {
+			s.logger.WithError(err).WithField("milestone_id", milestone.ID).Error("Failed to settle milestone")
+			failed = err
+		}
+	}
+	return failed
+}
+
+// customerOrder загружает заказ и проверяет, что он принадлежит клиенту.
+func (s *EscrowUsecase) customerOrder(customerID, orderID string) (*domain.Order, error) {
+	order, err := s.orderRepo.GetByID(orderID)
+	if err != nil || order.CustomerID != customerID {
+		s.logger.Warn("Order not found")
+		return nil, ErrOrderNotFound
+	}
+	return order, nil
+}
+
+func (s *EscrowUsecase) orderMilestone(orderID, milestoneID string) (*domain.Milestone, error) {
+	milestone, err := s.milestoneRepo.GetByID(milestoneID)
+	if err != nil || milestone.OrderID != orderID {
+		return nil, ErrMilestoneNotFound
+	}
+	return milestone, nil
+}
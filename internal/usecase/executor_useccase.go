@@ -1,143 +1,138 @@
 package usecase
 
 import (
-	"errors"
-	"time"
+	"BruhPro/internal/domain"
+	"BruhPro/internal/repository"
 
-	"BuhPro+/internal/domain"
-	"BuhPro+/internal/repository"
-	"BuhPro+/internal/utils" // Обновлен импорт
-
-	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
-	"golang.org/x/crypto/bcrypt"
 )
 
 type ExecutorUsecase struct {
-	executorRepo repository.ExecutorRepository
-	jwtSecret    string
-	logger       *logrus.Logger
+	executorRepo    repository.ExecutorRepository
+	auth            *AuthUsecase
+	specializations *SpecializationUsecase
+	logger          *logrus.Logger
 }
 
-func NewExecutorUsecase(repo repository.ExecutorRepository, secret string, logger *logrus.Logger) *ExecutorUsecase {
-	return &ExecutorUsecase{repo, secret, logger}
+func NewExecutorUsecase(repo repository.ExecutorRepository, auth *AuthUsecase, specializations *SpecializationUsecase, logger *logrus.Logger) *ExecutorUsecase {
+	return &ExecutorUsecase{repo, auth, specializations, logger}
 }
 
-// internal/usecase/executor_usecase.go
-// ... (остальная часть файла)
-
-func (s *ExecutorUsecase) RegisterExecutor(executor *domain.Executor) error { // Изменена сигнатура
-	s.logger.WithFields(logrus.Fields{
-		"email": executor.Email,
-	}).Info("Attempting to register executor")
-
-	_, err := s.executorRepo.GetByEmail(executor.Email)
-	if err == nil {
-		s.logger.Warn("Executor already exists")
-		return errors.New("executor with this email already exists")
-	}
-
-	if !utils.IsPasswordComplex(executor.PasswordHash) {
-		s.logger.Warn("Password does not meet complexity requirements")
-		return errors.New("password must contain at least one uppercase letter, one lowercase letter, one number, and one special character")
-	}
-
-	hashed, err := bcrypt.GenerateFromPassword([]byte(executor.PasswordHash), bcrypt.DefaultCost)
-	if err != nil {
-		s.logger.WithError(err).Error("Failed to hash password")
-		return err
-	}
-	executor.PasswordHash = string(hashed) // Хешируем пароль
-
-	if err := s.executorRepo.Create(executor); err != nil {
-		s.logger.WithError(err).Error("Failed to create executor")
+// RegisterExecutor создает аккаунт с профилем исполнителя или добавляет профиль к уже существующему аккаунту.
+func (s *ExecutorUsecase) RegisterExecutor(email, password string, executor *domain.Executor) error {
+	if err := s.specializations.Validate(domain.RoleExecutor, specializationCodes(executor.Specializations)...); err != nil {
 		return err
 	}
+	return s.auth.registerWithRole(email, password, domain.RoleExecutor, executor, func(id string) { executor.ID = id })
+}
 
-	s.logger.Info("Executor registered successfully")
-	return nil
+func (s *ExecutorUsecase) LoginExecutor(email, password string, client ClientInfo) (*TokenPair, error) {
+	return s.auth.Login(email, password, domain.RoleExecutor, client)
 }
 
-// ... (остальная часть файла)
-func (s *ExecutorUsecase) LoginExecutor(email, password string) (string, string, error) {
-	s.logger.WithFields(logrus.Fields{
-		"email": email,
-	}).Info("Attempting to login executor")
+func (s *ExecutorUsecase) RefreshExecutorToken(refreshToken string, client ClientInfo) (*TokenPair, error) {
+	return s.auth.RefreshToken(refreshToken, domain.RoleExecutor, client)
+}
 
-	executor, err := s.executorRepo.GetByEmail(email)
+func (s *ExecutorUsecase) GetExecutorByID(id string) (*domain.Executor, error) {
+	executor, err := s.executorRepo.GetByID(id)
 	if err != nil {
-		s.logger.Warn("Invalid email or password for executor login")
-		return "", "", errors.New("invalid email or password")
+		s.logger.WithError(err).Error("Failed to get executor by ID")
+		return nil, err
 	}
+	return executor, nil
+}
 
-	if err := bcrypt.CompareHashAndPassword([]byte(executor.PasswordHash), []byte(password)); err != nil {
-		s.logger.Warn("Invalid email or password for executor login")
-		return "", "", errors.New("invalid email or password")
+// SearchExecutors возвращает страницу публичного поиска исполнителей и курсор следующей страницы.
+// Пустой курсор в ответе означает, что страниц больше нет.
+func (s *ExecutorUsecase) SearchExecutors(filter repository.ExecutorFilter, cursor string) ([]domain.Executor, string, error) {
+	if filter.MaxRate > 0 && filter.MinRate > filter.MaxRate {
+		return nil, "", ErrInvalidSearchFilter
 	}
-
-	accessToken, err := utils.GenerateToken(executor.ID, s.jwtSecret)
+	filter.Sort = searchSort(filter.Sort, filter.Query)
+	after, err := decodeSearchCursor(cursor, filter.Sort)
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to generate access token for executor")
-		return "", "", err
+		return nil, "", err
 	}
+	filter.After = after
+	filter.VerifiedOnly = true // в публичный каталог попадают только профили с подтвержденным email
+	limit := pageLimit(filter.Limit)
+	filter.Limit = limit + 1 // лишняя запись показывает, есть ли следующая страница
 
-	refreshToken, err := utils.GenerateRefreshToken()
+	executors, err := s.executorRepo.Search(filter)
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to generate refresh token for executor")
-		return "", "", err
+		s.logger.WithError(err).Error("Failed to search executors")
+		return nil, "", err
 	}
-
-	refreshTokenModel := &domain.RefreshToken{
-		ID:        uuid.New().String(),
-		UserID:    executor.ID,
-		Token:     refreshToken,
-		ExpiresAt: time.Now().Add(7 * 24 * time.Hour),
+	if len(executors) <= limit {
+		return executors, "", nil
 	}
 
-	if err := s.executorRepo.CreateRefreshToken(refreshTokenModel); err != nil {
-		s.logger.WithError(err).Error("Failed to save refresh token for executor")
-		return "", "", err
+	executors = executors[:limit]
+	last := &executors[limit-1]
+	next := repository.SearchCursor{Sort: filter.Sort, ID: last.ID, CreatedAt: last.CreatedAt}
+	switch filter.Sort {
+	case repository.SortRelevance:
+		next.Value = last.SearchRank
+	case repository.SortRating:
+		next.Value = last.Rating.Average
+	case repository.SortPriceAsc, repository.SortPriceDesc:
+		next.Value = last.HourlyRate
 	}
-
-	s.logger.Info("Executor logged in successfully")
-	return accessToken, refreshToken, nil
+	return executors, encodeSearchCursor(next), nil
 }
 
-func (s *ExecutorUsecase) RefreshExecutorToken(refreshToken string) (string, error) {
-	s.logger.Info("Attempting to refresh executor token")
-
-	token, err := s.executorRepo.GetRefreshToken(refreshToken)
-	if err != nil {
-		s.logger.WithError(err).Warn("Invalid refresh token for executor")
-		return "", errors.New("invalid refresh token")
+// UpdateExecutorProfile меняет переданные поля профиля исполнителя, если его версия все еще version,
+// и возвращает профиль после изменения.
+func (s *ExecutorUsecase) UpdateExecutorProfile(id string, version int64, patch ExecutorPatch) (*domain.Executor, error) {
+	if patch.WorkFormat != nil && !domain.Contains(domain.WorkFormats, *patch.WorkFormat) {
+		return nil, ErrUnknownWorkFormat
 	}
-
-	if time.Now().After(token.ExpiresAt) {
-		s.logger.Warn("Refresh token expired for executor")
-		return "", errors.New("refresh token expired")
+	if patch.HourlyRate != nil && *patch.HourlyRate <= 0 {
+		return nil, ErrInvalidHourlyRate
 	}
 
-	executor, err := s.executorRepo.GetByID(token.UserID)
+	executor, err := s.executorRepo.GetByID(id)
 	if err != nil {
-		s.logger.WithError(err).Error("Executor not found for refresh token")
-		return "", errors.New("executor not found")
+		return nil, ErrProfileNotFound
+	}
+	if executor.Version != version {
+		return nil, ErrProfileVersionConflict
 	}
 
-	accessToken, err := utils.GenerateToken(executor.ID, s.jwtSecret)
-	if err != nil {
-		s.logger.WithError(err).Error("Failed to generate new access token for executor")
-		return "", err
+	edit := newProfileEdit(id, domain.RoleExecutor, version)
+	setField(edit, "name", &executor.Name, patch.Name)
+	setField(edit, "surname", &executor.Surname, patch.Surname)
+	setField(edit, "patronymic", &executor.Patronymic, patch.Patronymic)
+	setField(edit, "phone_number", &executor.PhoneNumber, patch.PhoneNumber)
+	setField(edit, "city", &executor.City, patch.City)
+	setField(edit, "exp_work", &executor.ExpWork, patch.ExpWork)
+	setField(edit, "education", &executor.Education, patch.Education)
+	setField(edit, "work_format", &executor.WorkFormat, patch.WorkFormat)
+	setField(edit, "hourly_rate", &executor.HourlyRate, patch.HourlyRate)
+	setField(edit, "about_executor", &executor.AboutExecutor, patch.AboutExecutor)
+	if added := edit.setSpecializations(executor.Specializations, patch.Specializations); len(added) > 0 {
+		if err := s.specializations.Validate(domain.RoleExecutor, added...); err != nil {
+			return nil, err
+		}
+	}
+	if edit.empty() {
+		return executor, nil
 	}
 
-	s.logger.Info("Executor token refreshed successfully")
-	return accessToken, nil
+	if err := s.executorRepo.Update(edit.update()); err != nil {
+		s.logger.WithError(err).Warn("Failed to update executor profile")
+		return nil, profileUpdateError(err)
+	}
+	return s.GetExecutorByID(id)
 }
 
-func (s *ExecutorUsecase) GetExecutorByID(id string) (*domain.Executor, error) {
-	executor, err := s.executorRepo.GetByID(id)
+// ListExecutorProfileChanges возвращает историю изменений профиля исполнителя, новые первыми.
+func (s *ExecutorUsecase) ListExecutorProfileChanges(id string, limit, offset int) ([]domain.ProfileChange, error) {
+	changes, err := s.executorRepo.ListChanges(id, pageLimit(limit), offset)
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get executor by ID")
+		s.logger.WithError(err).Error("Failed to list executor profile changes")
 		return nil, err
 	}
-	return executor, nil
+	return changes, nil
 }
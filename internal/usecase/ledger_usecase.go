@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"time"
+
+	"BruhPro/internal/ledger"
+	"BruhPro/internal/payments"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LedgerUsecase дает администратору сальдо и выписки по счетам журнала
+// и сверку журнала с данными платежного провайдера.
+type LedgerUsecase struct {
+	ledger   *ledger.Ledger
+	provider payments.Provider
+	logger   *logrus.Logger
+}
+
+func NewLedgerUsecase(journal *ledger.Ledger, provider payments.Provider, logger *logrus.Logger) *LedgerUsecase {
+	return &LedgerUsecase{journal, provider, logger}
+}
+
+func (s *LedgerUsecase) Balance(account string, at time.Time) (int64, error) {
+	return s.ledger.Balance(account, at)
+}
+
+func (s *LedgerUsecase) Statement(account string, from, to time.Time) ([]ledger.Entry, error) {
+	return s.ledger.Statement(account, from, to)
+}
+
+// Reconcile сверяет платежи провайдера за период [from, to) с журналом.
+func (s *LedgerUsecase) Reconcile(from, to time.Time) (*ledger.ReconciliationReport, error) {
+	charges, err := s.provider.ListCharges(from, to)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to load provider charges")
+		return nil, err
+	}
+
+	records := make([]ledger.ProviderRecord, 0, len(charges))
+	for _, charge := range charges {
+		records = append(records, ledger.ProviderRecord{
+			ChargeID:  charge.ID,
+			Reference: charge.Reference,
+			Captured:  charge.Captured,
+			Refunded:  charge.Refunded,
+		})
+	}
+
+	report, err := s.ledger.Reconcile(records, from, to)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to reconcile ledger")
+		return nil, err
+	}
+
+	entry := s.logger.WithFields(logrus.Fields{
+		"from":          from,
+		"to":            to,
+		"matched":       report.Matched,
+		"discrepancies": len(report.Discrepancies),
+	})
+	if len(report.Discrepancies) > 0 {
+		entry.Warn("Ledger reconciliation found discrepancies")
+	} else {
+		entry.Info("Ledger reconciliation completed")
+	}
+	return report, nil
+}
@@ -0,0 +1,259 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"BruhPro/internal/domain"
+	"BruhPro/internal/ratelimit"
+	"BruhPro/internal/repository"
+	"BruhPro/internal/utils"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	mfaIssuer        = "BuhPro"
+	mfaRecoveryCodes = 10
+)
+
+var (
+	ErrInvalidMFACode = errors.New("invalid authentication code")
+	ErrMFALocked      = errors.New("too many invalid authentication codes, try again later")
+)
+
+// MFAEnrollment — данные для подключения приложения-аутентификатора.
+type MFAEnrollment struct {
+	Secret          string
+	ProvisioningURI string
+}
+
+// MFAUsecase подключает TOTP и проверяет второй фактор при входе.
+// Время берется из now, поэтому проверку кодов можно воспроизвести с фиксированными часами.
+// Ошибки кода при входе, подключении и отключении 2FA учитываются в общем lockout по ID
+// аккаунта: пароль или токен уже у атакующего, и перебор шестизначного кода с разных IP
+// лимит по IP не остановит.
+type MFAUsecase struct {
+	mfaRepo repository.MFARepository
+	auth    *AuthUsecase
+	lockout *ratelimit.Lockout
+	now     func() time.Time
+	logger  *logrus.Logger
+}
+
+func NewMFAUsecase(mfaRepo repository.MFARepository, auth *AuthUsecase, lockout *ratelimit.Lockout, logger *logrus.Logger) *MFAUsecase {
+	return &MFAUsecase{mfaRepo, auth, lockout, time.Now, logger}
+}
+
+// WithClock подменяет источник времени (для тестов).
+func (s *MFAUsecase) WithClock(now func() time.Time) *MFAUsecase {
+	s.now = now
+	return s
+}
+
+// Enroll выпускает новый секрет. 2FA начинает действовать только после Enable.
+func (s *MFAUsecase) Enroll(accountID string) (*MFAEnrollment, error) {
+	s.logger.WithField("account_id", accountID).Info("Attempting to enroll MFA")
+
+	account, _, err := s.auth.GetAccountByID(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if current, err := s.mfaRepo.Get(accountID); err == nil && current.EnabledAt != nil {
+		s.logger.Warn("MFA already enabled")
+		return nil, errors.New("two-factor authentication is already enabled")
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to generate TOTP secret")
+		return nil, err
+	}
+
+	if err := s.mfaRepo.Save(&domain.AccountMFA{AccountID: accountID, Secret: secret}); err != nil {
+		s.logger.WithError(err).Error("Failed to save TOTP secret")
+		return nil, err
+	}
+
+	return &MFAEnrollment{
+		Secret:          secret,
+		ProvisioningURI: utils.TOTPProvisioningURI(secret, account.Email, mfaIssuer),
+	}, nil
+}
+
+// Enable подтверждает подключение первым кодом и возвращает коды восстановления.
+// Коды показываются один раз: в базе хранятся только их хеши.
+func (s *MFAUsecase) Enable(accountID, code string) ([]string, error) {
+	s.logger.WithField("account_id", accountID).Info("Attempting to enable MFA")
+
+	mfa, err := s.mfaRepo.Get(accountID)
+	if err != nil {
+		s.logger.Warn("MFA enrollment not found")
+		return nil, errors.New("two-factor authentication enrollment not found")
+	}
+	if mfa.EnabledAt != nil {
+		s.logger.Warn("MFA already enabled")
+		return nil, errors.New("two-factor authentication is already enabled")
+	}
+
+	var step int64
+	err = s.guard(accountID, func() error {
+		var ok bool
+		if step, ok = utils.ValidateTOTP(mfa.Secret, code, s.now()); !ok {
+			s.logger.Warn("Invalid TOTP code")
+			return ErrInvalidMFACode
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, 0, mfaRecoveryCodes)
+	hashes := make([]string, 0, mfaRecoveryCodes)
+	for i := 0; i < mfaRecoveryCodes; i++ {
+		code, err := utils.GenerateRecoveryCode()
+		if err != nil {
+			s.logger.WithError(err).Error("Failed to generate recovery code")
+			return nil, err
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, utils.HashToken(utils.NormalizeRecoveryCode(code)))
+	}
+
+	if err := s.mfaRepo.Enable(accountID, step, hashes); err != nil {
+		s.logger.WithError(err).Error("Failed to enable MFA")
+		return nil, err
+	}
+
+	s.logger.WithField("account_id", accountID).Info("MFA enabled")
+	return codes, nil
+}
+
+// Disable отключает 2FA после проверки текущего кода или кода восстановления.
+func (s *MFAUsecase) Disable(accountID, code string) error {
+	s.logger.WithField("account_id", accountID).Info("Attempting to disable MFA")
+
+	if err := s.guard(accountID, func() error { return s.verify(accountID, code) }); err != nil {
+		return err
+	}
+
+	if err := s.mfaRepo.Delete(accountID); err != nil {
+		s.logger.WithError(err).Error("Failed to disable MFA")
+		return err
+	}
+
+	s.logger.WithField("account_id", accountID).Info("MFA disabled")
+	return nil
+}
+
+// CompleteLogin завершает двухшаговый вход: проверяет токен первого шага и код,
+// затем открывает сессию с подтвержденным вторым фактором.
+// Токен первого шага одноразовый: после первой проверки кода, удачной или нет, он отзывается.
+func (s *MFAUsecase) CompleteLogin(mfaToken, code string, client ClientInfo) (*TokenPair, error) {
+	s.logger.Info("Attempting to complete MFA login")
+
+	challenge, err := s.auth.tokens.ParseMFAChallenge(mfaToken, s.now())
+	if err != nil || s.auth.denylist.IsRevoked(challenge.ID) {
+		s.logger.WithError(err).Warn("Invalid MFA token")
+		return nil, errors.New("invalid or expired MFA token")
+	}
+	accountID, role := challenge.UserID, challenge.Role
+
+	err = s.guard(accountID, func() error { return s.verify(accountID, code) })
+	if errors.Is(err, ErrMFALocked) {
+		return nil, err
+	}
+	if revokeErr := s.auth.denylist.Revoke(challenge.ID, challenge.ExpiresAt); revokeErr != nil {
+		return nil, revokeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := s.auth.accountRepo.GetRoles(accountID)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get account roles")
+		return nil, err
+	}
+	if !hasRole(roles, role) {
+		s.logger.Warnf("Account has no %s role", role)
+		return nil, errors.New("invalid or expired MFA token")
+	}
+
+	return s.auth.openSession(accountID, role, roles, client, true)
+}
+
+// guard проверяет код через check с учетом lockout "mfa:"+accountID: у заблокированного
+// аккаунта код не проверяется, ErrInvalidMFACode засчитывается как ошибка,
+// успешная проверка сбрасывает счетчик.
+func (s *MFAUsecase) guard(accountID string, check func() error) error {
+	lockKey := "mfa:" + accountID
+
+	retryAfter, err := s.lockout.Check(lockKey)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to check MFA lockout")
+	}
+	if retryAfter > 0 {
+		s.logger.WithField("account_id", accountID).Warn("MFA code for locked account rejected")
+		return ErrMFALocked
+	}
+
+	err = check()
+	if errors.Is(err, ErrInvalidMFACode) {
+		lockedFor, failures, lockErr := s.lockout.Fail(lockKey)
+		if lockErr != nil {
+			s.logger.WithError(lockErr).Error("Failed to record MFA failure")
+		}
+		s.logger.WithFields(logrus.Fields{
+			"account_id": accountID,
+			"failures":   failures,
+			"locked_for": lockedFor.String(),
+		}).Warn("Invalid MFA code")
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := s.lockout.Success(lockKey); err != nil {
+		s.logger.WithError(err).Error("Failed to reset MFA failures")
+	}
+	return nil
+}
+
+// verify принимает TOTP-код или неиспользованный код восстановления.
+// Один и тот же TOTP-код нельзя предъявить дважды.
+func (s *MFAUsecase) verify(accountID, code string) error {
+	mfa, err := s.mfaRepo.Get(accountID)
+	if err != nil || mfa.EnabledAt == nil {
+		s.logger.Warn("MFA is not enabled")
+		return errors.New("two-factor authentication is not enabled")
+	}
+
+	if step, ok := utils.ValidateTOTP(mfa.Secret, code, s.now()); ok {
+		accepted, err := s.mfaRepo.UpdateLastUsedStep(accountID, step)
+		if err != nil {
+			s.logger.WithError(err).Error("Failed to save TOTP step")
+			return err
+		}
+		if !accepted {
+			s.logger.Warn("TOTP code reuse")
+			return ErrInvalidMFACode
+		}
+		return nil
+	}
+
+	used, err := s.mfaRepo.UseRecoveryCode(accountID, utils.HashToken(utils.NormalizeRecoveryCode(code)))
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to use recovery code")
+		return err
+	}
+	if !used {
+		s.logger.Warn("Invalid MFA code")
+		return ErrInvalidMFACode
+	}
+
+	s.logger.WithField("account_id", accountID).Warn("Recovery code used")
+	return nil
+}
@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"BruhPro/internal/domain"
+	"BruhPro/internal/ratelimit"
+	"BruhPro/internal/utils"
+
+	"github.com/sirupsen/logrus"
+)
+
+// memoryMFARepository повторяет условия репозитория: шаг принимается, только если он
+// больше последнего принятого.
+type memoryMFARepository struct {
+	mfa map[string]*domain.AccountMFA
+}
+
+func newMemoryMFARepository() *memoryMFARepository {
+	return &memoryMFARepository{mfa: map[string]*domain.AccountMFA{}}
+}
+
+func (r *memoryMFARepository) Get(accountID string) (*domain.AccountMFA, error) {
+	mfa, ok := r.mfa[accountID]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	copied := *mfa
+	return &copied, nil
+}
+
+func (r *memoryMFARepository) Save(mfa *domain.AccountMFA) error {
+	copied := *mfa
+	r.mfa[mfa.AccountID] = &copied
+	return nil
+}
+
+func (r *memoryMFARepository) Enable(accountID string, step int64, codeHashes []string) error {
+	now := time.Now()
+	r.mfa[accountID].EnabledAt = &now
+	r.mfa[accountID].LastUsedStep = step
+	return nil
+}
+
+func (r *memoryMFARepository) UpdateLastUsedStep(accountID string, step int64) (bool, error) {
+	mfa := r.mfa[accountID]
+	if mfa.LastUsedStep >= step {
+		return false, nil
+	}
+	mfa.LastUsedStep = step
+	return true, nil
+}
+
+func (r *memoryMFARepository) UseRecoveryCode(accountID, codeHash string) (bool, error) {
+	return false, nil
+}
+
+func (r *memoryMFARepository) Delete(accountID string) error {
+	delete(r.mfa, accountID)
+	return nil
+}
+
+const testMFASecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func newTestMFAUsecase(t *testing.T, now *time.Time) (*MFAUsecase, *memoryMFARepository) {
+	t.Helper()
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	repo := newMemoryMFARepository()
+	if err := repo.Save(&domain.AccountMFA{AccountID: "account", Secret: testMFASecret}); err != nil {
+		t.Fatal(err)
+	}
+	lockout := ratelimit.NewLockout(ratelimit.NewMemoryStore(), 5, time.Minute, 24*time.Hour, 24*time.Hour)
+	u := NewMFAUsecase(repo, nil, lockout, logger).WithClock(func() time.Time { return *now })
+	return u, repo
+}
+
+func totpCodeAt(t *testing.T, now time.Time, offset int64) string {
+	t.Helper()
+	code, err := utils.TOTPCode(testMFASecret, utils.TOTPStep(now)+offset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return code
+}
+
+func TestMFAEnableUsesClock(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	u, repo := newTestMFAUsecase(t, &now)
+
+	if _, err := u.Enable("account", "000000"); !errors.Is(err, ErrInvalidMFACode) {
+		t.Fatalf("wrong code: err = %v, want ErrInvalidMFACode", err)
+	}
+
+	codes, err := u.Enable("account", "005924") // RFC 6238, T=1234567890
+	if err != nil {
+		t.Fatalf("enable: %v", err)
+	}
+	if len(codes) != mfaRecoveryCodes {
+		t.Errorf("got %d recovery codes, want %d", len(codes), mfaRecoveryCodes)
+	}
+	if step := repo.mfa["account"].LastUsedStep; step != utils.TOTPStep(now) {
+		t.Errorf("LastUsedStep = %d, want %d", step, utils.TOTPStep(now))
+	}
+}
+
+func TestMFAVerifyRejectsReplay(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	u, _ := newTestMFAUsecase(t, &now)
+
+	enableCode := totpCodeAt(t, now, 0)
+	if _, err := u.Enable("account", enableCode); err != nil {
+		t.Fatalf("enable: %v", err)
+	}
+
+	// Код, которым подключили 2FA, уже использован
+	if err := u.verify("account", enableCode); !errors.Is(err, ErrInvalidMFACode) {
+		t.Fatalf("replayed enable code: err = %v, want ErrInvalidMFACode", err)
+	}
+
+	// Код следующего интервала принимается один раз, даже пока часы еще в текущем
+	next := totpCodeAt(t, now, 1)
+	if err := u.verify("account", next); err != nil {
+		t.Fatalf("next step code: %v", err)
+	}
+	if err := u.verify("account", next); !errors.Is(err, ErrInvalidMFACode) {
+		t.Fatalf("replayed code: err = %v, want ErrInvalidMFACode", err)
+	}
+
+	// Через интервал предыдущий код все еще в окне допуска, но его шаг не новее принятого
+	now = now.Add(30 * time.Second)
+	if err := u.verify("account", next); !errors.Is(err, ErrInvalidMFACode) {
+		t.Fatalf("old code after clock moved: err = %v, want ErrInvalidMFACode", err)
+	}
+	if err := u.verify("account", totpCodeAt(t, now, 1)); err != nil {
+		t.Fatalf("fresh code after clock moved: %v", err)
+	}
+}
+
+func TestMFAEnableAndDisableShareLockout(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	u, _ := newTestMFAUsecase(t, &now)
+
+	for i := 0; i < 5; i++ {
+		if _, err := u.Enable("account", "000000"); !errors.Is(err, ErrInvalidMFACode) {
+			t.Fatalf("attempt %d: err = %v, want ErrInvalidMFACode", i+1, err)
+		}
+	}
+	// Шестая ошибка включает блокировку: дальше даже верный код не проверяется
+	if _, err := u.Enable("account", "000000"); !errors.Is(err, ErrInvalidMFACode) {
+		t.Fatalf("attempt 6: err = %v, want ErrInvalidMFACode", err)
+	}
+	if _, err := u.Enable("account", totpCodeAt(t, now, 0)); !errors.Is(err, ErrMFALocked) {
+		t.Fatalf("enable while locked: err = %v, want ErrMFALocked", err)
+	}
+	if err := u.Disable("account", totpCodeAt(t, now, 0)); !errors.Is(err, ErrMFALocked) {
+		t.Fatalf("disable while locked: err = %v, want ErrMFALocked", err)
+	}
+}
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"fmt"
+
+	"BruhPro/internal/domain"
+	"BruhPro/internal/mailer"
+	"BruhPro/internal/repository"
+
+	"github.com/sirupsen/logrus"
+)
+
+var orderStatusNames = map[string]string{
+	domain.OrderStatusDraft:      "черновик",
+	domain.OrderStatusPublished:  "опубликован",
+	domain.OrderStatusInProgress: "в работе",
+	domain.OrderStatusOnReview:   "на проверке",
+	domain.OrderStatusCompleted:  "завершен",
+	domain.OrderStatusCancelled:  "отменен",
+	domain.OrderStatusDisputed:   "спор",
+}
+
+// OrderNotifier сообщает участникам заказа о смене статуса по email.
+// Подключается к OrderUsecase через OnTransition.
+type OrderNotifier struct {
+	accountRepo repository.AccountRepository
+	mailer      mailer.Mailer
+	logger      *logrus.Logger
+}
+
+func NewOrderNotifier(accountRepo repository.AccountRepository, m mailer.Mailer, logger *logrus.Logger) *OrderNotifier {
+	return &OrderNotifier{accountRepo, m, logger}
+}
+
+// Notify отправляет письмо клиенту и назначенному исполнителю, кроме автора перехода.
+func (n *OrderNotifier) Notify(order *domain.Order, change *domain.OrderStatusChange) error {
+	recipients := []string{order.CustomerID}
+	if order.ExecutorID != nil {
+		recipients = append(recipients, *order.ExecutorID)
+	}
+
+	for _, accountID := range recipients {
+		if accountID == change.ActorID {
+			continue
+		}
+
+		account, err := n.accountRepo.GetByID(accountID)
+		if err != nil {
+			n.logger.WithError(err).Warn("Order participant not found")
+			continue
+		}
+
+		body := fmt.Sprintf("Здравствуйте!\n\nСтатус заказа «%s» изменен: %s.\n", order.Title, orderStatusNames[change.ToStatus])
+		if change.Reason != "" {
+			body += fmt.Sprintf("Причина: %s\n", change.Reason)
+		}
+
+		msg := mailer.Message{
+			To:      account.Email,
+			Subject: "Статус заказа в BuhPro изменен",
+			Body:    body,
+		}
+		if err := n.mailer.Send(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
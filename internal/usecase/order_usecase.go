@@ -0,0 +1,373 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"BruhPro/internal/domain"
+	"BruhPro/internal/repository"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrOrderNotFound          = errors.New("order not found")
+	ErrOrderForbidden         = errors.New("order belongs to another customer")
+	ErrInvalidOrderTransition = errors.New("order status transition is not allowed")
+)
+
+// orderTransitions — допустимые переходы статусов и роли, которым они разрешены:
+//
+//	draft → published → in_progress → on_review → completed
+//
+// с ветками cancelled и disputed. Пустой список ролей означает, что переход выполняется
+// только отдельным сценарием (например, in_progress — принятием отклика исполнителя).
+var orderTransitions = map[string]map[string][]string{
+	domain.OrderStatusDraft: {
+		domain.OrderStatusPublished: {domain.RoleCustomer},
+		domain.OrderStatusCancelled: {domain.RoleCustomer},
+	},
+	domain.OrderStatusPublished: {
+		domain.OrderStatusDraft:      {domain.RoleCustomer},
+		domain.OrderStatusInProgress: {},
+		domain.OrderStatusCancelled:  {domain.RoleCustomer, domain.RoleAdmin},
+	},
+	domain.OrderStatusInProgress: {
+		domain.OrderStatusOnReview:  {domain.RoleExecutor},
+		domain.OrderStatusDisputed:  {domain.RoleCustomer, domain.RoleExecutor},
+		domain.OrderStatusCancelled: {domain.RoleAdmin},
+	},
+	domain.OrderStatusOnReview: {
+		domain.OrderStatusCompleted:  {domain.RoleCustomer},
+		domain.OrderStatusInProgress: {domain.RoleCustomer},
+		domain.OrderStatusDisputed:   {domain.RoleCustomer, domain.RoleExecutor},
+	},
+	domain.OrderStatusDisputed: {
+		domain.OrderStatusInProgress: {domain.RoleAdmin},
+		domain.OrderStatusCompleted:  {domain.RoleAdmin},
+		domain.OrderStatusCancelled:  {domain.RoleAdmin},
+	},
+}
+
+// OrderTransitionHook вызывается после сохранения перехода статуса — например, для
+// уведомлений или платежей. Ошибка хука записывается в лог и не отменяет переход.
+type OrderTransitionHook func(order *domain.Order, change *domain.OrderStatusChange) error
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// OrderUsecase управляет заказами клиентов и их жизненным циклом.
+type OrderUsecase struct {
+	orderRepo       repository.OrderRepository
+	verification    *EmailVerificationUsecase
+	specializations *SpecializationUsecase
+	hooks           []OrderTransitionHook
+	logger          *logrus.Logger
+}
+
+func NewOrderUsecase(orderRepo repository.OrderRepository, verification *EmailVerificationUsecase, specializations *SpecializationUsecase, logger *logrus.Logger) *OrderUsecase {
+	return &OrderUsecase{orderRepo: orderRepo, verification: verification, specializations: specializations, logger: logger}
+}
+
+// OnTransition регистрирует хук, вызываемый после каждого перехода статуса.
+// Хуки регистрируются при старте приложения, до обработки запросов.
+func (s *OrderUsecase) OnTransition(hook OrderTransitionHook) {
+	s.hooks = append(s.hooks, hook)
+}
+
+// Create создает черновик заказа; при publish заказ сразу публикуется.
+// Создавать заказы можно только с подтвержденным email.
+func (s *OrderUsecase) Create(customerID string, order *domain.Order, publish bool) error {
+	s.logger.WithField("customer_id", customerID).Info("Attempting to create order")
+
+	if err := s.verification.RequireVerifiedEmail(customerID); err != nil {
+		s.logger.WithError(err).Warn("Order creation rejected")
+		return err
+	}
+
+	if err := s.validateOrder(order); err != nil {
+		s.logger.WithError(err).Warn("Invalid order")
+		return err
+	}
+
+	order.ID = ""
+	order.CustomerID = customerID
+	order.ExecutorID = nil
+	order.Status = domain.OrderStatusDraft
+
+	change := &domain.OrderStatusChange{
+		ToStatus:  domain.OrderStatusDraft,
+		ActorID:   customerID,
+		ActorRole: domain.RoleCustomer,
+	}
+	if err := s.orderRepo.Create(order, change); err != nil {
+		s.logger.WithError(err).Error("Failed to create order")
+		return err
+	}
+	s.logger.WithField("order_id", order.ID).Info("Order created successfully")
+
+	if publish {
+		return s.transition(order, domain.OrderStatusPublished, customerID, domain.RoleCustomer, "")
+	}
+	return nil
+}
+
+// GetByID возвращает заказ. Опубликованный заказ виден всем, остальные — только участникам и администратору.
+func (s *OrderUsecase) GetByID(viewerID, viewerRole, id string) (*domain.Order, error) {
+	order, err := s.orderRepo.GetByID(id)
+	if err != nil {
+		s.logger.WithError(err).Warn("Order not found")
+		return nil, ErrOrderNotFound
+	}
+
+	if order.Status != domain.OrderStatusPublished && !canViewOrder(order, viewerID, viewerRole) {
+		return nil, ErrOrderNotFound
+	}
+	return order, nil
+}
+
+// History возвращает историю статусов заказа его участникам и администратору.
+func (s *OrderUsecase) History(viewerID, viewerRole, id string) ([]domain.OrderStatusChange, error) {
+	order, err := s.orderRepo.GetByID(id)
+	if err != nil || !canViewOrder(order, viewerID, viewerRole) {
+		s.logger.Warn("Order not found")
+		return nil, ErrOrderNotFound
+	}
+
+	history, err := s.orderRepo.ListHistory(id)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get order history")
+		return nil, err
+	}
+	return history, nil
+}
+
+// Transition переводит заказ в статус to от имени участника заказа.
+// Недопустимый переход или переход, не разрешенный роли, отклоняется с ErrInvalidOrderTransition.
+func (s *OrderUsecase) Transition(actorID, actorRole, id, to, reason string) (*domain.Order, error) {
+	s.logger.WithFields(logrus.Fields{
+		"order_id": id,
+		"actor_id": actorID,
+		"to":       to,
+	}).Info("Attempting to change order status")
+
+	order, err := s.orderRepo.GetByID(id)
+	if err != nil || !canViewOrder(order, actorID, actorRole) {
+		s.logger.Warn("Order not found")
+		return nil, ErrOrderNotFound
+	}
+
+	roles, ok := orderTransitions[order.Status][to]
+	if !ok || !hasRole(roles, actorRole) {
+		s.logger.WithFields(logrus.Fields{
+			"from": order.Status,
+			"to":   to,
+			"role": actorRole,
+		}).Warn("Order status transition rejected")
+		return nil, ErrInvalidOrderTransition
+	}
+
+	if err := s.transition(order, to, actorID, actorRole, reason); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// transition сохраняет переход, уже проверенный вызывающим кодом, и запускает хуки.
+func (s *OrderUsecase) transition(order *domain.Order, to, actorID, actorRole, reason string) error {
+	from := order.Status
+	order.Status = to
+
+	change := &domain.OrderStatusChange{
+		FromStatus: from,
+		ToStatus:   to,
+		ActorID:    actorID,
+		ActorRole:  actorRole,
+		Reason:     reason,
+	}
+	if err := s.orderRepo.Transition(order, from, change); err != nil {
+		order.Status = from
+		if errors.Is(err, repository.ErrOrderStatusChanged) {
+			s.logger.Warn("Order status changed concurrently")
+			return ErrInvalidOrderTransition
+		}
+		s.logger.WithError(err).Error("Failed to change order status")
+		return err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"order_id": order.ID,
+		"from":     from,
+		"to":       to,
+	}).Info("Order status changed")
+
+	s.runHooks(order, change)
+	return nil
+}
+
+func (s *OrderUsecase) runHooks(order *domain.Order, change *domain.OrderStatusChange) {
+	for _, hook := range s.hooks {
+		if err := hook(order, change); err != nil {
+			s.logger.WithError(err).WithField("order_id", order.ID).Error("Order transition hook failed")
+		}
+	}
+}
+
+// canViewOrder сообщает, является ли пользователь участником заказа или администратором.
+func canViewOrder(order *domain.Order, viewerID, viewerRole string) bool {
+	switch viewerRole {
+	case domain.RoleAdmin:
+		return true
+	case domain.RoleCustomer:
+		return order.CustomerID == viewerID
+	case domain.RoleExecutor:
+		return order.ExecutorID != nil && *order.ExecutorID == viewerID
+	}
+	return false
+}
+
+// ListPublished возвращает опубликованные заказы для исполнителей.
+func (s *OrderUsecase) ListPublished(filter repository.OrderFilter) ([]domain.Order, error) {
+	filter.CustomerID = ""
+	filter.ExecutorID = ""
+	filter.Status = domain.OrderStatusPublished
+	return s.list(filter)
+}
+
+// ListByCustomer возвращает все заказы клиента.
+func (s *OrderUsecase) ListByCustomer(customerID string, filter repository.OrderFilter) ([]domain.Order, error) {
+	filter.CustomerID = customerID
+	filter.ExecutorID = ""
+	return s.list(filter)
+}
+
+// ListByExecutor возвращает заказы, в которых исполнитель назначен.
+func (s *OrderUsecase) ListByExecutor(executorID string, filter repository.OrderFilter) ([]domain.Order, error) {
+	filter.CustomerID = ""
+	filter.ExecutorID = executorID
+	return s.list(filter)
+}
+
+func (s *OrderUsecase) list(filter repository.OrderFilter) ([]domain.Order, error) {
+	if filter.Limit <= 0 || filter.Limit > maxPageSize {
+		filter.Limit = defaultPageSize
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	orders, err := s.orderRepo.List(filter)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to list orders")
+		return nil, err
+	}
+	return orders, nil
+}
+
+// Update изменяет заказ. Редактировать можно только свой заказ до начала работы.
+func (s *OrderUsecase) Update(customerID, id string, changes *domain.Order) (*domain.Order, error) {
+	s.logger.WithFields(logrus.Fields{
+		"customer_id": customerID,
+		"order_id":    id,
+	}).Info("Attempting to update order")
+
+	order, err := s.ownOrder(customerID, id)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != domain.OrderStatusDraft && order.Status != domain.OrderStatusPublished {
+		s.logger.WithField("status", order.Status).Warn("Order can no longer be edited")
+		return nil, errors.New("order can be edited only in draft or published status")
+	}
+
+	if err := s.validateOrder(changes); err != nil {
+		s.logger.WithError(err).Warn("Invalid order")
+		return nil, err
+	}
+
+	order.Title = changes.Title
+	order.Description = changes.Description
+	order.Specialization = changes.Specialization
+	order.PaymentMode = changes.PaymentMode
+	order.Budget = changes.Budget
+	order.Deadline = changes.Deadline
+	order.WorkFormat = changes.WorkFormat
+	order.City = changes.City
+
+	if err := s.orderRepo.Update(order, domain.OrderStatusDraft, domain.OrderStatusPublished); err != nil {
+		if errors.Is(err, repository.ErrOrderStatusChanged) {
+			s.logger.Warn("Order status changed concurrently")
+			return nil, ErrInvalidOrderTransition
+		}
+		s.logger.WithError(err).Error("Failed to update order")
+		return nil, err
+	}
+
+	s.logger.WithField("order_id", id).Info("Order updated successfully")
+	return order, nil
+}
+
+// Delete удаляет свой черновик. Опубликованный заказ можно только отменить, чтобы сохранить историю.
+func (s *OrderUsecase) Delete(customerID, id string) error {
+	s.logger.WithFields(logrus.Fields{
+		"customer_id": customerID,
+		"order_id":    id,
+	}).Info("Attempting to delete order")
+
+	order, err := s.ownOrder(customerID, id)
+	if err != nil {
+		return err
+	}
+	if order.Status != domain.OrderStatusDraft {
+		s.logger.WithField("status", order.Status).Warn("Only draft orders can be deleted")
+		return errors.New("only draft orders can be deleted, cancel the order instead")
+	}
+
+	if err := s.orderRepo.Delete(id, domain.OrderStatusDraft); err != nil {
+		if errors.Is(err, repository.ErrOrderStatusChanged) {
+			s.logger.Warn("Order status changed concurrently")
+			return ErrInvalidOrderTransition
+		}
+		s.logger.WithError(err).Error("Failed to delete order")
+		return err
+	}
+
+	s.logger.WithField("order_id", id).Info("Order deleted successfully")
+	return nil
+}
+
+// ownOrder загружает заказ и проверяет, что он принадлежит клиенту.
+func (s *OrderUsecase) ownOrder(customerID, id string) (*domain.Order, error) {
+	order, err := s.orderRepo.GetByID(id)
+	if err != nil {
+		s.logger.WithError(err).Warn("Order not found")
+		return nil, ErrOrderNotFound
+	}
+
+	if order.CustomerID != customerID {
+		s.logger.Warn("Order belongs to another customer")
+		return nil, ErrOrderForbidden
+	}
+	return order, nil
+}
+
+func (s *OrderUsecase) validateOrder(order *domain.Order) error {
+	if err := s.specializations.Validate(domain.RoleExecutor, order.Specialization); err != nil {
+		return err
+	}
+	if !domain.Contains(domain.WorkFormats, order.WorkFormat) {
+		return errors.New("unknown work format")
+	}
+	if order.PaymentMode != domain.PaymentModeFixed && order.PaymentMode != domain.PaymentModeHourly {
+		return errors.New("payment mode must be fixed or hourly")
+	}
+	if order.Budget <= 0 {
+		return errors.New("budget must be positive")
+	}
+	if order.Deadline != nil && order.Deadline.Before(time.Now()) {
+		return errors.New("deadline must be in the future")
+	}
+	return nil
+}
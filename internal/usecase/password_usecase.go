@@ -0,0 +1,191 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"BruhPro/internal/domain"
+	"BruhPro/internal/mailer"
+	"BruhPro/internal/repository"
+	"BruhPro/internal/utils"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// passwordResetTTL — сколько действует ссылка для сброса пароля.
+const passwordResetTTL = 1 * time.Hour
+
+var (
+	ErrPasswordTooWeak      = errors.New("password must contain at least one uppercase letter, one lowercase letter, one number, and one special character")
+	ErrWrongCurrentPassword = errors.New("current password is incorrect")
+	ErrPasswordNotChanged   = errors.New("new password must differ from the current one")
+)
+
+// PasswordUsecase отвечает за смену пароля и восстановление пароля по email.
+type PasswordUsecase struct {
+	accountRepo repository.AccountRepository
+	resetRepo   repository.PasswordResetRepository
+	auth        *AuthUsecase
+	mailer      mailer.Mailer
+	baseURL     string
+	logger      *logrus.Logger
+}
+
+func NewPasswordUsecase(accountRepo repository.AccountRepository, resetRepo repository.PasswordResetRepository, auth *AuthUsecase, m mailer.Mailer, baseURL string, logger *logrus.Logger) *PasswordUsecase {
+	return &PasswordUsecase{accountRepo, resetRepo, auth, m, baseURL, logger}
+}
+
+// ForgotPassword отправляет письмо со ссылкой для сброса пароля.
+// Ответ не зависит от того, существует ли аккаунт, чтобы по нему нельзя было перебирать email.
+func (s *PasswordUsecase) ForgotPassword(email, role string) error {
+	s.logger.WithFields(logrus.Fields{
+		"email": email,
+		"role":  role,
+	}).Info("Password reset requested")
+
+	account, err := s.accountRepo.GetByEmail(email)
+	if err != nil {
+		s.logger.Warn("Password reset requested for unknown email")
+		return nil
+	}
+
+	roles, err := s.accountRepo.GetRoles(account.ID)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get account roles")
+		return err
+	}
+	if !hasRole(roles, role) {
+		s.logger.Warnf("Password reset requested for account without %s role", role)
+		return nil
+	}
+
+	token, err := utils.GenerateSecureToken()
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to generate reset token")
+		return err
+	}
+
+	resetToken := &domain.PasswordResetToken{
+		AccountID: account.ID,
+		Role:      role,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: time.Now().Add(passwordResetTTL),
+	}
+	if err := s.resetRepo.Create(resetToken); err != nil {
+		s.logger.WithError(err).Error("Failed to save reset token")
+		return err
+	}
+
+	link := fmt.Sprintf("%s/%s/password/reset?token=%s", s.baseURL, role, token)
+	msg := mailer.Message{
+		To:      account.Email,
+		Subject: "Восстановление пароля BuhPro",
+		Body: fmt.Sprintf("Здравствуйте!\n\nЧтобы задать новый пароль, перейдите по ссылке:\n%s\n\n"+
+			"Ссылка действует %d мин. и может быть использована один раз.\n"+
+			"Если вы не запрашивали сброс пароля, просто проигнорируйте это письмо.\n", link, int(passwordResetTTL.Minutes())),
+	}
+	if err := s.mailer.Send(msg); err != nil {
+		s.logger.WithError(err).Error("Failed to send password reset email")
+		return nil
+	}
+
+	s.logger.Info("Password reset email sent")
+	return nil
+}
+
+// ResetPassword устанавливает новый пароль по токену из письма и завершает все сессии аккаунта.
+// Токен принимается только в кабинете роли, для которой он выдан.
+func (s *PasswordUsecase) ResetPassword(token, role, newPassword string) error {
+	s.logger.WithField("role", role).Info("Attempting to reset password")
+
+	resetToken, err := s.resetRepo.GetByTokenHash(utils.HashToken(token))
+	if err != nil {
+		s.logger.Warn("Invalid reset token")
+		return repository.ErrResetTokenInvalid
+	}
+
+	if resetToken.UsedAt != nil || time.Now().After(resetToken.ExpiresAt) {
+		s.logger.Warn("Reset token used or expired")
+		return repository.ErrResetTokenInvalid
+	}
+	if resetToken.Role != role {
+		s.logger.Warnf("Reset token issued for %s role used for %s", resetToken.Role, role)
+		return repository.ErrResetTokenInvalid
+	}
+
+	if !utils.IsPasswordComplex(newPassword) {
+		s.logger.Warn("Password does not meet complexity requirements")
+		return ErrPasswordTooWeak
+	}
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to hash password")
+		return err
+	}
+
+	if err := s.resetRepo.Consume(resetToken, string(hashed)); err != nil {
+		s.logger.WithError(err).Warn("Failed to consume reset token")
+		return err
+	}
+
+	// Тот, кто знал старый пароль, не должен сохранить доступ
+	if err := s.auth.RevokeAllSessions(resetToken.AccountID, ""); err != nil {
+		return err
+	}
+
+	s.logger.WithField("account_id", resetToken.AccountID).Info("Password reset successfully")
+	return nil
+}
+
+// ChangePassword меняет пароль по текущему паролю и завершает все сессии аккаунта, кроме sessionID,
+// из которой пришел запрос. Владельцу уходит письмо: если пароль менял не он, он узнает об этом сразу.
+func (s *PasswordUsecase) ChangePassword(accountID, sessionID, currentPassword, newPassword string) error {
+	account, err := s.accountRepo.GetByID(accountID)
+	if err != nil {
+		s.logger.WithError(err).Warn("Account not found")
+		return errors.New("account not found")
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(account.PasswordHash), []byte(currentPassword)); err != nil {
+		s.logger.WithField("account_id", accountID).Warn("Wrong current password on password change")
+		return ErrWrongCurrentPassword
+	}
+	if currentPassword == newPassword {
+		return ErrPasswordNotChanged
+	}
+	if !utils.IsPasswordComplex(newPassword) {
+		s.logger.Warn("Password does not meet complexity requirements")
+		return ErrPasswordTooWeak
+	}
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to hash password")
+		return err
+	}
+
+	if err := s.accountRepo.UpdatePassword(accountID, string(hashed)); err != nil {
+		s.logger.WithError(err).Error("Failed to update password")
+		return err
+	}
+
+	if err := s.auth.RevokeAllSessions(accountID, sessionID); err != nil {
+		return err
+	}
+
+	msg := mailer.Message{
+		To:      account.Email,
+		Subject: "Пароль BuhPro изменен",
+		Body: "Здравствуйте!\n\nПароль вашего аккаунта BuhPro был изменен, остальные сеансы завершены.\n" +
+			"Если это были не вы, восстановите доступ через «Забыли пароль?» и свяжитесь с поддержкой.\n",
+	}
+	if err := s.mailer.Send(msg); err != nil {
+		s.logger.WithError(err).Error("Failed to send password change notice")
+	}
+
+	s.logger.WithField("account_id", accountID).Info("Password changed successfully")
+	return nil
+}
@@ -0,0 +1,434 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"BruhPro/internal/domain"
+	"BruhPro/internal/ledger"
+	"BruhPro/internal/payments"
+	"BruhPro/internal/repository"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrPaymentNotFound      = errors.New("payment not found")
+	ErrAlreadyPaid          = errors.New("already paid")
+	ErrPaymentNotRefundable = errors.New("payment cannot be refunded in its current status")
+	ErrPaymentChanged       = errors.New("payment has been changed concurrently, try again")
+)
+
+// paymentTransitions — из каких статусов платеж может перейти в ключевой статус.
+var paymentTransitions = map[string][]string{
+	domain.PaymentStatusAuthorized: {domain.PaymentStatusPending},
+	domain.PaymentStatusCaptured:   {domain.PaymentStatusPending, domain.PaymentStatusAuthorized},
+	domain.PaymentStatusFailed:     {domain.PaymentStatusPending, domain.PaymentStatusAuthorized},
+	domain.PaymentStatusRefunded:   {domain.PaymentStatusAuthorized, domain.PaymentStatusCaptured},
+}
+
+// PaymentHook вызывается после смены статуса платежа — например, чтобы зачислить
+// оплаченный этап заказа на эскроу. Ошибка хука записывается в лог.
+type PaymentHook func(payment *domain.Payment) error
+
+// PaymentUsecase принимает оплату этапов заказов и курсов через платежного провайдера.
+// Авторизованный платеж сразу списывается: оплата курса открывает доступ к нему,
+// оплата этапа заказа остается на эскроу платформы (см. EscrowUsecase).
+type PaymentUsecase struct {
+	paymentRepo    repository.PaymentRepository
+	courseRepo     repository.CourseRepository
+	enrollmentRepo repository.EnrollmentRepository
+	provider       payments.Provider
+	ledger         *ledger.Ledger
+	hooks          []PaymentHook
+	logger         *logrus.Logger
+}
+
+func NewPaymentUsecase(
+	paymentRepo repository.PaymentRepository,
+	courseRepo repository.CourseRepository,
+	enrollmentRepo repository.EnrollmentRepository,
+	provider payments.Provider,
+	journal *ledger.Ledger,
+	logger *logrus.Logger,
+) *PaymentUsecase {
+	return &PaymentUsecase{
+		paymentRepo:    paymentRepo,
+		courseRepo:     courseRepo,
+		enrollmentRepo: enrollmentRepo,
+		provider:       provider,
+		ledger:         journal,
+		logger:         logger,
+	}
+}
+
+// OnStatusChange регистрирует хук смены статуса платежа.
+func (s *PaymentUsecase) OnStatusChange(hook PaymentHook) {
+	s.hooks = append(s.hooks, hook)
+}
+
+// PayMilestone начинает оплату этапа заказа. Проверки заказа и этапа выполняет EscrowUsecase.
+// Повторный вызов до оплаты возвращает тот же незавершенный платеж.
+func (s *PaymentUsecase) PayMilestone(customerID string, order *domain.Order, milestone *domain.Milestone) (*domain.Payment, error) {
+	// Этап еще не оплачен, а платеж уже прошел: сорвалось списание или зачисление на эскроу; довершаем их.
+	if existing, err := s.paymentRepo.FindActive(domain.PaymentPurposeOrder, milestone.ID); err == nil {
+		if existing.Status != domain.PaymentStatusPending {
+			s.afterStatusChange(existing)
+		}
+		return existing, nil
+	}
+
+	payment := &domain.Payment{
+		AccountID:   customerID,
+		Purpose:     domain.PaymentPurposeOrder,
+		OrderID:     &order.ID,
+		MilestoneID: &milestone.ID,
+		Amount:      milestone.Amount,
+	}
+	if err := s.startPayment(payment, fmt.Sprintf("Оплата этапа «%s» заказа «%s»", milestone.Title, order.Title)); err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
+
+// PayCourse начинает оплату записи на платный курс.
+func (s *PaymentUsecase) PayCourse(accountID, courseID string) (*domain.Payment, error) {
+	s.logger.WithFields(logrus.Fields{
+		"account_id": accountID,
+		"course_id":  courseID,
+	}).Info("Attempting to pay for course")
+
+	enrollment, err := s.enrollmentRepo.Get(courseID, accountID)
+	if err != nil {
+		return nil, ErrNotEnrolled
+	}
+	if enrollment.Status == domain.EnrollmentStatusActive {
+		return nil, ErrAlreadyPaid
+	}
+
+	// Платеж мог пройти, а списание или активация записи — сорваться; довершаем их.
+	if existing, err := s.paymentRepo.FindActive(domain.PaymentPurposeCourse, enrollment.ID); err == nil {
+		if existing.Status != domain.PaymentStatusPending {
+			s.afterStatusChange(existing)
+		}
+		return existing, nil
+	}
+
+	course, err := s.courseRepo.GetByID(courseID)
+	if err != nil {
+		return nil, ErrCourseNotFound
+	}
+
+	payment := &domain.Payment{
+		AccountID:    accountID,
+		Purpose:      domain.PaymentPurposeCourse,
+		EnrollmentID: &enrollment.ID,
+		Amount:       course.Price,
+	}
+	if err := s.startPayment(payment, fmt.Sprintf("Оплата курса «%s»", course.Title)); err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
+
+// startPayment сохраняет платеж и создает его у провайдера.
+func (s *PaymentUsecase) startPayment(payment *domain.Payment, description string) error {
+	payment.Currency = domain.CurrencyKZT
+	payment.Status = domain.PaymentStatusPending
+	payment.Provider = s.provider.Name()
+
+	if err := s.paymentRepo.Create(payment); err != nil {
+		s.logger.WithError(err).Error("Failed to create payment")
+		return err
+	}
+
+	charge, err := s.provider.Create(payments.CreateRequest{
+		Reference:   payment.ID,
+		Amount:      payment.Amount,
+		Currency:    payment.Currency,
+		Description: description,
+	})
+	if err != nil {
+		s.logger.WithError(err).WithField("payment_id", payment.ID).Error("Payment provider rejected payment")
+		payment.Status = domain.PaymentStatusFailed
+		payment.FailureReason = err.Error()
+		if err := s.paymentRepo.UpdateStatus(payment, domain.PaymentStatusPending); err != nil {
+			s.logger.WithError(err).Error("Failed to mark payment as failed")
+		}
+		return errors.New("payment provider is unavailable")
+	}
+
+	payment.ProviderPaymentID = &charge.ID
+	payment.CheckoutURL = charge.CheckoutURL
+	if err := s.paymentRepo.AttachCharge(payment); err != nil {
+		s.logger.WithError(err).Error("Failed to save provider payment ID")
+		return err
+	}
+
+	s.logger.WithField("payment_id", payment.ID).Info("Payment created successfully")
+	return nil
+}
+
+// HandleWebhook применяет подписанное уведомление провайдера. Повторные доставки
+// и уведомления, пришедшие не по порядку, не меняют платеж.
+func (s *PaymentUsecase) HandleWebhook(payload []byte, signature string) error {
+	event, err := s.provider.ParseWebhook(payload, signature)
+	if err != nil {
+		s.logger.WithError(err).Warn("Rejected payment webhook")
+		return err
+	}
+
+	logger := s.logger.WithFields(logrus.Fields{
+		"event_id":  event.ID,
+		"charge_id": event.ChargeID,
+		"status":    event.Status,
+	})
+
+	payment, err := s.paymentRepo.GetByProviderPaymentID(s.provider.Name(), event.ChargeID)
+	if err != nil {
+		logger.Warn("Payment for webhook not found")
+		return ErrPaymentNotFound
+	}
+
+	fromStatuses, ok := paymentTransitions[event.Status]
+	if !ok {
+		logger.Info("Ignoring payment webhook with unsupported status")
+		return nil
+	}
+	if event.Status != domain.PaymentStatusFailed && event.Amount != payment.Amount {
+		logger.WithField("payment_id", payment.ID).Error("Payment webhook amount does not match payment")
+		return nil
+	}
+
+	previousStatus, previousRefunded := payment.Status, payment.RefundedAmount
+	payment.Status = event.Status
+	switch {
+	case event.Status == domain.PaymentStatusFailed:
+		payment.FailureReason = event.Reason
+	case event.Status == domain.PaymentStatusRefunded && previousStatus == domain.PaymentStatusCaptured:
+		payment.RefundedAmount = payment.Amount
+	}
+
+	applied, err := s.paymentRepo.ApplyEvent(&domain.PaymentEvent{
+		Provider:  s.provider.Name(),
+		EventID:   event.ID,
+		PaymentID: payment.ID,
+		Status:    event.Status,
+	}, payment, fromStatuses...)
+	if err != nil {
+		logger.WithError(err).Error("Failed to apply payment webhook")
+		return err
+	}
+	if !applied {
+		logger.Info("Payment webhook already processed or outdated")
+		return nil
+	}
+
+	logger.WithField("payment_id", payment.ID).Info("Payment status updated from webhook")
+	if refunded := payment.RefundedAmount - previousRefunded; refunded > 0 {
+		s.postRefund(payment, refunded)
+	}
+	s.afterStatusChange(payment)
+	return nil
+}
+
+// afterStatusChange выполняет действия, которые следуют за новым статусом платежа.
+func (s *PaymentUsecase) afterStatusChange(payment *domain.Payment) {
+	logger := s.logger.WithField("payment_id", payment.ID)
+
+	switch payment.Status {
+	case domain.PaymentStatusAuthorized:
+		if err := s.capture(payment); err != nil {
+			logger.WithError(err).Error("Failed to capture payment")
+		}
+		return
+	case domain.PaymentStatusCaptured:
+		s.postCapture(payment)
+		if payment.Purpose == domain.PaymentPurposeCourse && payment.EnrollmentID != nil {
+			if err := s.enrollmentRepo.Activate(*payment.EnrollmentID); err != nil {
+				logger.WithError(err).Error("Failed to activate course enrollment")
+			} else {
+				logger.WithField("enrollment_id", *payment.EnrollmentID).Info("Course enrollment activated after payment")
+			}
+		}
+	}
+
+	for _, hook := range s.hooks {
+		if err := hook(payment); err != nil {
+			logger.WithError(err).Error("Payment hook failed")
+		}
+	}
+}
+
+// capture списывает авторизованный платеж полностью.
+func (s *PaymentUsecase) capture(payment *domain.Payment) error {
+	if _, err := s.provider.Capture(*payment.ProviderPaymentID, payment.Amount); err != nil {
+		return err
+	}
+
+	payment.Status = domain.PaymentStatusCaptured
+	if err := s.paymentRepo.UpdateStatus(payment, domain.PaymentStatusAuthorized); err != nil {
+		return err
+	}
+
+	s.logger.WithField("payment_id", payment.ID).Info("Payment captured")
+	s.afterStatusChange(payment)
+	return nil
+}
+
+// postCapture проводит списанный платеж по журналу. Проводка идемпотентна, поэтому
+// повторный вызов для того же платежа безопасен; ошибка записывается в лог и
+// всплывает при сверке с провайдером.
+func (s *PaymentUsecase) postCapture(payment *domain.Payment) {
+	holdAccount, err := s.holdAccount(payment)
+	if err == nil {
+		err = s.ledger.PaymentCaptured(payment.ID, payment.AccountID, holdAccount, payment.Amount)
+	}
+	if err != nil {
+		s.logger.WithError(err).WithField("payment_id", payment.ID).Error("Failed to post payment capture to ledger")
+	}
+}
+
+func (s *PaymentUsecase) postRefund(payment *domain.Payment, amount int64) {
+	holdAccount, err := s.holdAccount(payment)
+	if err == nil {
+		err = s.ledger.PaymentRefunded(payment.ID, payment.AccountID, holdAccount, amount, payment.RefundedAmount)
+	}
+	if err != nil {
+		s.logger.WithError(err).WithField("payment_id", payment.ID).Error("Failed to post payment refund to ledger")
+	}
+}
+
+// holdAccount возвращает счет, на котором держатся деньги платежа:
+// эскроу заказа или счет к выплате коучу курса.
+func (s *PaymentUsecase) holdAccount(payment *domain.Payment) (string, error) {
+	if payment.Purpose == domain.PaymentPurposeOrder && payment.OrderID != nil {
+		return ledger.Account(ledger.Escrow, *payment.OrderID), nil
+	}
+	if payment.EnrollmentID == nil {
+		return "", errors.New("payment has no target")
+	}
+
+	enrollment, err := s.enrollmentRepo.GetByID(*payment.EnrollmentID)
+	if err != nil {
+		return "", err
+	}
+	course, err := s.courseRepo.GetByID(enrollment.CourseID)
+	if err != nil {
+		return "", err
+	}
+	return ledger.Account(ledger.CoachPayable, course.CoachID), nil
+}
+
+// Refund возвращает amount тиынов плательщику; 0 — весь остаток.
+// С авторизованного платежа снимается блокировка целиком.
+func (s *PaymentUsecase) Refund(id string, amount int64) (*domain.Payment, error) {
+	payment, err := s.paymentRepo.GetByID(id)
+	if err != nil {
+		return nil, ErrPaymentNotFound
+	}
+	return payment, s.refund(payment, amount)
+}
+
+// Cancel помечает неоплаченный платеж неуспешным, а по оплаченному возвращает весь остаток.
+func (s *PaymentUsecase) Cancel(id, reason string) error {
+	payment, err := s.paymentRepo.GetByID(id)
+	if err != nil {
+		return ErrPaymentNotFound
+	}
+
+	if payment.Status == domain.PaymentStatusPending {
+		payment.Status = domain.PaymentStatusFailed
+		payment.FailureReason = reason
+		return s.paymentRepo.UpdateStatus(payment, domain.PaymentStatusPending)
+	}
+	return s.refund(payment, 0)
+}
+
+func (s *PaymentUsecase) refund(payment *domain.Payment, amount int64) error {
+	if payment.Status != domain.PaymentStatusAuthorized && payment.Status != domain.PaymentStatusCaptured {
+		return ErrPaymentNotRefundable
+	}
+
+	remaining := payment.Amount - payment.RefundedAmount
+	if payment.Status == domain.PaymentStatusAuthorized || amount == 0 {
+		amount = remaining
+	}
+	if amount <= 0 || amount > remaining {
+		return errors.New("refund amount exceeds the paid amount")
+	}
+
+	// Оплаченную сумму резервируем до обращения к провайдеру: параллельный возврат
+	// по тому же платежу увидит измененный refunded_amount и получит ErrPaymentChanged.
+	// Отмена авторизации денег не двигает, ее повтор отклонит провайдер.
+	from := payment.Status
+	if from == domain.PaymentStatusCaptured {
+		if err := s.paymentRepo.ReserveRefund(payment, amount); err != nil {
+			if errors.Is(err, repository.ErrPaymentStatusChanged) {
+				return ErrPaymentChanged
+			}
+			s.logger.WithError(err).Error("Failed to reserve refund")
+			return err
+		}
+	}
+
+	charge, err := s.provider.Refund(*payment.ProviderPaymentID, amount)
+	if err != nil {
+		s.logger.WithError(err).WithField("payment_id", payment.ID).Error("Payment provider refund failed")
+		if from == domain.PaymentStatusCaptured {
+			if err := s.paymentRepo.ReleaseRefund(payment, amount); err != nil {
+				s.logger.WithError(err).WithField("payment_id", payment.ID).Error("Failed to release refund reservation")
+			}
+		}
+		return err
+	}
+
+	// Частичный возврат уже записан резервом; статус меняется только при полном возврате,
+	// а тогда параллельных резервов быть не может — остатка не осталось
+	if charge.Status == payments.StatusRefunded {
+		payment.Status = domain.PaymentStatusRefunded
+		if err := s.paymentRepo.UpdateStatus(payment, from); err != nil {
+			s.logger.WithError(err).Error("Failed to save refund")
+			return err
+		}
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"payment_id": payment.ID,
+		"amount":     amount,
+	}).Info("Payment refunded")
+
+	if from == domain.PaymentStatusCaptured {
+		s.postRefund(payment, amount)
+	}
+
+	if payment.Status == domain.PaymentStatusRefunded {
+		s.afterStatusChange(payment)
+	}
+	return nil
+}
+
+// GetByID возвращает платеж плательщику или администратору.
+func (s *PaymentUsecase) GetByID(viewerID, viewerRole, id string) (*domain.Payment, error) {
+	payment, err := s.paymentRepo.GetByID(id)
+	if err != nil || (payment.AccountID != viewerID && viewerRole != domain.RoleAdmin) {
+		return nil, ErrPaymentNotFound
+	}
+	return payment, nil
+}
+
+func (s *PaymentUsecase) ListByAccount(accountID string, limit, offset int) ([]domain.Payment, error) {
+	if limit <= 0 || limit > maxPageSize {
+		limit = defaultPageSize
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	list, err := s.paymentRepo.ListByAccount(accountID, limit, offset)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to list payments")
+		return nil, err
+	}
+	return list, nil
+}
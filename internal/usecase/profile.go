@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"BruhPro/internal/domain"
+	"BruhPro/internal/phone"
+	"BruhPro/internal/repository"
+)
+
+var (
+	ErrProfileNotFound        = errors.New("profile not found")
+	ErrProfileVersionConflict = errors.New("profile was changed by another request; reload it and retry")
+	ErrUnknownWorkFormat      = errors.New("unknown work format")
+	ErrInvalidHourlyRate      = errors.New("hourly rate must be positive")
+)
+
+// CustomerPatch — изменяемые поля профиля клиента; nil — поле не меняется.
+// Тип клиента и ИИН/БИН не меняются: они подтверждают, кто заключает сделки.
+type CustomerPatch struct {
+	CompanyName     *string
+	Name            *string
+	JobPosition     *string
+	PhoneNumber     *phone.Number
+	Address         *string
+	WorkDescription *string
+}
+
+// CoachPatch — изменяемые поля профиля коуча; nil — поле не меняется.
+type CoachPatch struct {
+	Name                   *string
+	Surname                *string
+	PhoneNumber            *phone.Number
+	ExpCoach               *string
+	Specializations        []string
+	EducationCertificates  *string
+	AchievementsExperience *string
+	Methodology            *string
+	AboutCoach             *string
+}
+
+// ExecutorPatch — изменяемые поля профиля исполнителя; nil — поле не меняется.
+// ИИН не меняется: по нему исполнитель получает выплаты.
+type ExecutorPatch struct {
+	Name            *string
+	Surname         *string
+	Patronymic      *string
+	PhoneNumber     *phone.Number
+	City            *string
+	ExpWork         *string
+	Specializations []string
+	Education       *string
+	WorkFormat      *string
+	HourlyRate      *float64
+	AboutExecutor   *string
+}
+
+// profileEdit собирает изменения профиля: новые значения колонок и записи истории.
+type profileEdit struct {
+	accountID       string
+	role            string
+	version         int64
+	columns         map[string]interface{}
+	specializations []string
+	changes         []domain.ProfileChange
+}
+
+func newProfileEdit(accountID, role string, version int64) *profileEdit {
+	return &profileEdit{accountID: accountID, role: role, version: version, columns: map[string]interface{}{}}
+}
+
+// setField переносит значение value в поле current профиля и запоминает изменение колонки column.
+// nil и совпадающее значение изменением не считаются.
+func setField[T comparable](edit *profileEdit, column string, current *T, value *T) {
+	if value == nil || *current == *value {
+		return
+	}
+	edit.record(column, fmt.Sprint(*current), fmt.Sprint(*value))
+	edit.columns[column] = *value
+	*current = *value
+}
+
+// setSpecializations заменяет специализации профиля, если набор кодов изменился.
+// Возвращает коды, которых у профиля раньше не было: только их нужно проверять по справочнику,
+// выключенные специализации, выбранные ранее, профиль сохраняет.
+func (e *profileEdit) setSpecializations(current []domain.Specialization, codes []string) []string {
+	if codes == nil {
+		return nil
+	}
+
+	before := specializationCodes(current)
+	after := make([]string, 0, len(codes))
+	seen := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		if !seen[code] {
+			seen[code] = true
+			after = append(after, code)
+		}
+	}
+	sort.Strings(before)
+	sort.Strings(after)
+	if strings.Join(before, ",") == strings.Join(after, ",") {
+		return nil
+	}
+
+	had := make(map[string]bool, len(before))
+	for _, code := range before {
+		had[code] = true
+	}
+	var added []string
+	for _, code := range after {
+		if !had[code] {
+			added = append(added, code)
+		}
+	}
+
+	e.record("specializations", strings.Join(before, ","), strings.Join(after, ","))
+	e.specializations = after
+	return added
+}
+
+func (e *profileEdit) record(field, oldValue, newValue string) {
+	e.changes = append(e.changes, domain.ProfileChange{
+		AccountID: e.accountID,
+		Role:      e.role,
+		Field:     field,
+		OldValue:  oldValue,
+		NewValue:  newValue,
+		Version:   e.version + 1,
+	})
+}
+
+func (e *profileEdit) empty() bool {
+	return len(e.changes) == 0
+}
+
+func (e *profileEdit) update() repository.ProfileUpdate {
+	return repository.ProfileUpdate{
+		ID:              e.accountID,
+		Version:         e.version,
+		Columns:         e.columns,
+		Specializations: e.specializations,
+		Changes:         e.changes,
+	}
+}
+
+// profileUpdateError переводит ошибку сохранения профиля в ошибку сценария.
+func profileUpdateError(err error) error {
+	if errors.Is(err, repository.ErrProfileVersionConflict) {
+		return ErrProfileVersionConflict
+	}
+	return err
+}
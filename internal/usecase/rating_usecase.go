@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"BruhPro/internal/domain"
+	"BruhPro/internal/repository"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ratingEditWindow — сколько клиент может исправлять свою оценку после публикации.
+const ratingEditWindow = 14 * 24 * time.Hour
+
+var ErrRatingNotFound = errors.New("rating not found")
+
+// RatingUsecase управляет оценками и отзывами: исполнителям — по завершенным заказам,
+// коучам — по курсам.
+type RatingUsecase struct {
+	ratingRepo     repository.RatingRepository
+	orderRepo      repository.OrderRepository
+	courseRepo     repository.CourseRepository
+	enrollmentRepo repository.EnrollmentRepository
+	now            func() time.Time
+	logger         *logrus.Logger
+}
+
+func NewRatingUsecase(ratingRepo repository.RatingRepository, orderRepo repository.OrderRepository, courseRepo repository.CourseRepository, enrollmentRepo repository.EnrollmentRepository, logger *logrus.Logger) *RatingUsecase {
+	return &RatingUsecase{ratingRepo, orderRepo, courseRepo, enrollmentRepo, time.Now, logger}
+}
+
+// Create оставляет оценку исполнителю по заказу. Оценить можно только свой завершенный
+// заказ и только один раз.
+func (s *RatingUsecase) Create(customerID, orderID string, rating *domain.Rating) error {
+	s.logger.WithFields(logrus.Fields{
+		"customer_id": customerID,
+		"order_id":    orderID,
+	}).Info("Attempting to rate order")
+
+	order, err := s.orderRepo.GetByID(orderID)
+	if err != nil || order.CustomerID != customerID {
+		s.logger.Warn("Order not found")
+		return ErrOrderNotFound
+	}
+	if order.Status != domain.OrderStatusCompleted || order.ExecutorID == nil {
+		s.logger.WithField("status", order.Status).Warn("Rating for not completed order rejected")
+		return errors.New("only completed orders can be rated")
+	}
+
+	exists, err := s.ratingRepo.ExistsForOrder(orderID)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to check existing rating")
+		return err
+	}
+	if exists {
+		s.logger.Warn("Order has already been rated")
+		return errors.New("order has already been rated")
+	}
+
+	rating.ID = ""
+	rating.OrderID = &orderID
+	rating.EnrollmentID = nil
+	rating.AuthorID = customerID
+	rating.TargetID = *order.ExecutorID
+	rating.TargetRole = domain.RoleExecutor
+	rating.Reply = ""
+	rating.RepliedAt = nil
+
+	if err := s.ratingRepo.Create(rating); err != nil {
+		s.logger.WithError(err).Error("Failed to save rating")
+		return err
+	}
+
+	s.logger.WithField("rating_id", rating.ID).Info("Rating created successfully")
+	return nil
+}
+
+// RateCourse оставляет оценку коучу курса. Оценить может только ученик с активной записью,
+// прошедший хотя бы один урок, и только один раз за запись.
+func (s *RatingUsecase) RateCourse(accountID, courseID string, rating *domain.Rating) error {
+	s.logger.WithFields(logrus.Fields{
+		"account_id": accountID,
+		"course_id":  courseID,
+	}).Info("Attempting to rate course")
+
+	enrollment, err := s.enrollmentRepo.Get(courseID, accountID)
+	if err != nil {
+		s.logger.Warn("Rating without enrollment rejected")
+		return ErrNotEnrolled
+	}
+	if enrollment.Status != domain.EnrollmentStatusActive {
+		s.logger.WithField("status", enrollment.Status).Warn("Rating for not paid enrollment rejected")
+		return ErrCourseNotPaid
+	}
+
+	progress, err := s.enrollmentRepo.ListProgress(enrollment.ID)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to load lesson progress")
+		return err
+	}
+	if len(progress) == 0 {
+		s.logger.Warn("Rating without completed lessons rejected")
+		return errors.New("complete at least one lesson before rating the course")
+	}
+
+	course, err := s.courseRepo.GetByID(courseID)
+	if err != nil {
+		s.logger.Warn("Course not found")
+		return ErrCourseNotFound
+	}
+
+	exists, err := s.ratingRepo.ExistsForEnrollment(enrollment.ID)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to check existing rating")
+		return err
+	}
+	if exists {
+		s.logger.Warn("Course has already been rated")
+		return errors.New("course has already been rated")
+	}
+
+	rating.ID = ""
+	rating.OrderID = nil
+	rating.EnrollmentID = &enrollment.ID
+	rating.AuthorID = accountID
+	rating.TargetID = course.CoachID
+	rating.TargetRole = domain.RoleCoach
+	rating.Reply = ""
+	rating.RepliedAt = nil
+
+	if err := s.ratingRepo.Create(rating); err != nil {
+		s.logger.WithError(err).Error("Failed to save rating")
+		return err
+	}
+
+	s.logger.WithField("rating_id", rating.ID).Info("Rating created successfully")
+	return nil
+}
+
+// Update исправляет оценку автором в течение ratingEditWindow.
+func (s *RatingUsecase) Update(authorID, ratingID string, changes *domain.Rating) (*domain.Rating, error) {
+	rating, err := s.ratingRepo.GetByID(ratingID)
+	if err != nil || rating.AuthorID != authorID {
+		s.logger.Warn("Rating not found")
+		return nil, ErrRatingNotFound
+	}
+
+	if s.now().After(rating.CreatedAt.Add(ratingEditWindow)) {
+		s.logger.Warn("Rating edit window has expired")
+		return nil, errors.New("rating can no longer be edited")
+	}
+
+	rating.Score = changes.Score
+	rating.Quality = changes.Quality
+	rating.Deadlines = changes.Deadlines
+	rating.Communication = changes.Communication
+	rating.Review = changes.Review
+
+	if err := s.ratingRepo.Update(rating); err != nil {
+		s.logger.WithError(err).Error("Failed to update rating")
+		return nil, err
+	}
+
+	s.logger.WithField("rating_id", ratingID).Info("Rating updated successfully")
+	return rating, nil
+}
+
+// Reply публикует ответ оцененного исполнителя или коуча на отзыв.
+func (s *RatingUsecase) Reply(targetID, targetRole, ratingID, reply string) (*domain.Rating, error) {
+	rating, err := s.ratingRepo.GetByID(ratingID)
+	if err != nil || rating.TargetID != targetID || rating.TargetRole != targetRole {
+		s.logger.Warn("Rating not found")
+		return nil, ErrRatingNotFound
+	}
+
+	now := s.now()
+	rating.Reply = reply
+	rating.RepliedAt = &now
+
+	if err := s.ratingRepo.SetReply(rating); err != nil {
+		s.logger.WithError(err).Error("Failed to save rating reply")
+		return nil, err
+	}
+
+	s.logger.WithField("rating_id", ratingID).Info("Rating reply saved")
+	return rating, nil
+}
+
+// ListForTarget возвращает отзывы о профиле от новых к старым.
+func (s *RatingUsecase) ListForTarget(targetID, targetRole string, limit, offset int) ([]domain.Rating, error) {
+	if limit <= 0 || limit > maxPageSize {
+		limit = defaultPageSize
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	ratings, err := s.ratingRepo.ListByTarget(targetID, targetRole, limit, offset)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to list ratings")
+		return nil, err
+	}
+	return ratings, nil
+}
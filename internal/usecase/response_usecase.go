@@ -0,0 +1,204 @@
+package usecase
+
+import (
+	"errors"
+	"math"
+
+	"BruhPro/internal/domain"
+	"BruhPro/internal/repository"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrResponseNotFound      = errors.New("response not found")
+	ErrResponseStatusChanged = errors.New("response has already been processed")
+)
+
+// ResponseUsecase управляет откликами исполнителей на заказы.
+type ResponseUsecase struct {
+	responseRepo repository.ResponseRepository
+	orderRepo    repository.OrderRepository
+	executorRepo repository.ExecutorRepository
+	orders       *OrderUsecase
+	verification *EmailVerificationUsecase
+	logger       *logrus.Logger
+}
+
+func NewResponseUsecase(responseRepo repository.ResponseRepository, orderRepo repository.OrderRepository, executorRepo repository.ExecutorRepository, orders *OrderUsecase, verification *EmailVerificationUsecase, logger *logrus.Logger) *ResponseUsecase {
+	return &ResponseUsecase{responseRepo, orderRepo, executorRepo, orders, verification, logger}
+}
+
+// Create оставляет отклик исполнителя на опубликованный заказ.
+// Для почасовых заказов ставка по умолчанию берется из профиля исполнителя.
+func (s *ResponseUsecase) Create(executorID, orderID string, response *domain.Response) error {
+	s.logger.WithFields(logrus.Fields{
+		"executor_id": executorID,
+		"order_id":    orderID,
+	}).Info("Attempting to respond to order")
+
+	if err := s.verification.RequireVerifiedEmail(executorID); err != nil {
+		s.logger.WithError(err).Warn("Response rejected")
+		return err
+	}
+
+	order, err := s.orderRepo.GetByID(orderID)
+	if err != nil || order.Status != domain.OrderStatusPublished {
+		s.logger.Warn("Order not found or not published")
+		return ErrOrderNotFound
+	}
+
+	if response.Price == 0 {
+		if order.PaymentMode != domain.PaymentModeHourly {
+			return errors.New("price is required for fixed budget orders")
+		}
+		executor, err := s.executorRepo.GetByID(executorID)
+		if err != nil {
+			s.logger.WithError(err).Error("Failed to get executor")
+			return err
+		}
+		response.Price = int64(math.Round(executor.HourlyRate * 100))
+	}
+	if response.Price <= 0 {
+		return errors.New("price must be positive")
+	}
+
+	response.ID = ""
+	response.OrderID = orderID
+	response.ExecutorID = executorID
+	response.Status = domain.ResponseStatusPending
+
+	if err := s.responseRepo.Create(response); err != nil {
+		if errors.Is(err, repository.ErrResponseExists) {
+			s.logger.Warn("Executor has already responded to this order")
+			return errors.New("you have already responded to this order")
+		}
+		s.logger.WithError(err).Error("Failed to create response")
+		return err
+	}
+
+	s.logger.WithField("response_id", response.ID).Info("Response created successfully")
+	return nil
+}
+
+// ListForOrder возвращает отклики на заказ его клиенту.
+func (s *ResponseUsecase) ListForOrder(customerID, orderID string) ([]domain.Response, error) {
+	if _, err := s.orders.ownOrder(customerID, orderID); err != nil {
+		return nil, err
+	}
+
+	responses, err := s.responseRepo.ListByOrder(orderID)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to list responses")
+		return nil, err
+	}
+	return responses, nil
+}
+
+// ListByExecutor возвращает отклики исполнителя.
+func (s *ResponseUsecase) ListByExecutor(executorID string) ([]domain.Response, error) {
+	responses, err := s.responseRepo.ListByExecutor(executorID)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to list executor responses")
+		return nil, err
+	}
+	return responses, nil
+}
+
+// Shortlist добавляет отклик в список кандидатов.
+func (s *ResponseUsecase) Shortlist(customerID, responseID string) (*domain.Response, error) {
+	return s.setStatus(customerID, responseID, domain.ResponseStatusShortlisted, domain.ResponseStatusPending)
+}
+
+// Reject отклоняет отклик.
+func (s *ResponseUsecase) Reject(customerID, responseID string) (*domain.Response, error) {
+	return s.setStatus(customerID, responseID, domain.ResponseStatusRejected, domain.ResponseStatusPending, domain.ResponseStatusShortlisted)
+}
+
+func (s *ResponseUsecase) setStatus(customerID, responseID, to string, from ...string) (*domain.Response, error) {
+	response, order, err := s.customerResponse(customerID, responseID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != domain.OrderStatusPublished {
+		return nil, ErrResponseStatusChanged
+	}
+
+	response.Status = to
+	if err := s.responseRepo.UpdateStatus(response, from...); err != nil {
+		if errors.Is(err, repository.ErrResponseStatusChanged) {
+			return nil, ErrResponseStatusChanged
+		}
+		s.logger.WithError(err).Error("Failed to update response status")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"response_id": responseID,
+		"status":      to,
+	}).Info("Response status changed")
+	return response, nil
+}
+
+// Accept выбирает исполнителя: отклик принимается, заказ переходит в работу с назначенным
+// исполнителем, остальные отклики отклоняются — все в одной транзакции.
+func (s *ResponseUsecase) Accept(customerID, responseID string) (*domain.Order, error) {
+	s.logger.WithFields(logrus.Fields{
+		"customer_id": customerID,
+		"response_id": responseID,
+	}).Info("Attempting to accept response")
+
+	response, order, err := s.customerResponse(customerID, responseID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != domain.OrderStatusPublished {
+		return nil, ErrInvalidOrderTransition
+	}
+
+	from := order.Status
+	response.Status = domain.ResponseStatusAccepted
+	order.Status = domain.OrderStatusInProgress
+	order.ExecutorID = &response.ExecutorID
+
+	change := &domain.OrderStatusChange{
+		FromStatus: from,
+		ToStatus:   domain.OrderStatusInProgress,
+		ActorID:    customerID,
+		ActorRole:  domain.RoleCustomer,
+		Reason:     "response accepted",
+	}
+	if err := s.responseRepo.Accept(response, order, from, change); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrResponseStatusChanged):
+			return nil, ErrResponseStatusChanged
+		case errors.Is(err, repository.ErrOrderStatusChanged):
+			return nil, ErrInvalidOrderTransition
+		}
+		s.logger.WithError(err).Error("Failed to accept response")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"order_id":    order.ID,
+		"executor_id": response.ExecutorID,
+	}).Info("Response accepted, order in progress")
+
+	s.orders.runHooks(order, change)
+	return order, nil
+}
+
+// customerResponse загружает отклик и заказ и проверяет, что заказ принадлежит клиенту.
+func (s *ResponseUsecase) customerResponse(customerID, responseID string) (*domain.Response, *domain.Order, error) {
+	response, err := s.responseRepo.GetByID(responseID)
+	if err != nil {
+		s.logger.WithError(err).Warn("Response not found")
+		return nil, nil, ErrResponseNotFound
+	}
+
+	order, err := s.orders.ownOrder(customerID, response.OrderID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return response, order, nil
+}
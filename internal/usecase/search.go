@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+
+	"BruhPro/internal/repository"
+)
+
+var (
+	ErrInvalidCursor       = errors.New("invalid cursor")
+	ErrInvalidSearchFilter = errors.New("min_rate must not exceed max_rate")
+)
+
+// searchSort подставляет сортировку по умолчанию: по релевантности, если задан текст запроса,
+// иначе по дате регистрации. Без текста сортировать по релевантности нечем.
+func searchSort(sort, query string) string {
+	if query != "" && (sort == "" || sort == repository.SortRelevance) {
+		return repository.SortRelevance
+	}
+	if sort == "" || sort == repository.SortRelevance {
+		return repository.SortRecent
+	}
+	return sort
+}
+
+// pageLimit ограничивает размер страницы.
+func pageLimit(limit int) int {
+	if limit <= 0 || limit > maxPageSize {
+		return defaultPageSize
+	}
+	return limit
+}
+
+// encodeSearchCursor упаковывает позицию страницы в непрозрачную для клиента строку.
+func encodeSearchCursor(cursor repository.SearchCursor) string {
+	raw, _ := json.Marshal(cursor) // в курсоре нет значений, которые не сериализуются
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodeSearchCursor разбирает курсор из запроса. Курсор от другой сортировки не подходит:
+// ключ страницы в нем не тот.
+func decodeSearchCursor(value, sort string) (*repository.SearchCursor, error) {
+	if value == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor repository.SearchCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.ID == "" || cursor.Sort != sort {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"errors"
+
+	"BruhPro/internal/domain"
+	"BruhPro/internal/repository"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrSpecializationNotFound = errors.New("specialization not found")
+	ErrSpecializationExists   = errors.New("specialization with this code already exists")
+	ErrSpecializationInUse    = errors.New("specialization is in use; deactivate it instead")
+	ErrUnknownSpecialization  = errors.New("unknown specialization")
+)
+
+// SpecializationUsecase ведет справочник специализаций и проверяет ссылки на него
+// при регистрации профилей, публикации заказов и создании курсов.
+type SpecializationUsecase struct {
+	specializationRepo repository.SpecializationRepository
+	logger             *logrus.Logger
+}
+
+func NewSpecializationUsecase(specializationRepo repository.SpecializationRepository, logger *logrus.Logger) *SpecializationUsecase {
+	return &SpecializationUsecase{specializationRepo, logger}
+}
+
+// List возвращает специализации роли; выключенные — только если includeInactive.
+func (s *SpecializationUsecase) List(role string, includeInactive bool) ([]domain.Specialization, error) {
+	specializations, err := s.specializationRepo.List(role, !includeInactive)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to list specializations")
+		return nil, err
+	}
+	return specializations, nil
+}
+
+// Validate проверяет, что все codes — включенные специализации роли.
+func (s *SpecializationUsecase) Validate(role string, codes ...string) error {
+	unique := make([]string, 0, len(codes))
+	seen := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		if code == "" {
+			return ErrUnknownSpecialization
+		}
+		if !seen[code] {
+			seen[code] = true
+			unique = append(unique, code)
+		}
+	}
+	if len(unique) == 0 {
+		return ErrUnknownSpecialization
+	}
+
+	count, err := s.specializationRepo.CountActive(role, unique)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to validate specializations")
+		return err
+	}
+	if count != int64(len(unique)) {
+		s.logger.WithField("codes", unique).Warn("Unknown specialization")
+		return ErrUnknownSpecialization
+	}
+	return nil
+}
+
+// Create добавляет специализацию в справочник.
+func (s *SpecializationUsecase) Create(specialization *domain.Specialization) error {
+	if _, err := s.specializationRepo.GetByCode(specialization.Code); err == nil {
+		s.logger.WithField("code", specialization.Code).Warn("Specialization already exists")
+		return ErrSpecializationExists
+	}
+
+	if err := s.specializationRepo.Create(specialization); err != nil {
+		s.logger.WithError(err).Error("Failed to create specialization")
+		return err
+	}
+	s.logger.WithField("code", specialization.Code).Info("Specialization created")
+	return nil
+}
+
+// Update меняет названия, порядок и активность специализации. Код и роль не меняются.
+func (s *SpecializationUsecase) Update(code string, changes *domain.Specialization) (*domain.Specialization, error) {
+	specialization, err := s.specializationRepo.GetByCode(code)
+	if err != nil {
+		return nil, ErrSpecializationNotFound
+	}
+
+	specialization.NameRu = changes.NameRu
+	specialization.NameKk = changes.NameKk
+	specialization.Position = changes.Position
+	specialization.Active = changes.Active
+	if err := s.specializationRepo.Update(specialization); err != nil {
+		s.logger.WithError(err).Error("Failed to update specialization")
+		return nil, err
+	}
+	s.logger.WithField("code", code).Info("Specialization updated")
+	return specialization, nil
+}
+
+// Delete удаляет специализацию, которую еще никто не выбрал. Используемую можно только выключить.
+func (s *SpecializationUsecase) Delete(code string) error {
+	if _, err := s.specializationRepo.GetByCode(code); err != nil {
+		return ErrSpecializationNotFound
+	}
+
+	if err := s.specializationRepo.Delete(code); err != nil {
+		if errors.Is(err, repository.ErrSpecializationInUse) {
+			return ErrSpecializationInUse
+		}
+		s.logger.WithError(err).Error("Failed to delete specialization")
+		return err
+	}
+	s.logger.WithField("code", code).Info("Specialization deleted")
+	return nil
+}
+
+// specializationCodes возвращает коды специализаций профиля.
+func specializationCodes(specializations []domain.Specialization) []string {
+	codes := make([]string, 0, len(specializations))
+	for _, specialization := range specializations {
+		codes = append(codes, specialization.Code)
+	}
+	return codes
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"sync"
+	"time"
+
+	"BruhPro/internal/domain"
+	"BruhPro/internal/repository"
+
+	"github.com/sirupsen/logrus"
+)
+
+// TokenDenylist — список отозванных jti и ID сессий с кешем в памяти.
+// JWT middleware проверяет только кеш, поэтому отзыв на этом инстансе действует сразу,
+// а на остальных — после очередного Sync.
+type TokenDenylist struct {
+	repo   repository.RevokedTokenRepository
+	logger *logrus.Logger
+
+	mu    sync.RWMutex
+	cache map[string]time.Time
+}
+
+func NewTokenDenylist(repo repository.RevokedTokenRepository, logger *logrus.Logger) *TokenDenylist {
+	return &TokenDenylist{
+		repo:   repo,
+		logger: logger,
+		cache:  make(map[string]time.Time),
+	}
+}
+
+// Revoke добавляет идентификатор в denylist до момента expiresAt.
+func (d *TokenDenylist) Revoke(tokenID string, expiresAt time.Time) error {
+	if err := d.repo.Add(&domain.RevokedToken{TokenID: tokenID, ExpiresAt: expiresAt}); err != nil {
+		d.logger.WithError(err).Error("Failed to save revoked token")
+		return err
+	}
+
+	d.mu.Lock()
+	d.cache[tokenID] = expiresAt
+	d.mu.Unlock()
+	return nil
+}
+
+// IsRevoked сообщает, отозван ли хотя бы один из переданных идентификаторов (jti, sid).
+func (d *TokenDenylist) IsRevoked(tokenIDs ...string) bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	now := time.Now()
+	for _, id := range tokenIDs {
+		if expiresAt, ok := d.cache[id]; ok && now.Before(expiresAt) {
+			return true
+		}
+	}
+	return false
+}
+
+// Sync перечитывает denylist из базы и удаляет истекшие записи.
+func (d *TokenDenylist) Sync() error {
+	if err := d.repo.DeleteExpired(); err != nil {
+		d.logger.WithError(err).Warn("Failed to delete expired revoked tokens")
+	}
+
+	tokens, err := d.repo.ListActive()
+	if err != nil {
+		d.logger.WithError(err).Error("Failed to load revoked tokens")
+		return err
+	}
+
+	cache := make(map[string]time.Time, len(tokens))
+	for _, t := range tokens {
+		cache[t.TokenID] = t.ExpiresAt
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	// Записи, добавленные локально во время загрузки, не должны потеряться
+	now := time.Now()
+	for id, expiresAt := range d.cache {
+		if now.Before(expiresAt) {
+			cache[id] = expiresAt
+		}
+	}
+	d.cache = cache
+	return nil
+}
+
+// StartSync загружает denylist и затем обновляет его с интервалом interval в фоне.
+func (d *TokenDenylist) StartSync(interval time.Duration) {
+	_ = d.Sync()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			_ = d.Sync()
+		}
+	}()
+}
@@ -1,23 +1,134 @@
 package utils
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 )
 
-func GenerateToken(userID, secret string) (string, error) {
+// AccessTokenTTL — время жизни access token.
+const AccessTokenTTL = 1 * time.Hour
+
+// MFAChallengeTTL — сколько действует токен второго шага входа.
+const MFAChallengeTTL = 5 * time.Minute
+
+// Значения claim "typ": access token и токен второго шага входа не взаимозаменяемы.
+const (
+	TokenTypeAccess       = "access"
+	TokenTypeMFAChallenge = "mfa_challenge"
+)
+
+// AccessToken — подписанный access token и данные, нужные для его отзыва.
+type AccessToken struct {
+	Token     string
+	ID        string // jti
+	ExpiresAt time.Time
+}
+
+// GenerateToken выпускает access token для пользователя с указанной ролью в рамках сессии sessionID.
+// mfa отмечает, что сессия открыта с подтверждением вторым фактором.
+func (m *JWTManager) GenerateToken(userID, role, sessionID string, mfa bool) (*AccessToken, error) {
+	jti := uuid.New().String()
+	now := time.Now()
+	expiresAt := now.Add(AccessTokenTTL)
+
 	claims := jwt.MapClaims{
+		"typ":     TokenTypeAccess,
+		"iss":     m.issuer,
+		"aud":     m.audience,
+		"sub":     userID,
 		"user_id": userID,
-		"exp":     time.Now().Add(1 * time.Hour).Unix(),
+		"role":    role,
+		"sid":     sessionID,
+		"jti":     jti,
+		"mfa":     mfa,
+		"iat":     now.Unix(),
+		"exp":     expiresAt.Unix(),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(secret))
+	signed, err := m.sign(claims)
+	if err != nil {
+		return nil, err
+	}
+	return &AccessToken{Token: signed, ID: jti, ExpiresAt: expiresAt}, nil
+}
+
+// ParseAccessToken проверяет подпись, алгоритм, iss, aud и срок действия access token
+// и возвращает его claims.
+func (m *JWTManager) ParseAccessToken(tokenStr string) (jwt.MapClaims, error) {
+	claims, err := m.parse(tokenStr, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	// Токен второго шага входа не является access token
+	if claims["typ"] != TokenTypeAccess {
+		return nil, errors.New("invalid token type")
+	}
+	return claims, nil
+}
+
+// MFAChallenge — разобранный токен второго шага входа.
+type MFAChallenge struct {
+	UserID    string
+	Role      string
+	ID        string // jti: после первой попытки кода токен попадает в denylist
+	ExpiresAt time.Time
+}
+
+// GenerateMFAChallenge выпускает короткоживущий токен, который после проверки пароля
+// обменивается на пару токенов вместе с TOTP-кодом или кодом восстановления.
+func (m *JWTManager) GenerateMFAChallenge(userID, role string, now time.Time) (string, error) {
+	claims := jwt.MapClaims{
+		"typ":     TokenTypeMFAChallenge,
+		"iss":     m.issuer,
+		"aud":     m.audience,
+		"user_id": userID,
+		"role":    role,
+		"jti":     uuid.New().String(),
+		"iat":     now.Unix(),
+		"exp":     now.Add(MFAChallengeTTL).Unix(),
+	}
+	return m.sign(claims)
+}
+
+// ParseMFAChallenge проверяет токен второго шага на момент now.
+func (m *JWTManager) ParseMFAChallenge(tokenStr string, now time.Time) (*MFAChallenge, error) {
+	claims, err := m.parse(tokenStr, now)
+	if err != nil || claims["typ"] != TokenTypeMFAChallenge {
+		return nil, errors.New("invalid or expired mfa token")
+	}
+
+	userID, _ := claims["user_id"].(string)
+	role, _ := claims["role"].(string)
+	jti, _ := claims["jti"].(string)
+	exp, err := claims.GetExpirationTime()
+	if userID == "" || role == "" || jti == "" || err != nil || exp == nil {
+		return nil, errors.New("invalid or expired mfa token")
+	}
+	return &MFAChallenge{UserID: userID, Role: role, ID: jti, ExpiresAt: exp.Time}, nil
 }
 
 func GenerateRefreshToken() (string, error) {
 	tokenID := uuid.New().String()
 	return tokenID, nil
 }
+
+// HashToken возвращает SHA-256 хеш токена в hex. В базе хранится только он.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// GenerateSecureToken возвращает 32 случайных байта в hex для одноразовых ссылок.
+func GenerateSecureToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
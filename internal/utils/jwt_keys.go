@@ -0,0 +1,218 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// JWTKey — ключ подписи токенов. Ключ без приватной части (выведенный из ротации)
+// используется только для проверки уже выданных токенов.
+type JWTKey struct {
+	ID      string // kid
+	Method  jwt.SigningMethod
+	Private crypto.Signer
+	Public  crypto.PublicKey
+}
+
+// ParseJWTKey разбирает PEM с ключом RSA (RS256) или Ed25519 (EdDSA).
+// Принимаются приватные ключи PKCS#8 и PKCS#1, а также публичные ключи PKIX.
+func ParseJWTKey(kid string, data []byte) (*JWTKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("key %s: no PEM block found", kid)
+	}
+
+	var parsed interface{}
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("key %s: unsupported PEM block %q", kid, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("key %s: %w", kid, err)
+	}
+
+	key := &JWTKey{ID: kid}
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.Method, key.Private, key.Public = jwt.SigningMethodRS256, k, &k.PublicKey
+	case *rsa.PublicKey:
+		key.Method, key.Public = jwt.SigningMethodRS256, k
+	case ed25519.PrivateKey:
+		key.Method, key.Private, key.Public = jwt.SigningMethodEdDSA, k, k.Public()
+	case ed25519.PublicKey:
+		key.Method, key.Public = jwt.SigningMethodEdDSA, k
+	default:
+		return nil, fmt.Errorf("key %s: only RSA and Ed25519 keys are supported", kid)
+	}
+	return key, nil
+}
+
+// JWTManager подписывает токены текущим ключом и проверяет их по любому из загруженных ключей,
+// выбирая ключ по заголовку kid. Так при ротации токены, подписанные прежним ключом,
+// остаются действительными до истечения срока.
+type JWTManager struct {
+	keys     map[string]*JWTKey
+	signing  *JWTKey
+	methods  []string
+	issuer   string
+	audience string
+}
+
+// NewJWTManager создает менеджер; signingKeyID должен указывать на ключ с приватной частью.
+func NewJWTManager(keys []*JWTKey, signingKeyID, issuer, audience string) (*JWTManager, error) {
+	if issuer == "" || audience == "" {
+		return nil, errors.New("jwt issuer and audience are required")
+	}
+
+	m := &JWTManager{keys: make(map[string]*JWTKey, len(keys)), issuer: issuer, audience: audience}
+	seen := map[string]bool{}
+	for _, key := range keys {
+		if _, ok := m.keys[key.ID]; ok {
+			return nil, fmt.Errorf("duplicate jwt key id %s", key.ID)
+		}
+		m.keys[key.ID] = key
+		if alg := key.Method.Alg(); !seen[alg] {
+			seen[alg] = true
+			m.methods = append(m.methods, alg)
+		}
+	}
+
+	signing, ok := m.keys[signingKeyID]
+	if !ok || signing.Private == nil {
+		return nil, fmt.Errorf("jwt signing key %q not found or has no private part", signingKeyID)
+	}
+	m.signing = signing
+	return m, nil
+}
+
+// LoadJWTManager загружает ключи из каталога dir: каждый файл *.pem — один ключ,
+// имя файла без расширения — его kid. Пример создания ключа:
+//
+//	openssl genpkey -algorithm ed25519 -out keys/2026-10.pem
+func LoadJWTManager(dir, signingKeyID, issuer, audience string) (*JWTManager, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no jwt keys found in %s", dir)
+	}
+	sort.Strings(files)
+
+	keys := make([]*JWTKey, 0, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		key, err := ParseJWTKey(strings.TrimSuffix(filepath.Base(file), ".pem"), data)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return NewJWTManager(keys, signingKeyID, issuer, audience)
+}
+
+func (m *JWTManager) sign(claims jwt.MapClaims) (string, error) {
+	token := jwt.NewWithClaims(m.signing.Method, claims)
+	token.Header["kid"] = m.signing.ID
+	return token.SignedString(m.signing.Private)
+}
+
+// parse проверяет токен на момент now. Алгоритм должен совпадать с алгоритмом ключа из kid:
+// токены с alg=none, HS256 или чужим алгоритмом отклоняются.
+func (m *JWTManager) parse(tokenStr string, now time.Time) (jwt.MapClaims, error) {
+	token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, ok := m.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown key id %q", kid)
+		}
+		if token.Method.Alg() != key.Method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+		}
+		return key.Public, nil
+	},
+		jwt.WithValidMethods(m.methods),
+		jwt.WithIssuer(m.issuer),
+		jwt.WithAudience(m.audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(func() time.Time { return now }),
+	)
+	if err != nil || !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, errors.New("invalid claims")
+	}
+	return claims, nil
+}
+
+// JWK — публичный ключ в формате RFC 7517.
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKSet — набор ключей для /.well-known/jwks.json.
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS возвращает публичные части всех загруженных ключей, чтобы другие сервисы
+// могли проверять токены BuhPro без общего секрета.
+func (m *JWTManager) JWKS() JWKSet {
+	ids := make([]string, 0, len(m.keys))
+	for id := range m.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	set := JWKSet{Keys: make([]JWK, 0, len(ids))}
+	for _, id := range ids {
+		key := m.keys[id]
+		jwk := JWK{Use: "sig", Alg: key.Method.Alg(), Kid: id}
+		switch pub := key.Public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
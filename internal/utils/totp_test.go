@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+// rfc6238Secret — ключ "12345678901234567890" из приложения B RFC 6238 (SHA-1) в base32.
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// Коды из RFC — восьмизначные, шестизначный код — их последние шесть цифр.
+var rfc6238Vectors = []struct {
+	unix int64
+	code string
+}{
+	{59, "287082"},
+	{1111111109, "081804"},
+	{1111111111, "050471"},
+	{1234567890, "005924"},
+	{2000000000, "279037"},
+	{20000000000, "353130"},
+}
+
+func TestTOTPCodeRFC6238(t *testing.T) {
+	for _, tc := range rfc6238Vectors {
+		got, err := TOTPCode(rfc6238Secret, TOTPStep(time.Unix(tc.unix, 0)))
+		if err != nil {
+			t.Fatalf("T=%d: %v", tc.unix, err)
+		}
+		if got != tc.code {
+			t.Errorf("T=%d: code %s, want %s", tc.unix, got, tc.code)
+		}
+	}
+}
+
+func TestValidateTOTPRFC6238(t *testing.T) {
+	for _, tc := range rfc6238Vectors {
+		now := time.Unix(tc.unix, 0)
+		step, ok := ValidateTOTP(rfc6238Secret, tc.code, now)
+		if !ok {
+			t.Errorf("T=%d: code %s rejected", tc.unix, tc.code)
+			continue
+		}
+		if step != TOTPStep(now) {
+			t.Errorf("T=%d: step %d, want %d", tc.unix, step, TOTPStep(now))
+		}
+	}
+}
+
+func TestValidateTOTPSkew(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	current := TOTPStep(now)
+
+	tests := []struct {
+		name   string
+		offset int64
+		ok     bool
+	}{
+		{"two steps behind", -2, false},
+		{"previous step", -1, true},
+		{"current step", 0, true},
+		{"next step", 1, true},
+		{"two steps ahead", 2, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			code, err := TOTPCode(rfc6238Secret, current+tc.offset)
+			if err != nil {
+				t.Fatal(err)
+			}
+			step, ok := ValidateTOTP(rfc6238Secret, code, now)
+			if ok != tc.ok {
+				t.Fatalf("accepted = %v, want %v", ok, tc.ok)
+			}
+			if ok && step != current+tc.offset {
+				t.Errorf("step %d, want %d", step, current+tc.offset)
+			}
+		})
+	}
+}
+
+func TestValidateTOTPRejectsMalformedCodes(t *testing.T) {
+	now := time.Unix(59, 0)
+	for _, code := range []string{"", "28708", "2870820", "94287082", "abcdef"} {
+		if _, ok := ValidateTOTP(rfc6238Secret, code, now); ok {
+			t.Errorf("code %q accepted", code)
+		}
+	}
+	if _, ok := ValidateTOTP(rfc6238Secret, " 287082 ", now); !ok {
+		t.Error("code with surrounding spaces rejected")
+	}
+}
@@ -2,11 +2,61 @@ package utils
 
 import (
 	"fmt"
+	"reflect"
 	"regexp" // Импорт regexp должен быть здесь
+	"strings"
+
+	"BruhPro/internal/iin"
+	"BruhPro/internal/phone"
 
 	"github.com/go-playground/validator/v10"
 )
 
+// NewValidator создает валидатор запросов с тегами проверки казахстанских идентификаторов
+// и телефонов:
+//
+//	iin        — ИИН физического лица с корректной датой рождения;
+//	bin        — БИН юридического лица;
+//	iin_or_bin — ИИН или БИН;
+//	bin_if     — БИН, если поле Field равно value: `bin_if=ClientType ТОО`;
+//	phone      — телефон в формате E.164 (phone.Number приводит к нему при разборе JSON).
+func NewValidator() *validator.Validate {
+	validate := validator.New()
+	_ = validate.RegisterValidation("iin", func(fl validator.FieldLevel) bool {
+		_, err := iin.ParseIIN(fl.Field().String())
+		return err == nil
+	})
+	_ = validate.RegisterValidation("bin", func(fl validator.FieldLevel) bool {
+		_, err := iin.ParseBIN(fl.Field().String())
+		return err == nil
+	})
+	_ = validate.RegisterValidation("iin_or_bin", func(fl validator.FieldLevel) bool {
+		_, err := iin.Parse(fl.Field().String())
+		return err == nil
+	})
+	_ = validate.RegisterValidation("bin_if", validateBINIf)
+	_ = validate.RegisterValidation("phone", func(fl validator.FieldLevel) bool {
+		number, err := phone.Parse(fl.Field().String())
+		return err == nil && number.String() == fl.Field().String()
+	})
+	return validate
+}
+
+// validateBINIf требует БИН, только если соседнее поле из параметра тега равно заданному значению.
+func validateBINIf(fl validator.FieldLevel) bool {
+	params := strings.SplitN(fl.Param(), " ", 2)
+	if len(params) != 2 {
+		panic(fmt.Sprintf("bin_if: expected \"Field value\", got %q", fl.Param()))
+	}
+
+	other := reflect.Indirect(fl.Parent()).FieldByName(params[0])
+	if !other.IsValid() || other.String() != params[1] {
+		return true
+	}
+	_, err := iin.ParseBIN(fl.Field().String())
+	return err == nil
+}
+
 // IsPasswordComplex проверяет сложность пароля.
 // Пароль должен содержать как минимум одну заглавную букву, одну строчную букву, одну цифру и один специальный символ.
 func IsPasswordComplex(password string) bool {
@@ -30,6 +80,18 @@ func CustomValidationErrors(errs validator.ValidationErrors) []string {
 			messages = append(messages, fmt.Sprintf("%s is required", err.Field()))
 		case "email":
 			messages = append(messages, fmt.Sprintf("%s must be a valid email address", err.Field()))
+		case "iin":
+			messages = append(messages, fmt.Sprintf("%s must be a valid individual IIN", err.Field()))
+		case "bin":
+			messages = append(messages, fmt.Sprintf("%s must be a valid BIN", err.Field()))
+		case "iin_or_bin":
+			messages = append(messages, fmt.Sprintf("%s must be a valid IIN or BIN", err.Field()))
+		case "bin_if":
+			messages = append(messages, fmt.Sprintf("%s must be a BIN when %s", err.Field(), strings.Replace(err.Param(), " ", " is ", 1)))
+		case "phone":
+			messages = append(messages, fmt.Sprintf("%s must be a valid phone number, e.g. +7 701 123 45 67", err.Field()))
+		case "isdefault":
+			messages = append(messages, fmt.Sprintf("%s cannot be changed here", err.Field()))
 		case "min":
 			messages = append(messages, fmt.Sprintf("%s must be at least %s characters long", err.Field(), err.Param()))
 		default:
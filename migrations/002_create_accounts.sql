@@ -0,0 +1,80 @@
+-- Единая учетная запись: email, пароль и сессии хранятся в accounts,
+-- а customers, coaches и executors становятся профилями ролей с тем же id, что и аккаунт.
+
+CREATE TABLE IF NOT EXISTS accounts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    email TEXT UNIQUE NOT NULL,
+    password_hash TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS account_roles (
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    role TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (account_id, role)
+);
+
+-- 1. Переносим учетные данные. Если один email встречается в нескольких таблицах,
+--    аккаунт создается по первой записи (users, затем customers, coaches, executors),
+--    и ее пароль остается паролем объединенного аккаунта.
+INSERT INTO accounts (id, email, password_hash, created_at)
+SELECT id, email, password_hash, created_at FROM users
+ON CONFLICT (email) DO NOTHING;
+
+INSERT INTO accounts (id, email, password_hash, created_at)
+SELECT id, email, password_hash, created_at FROM customers
+ON CONFLICT (email) DO NOTHING;
+
+INSERT INTO accounts (id, email, password_hash, created_at)
+SELECT id, email, password_hash, created_at FROM coaches
+ON CONFLICT (email) DO NOTHING;
+
+INSERT INTO accounts (id, email, password_hash, created_at)
+SELECT id, email, password_hash, created_at FROM executors
+ON CONFLICT (email) DO NOTHING;
+
+-- 2. Профили получают id своего аккаунта (меняется только у объединенных записей).
+UPDATE customers c SET id = a.id FROM accounts a WHERE a.email = c.email AND c.id <> a.id;
+UPDATE coaches c SET id = a.id FROM accounts a WHERE a.email = c.email AND c.id <> a.id;
+UPDATE executors e SET id = a.id FROM accounts a WHERE a.email = e.email AND e.id <> a.id;
+
+-- 3. Роли аккаунтов.
+INSERT INTO account_roles (account_id, role)
+SELECT a.id, 'user' FROM users u JOIN accounts a ON a.email = u.email
+ON CONFLICT DO NOTHING;
+
+INSERT INTO account_roles (account_id, role) SELECT id, 'customer' FROM customers ON CONFLICT DO NOTHING;
+INSERT INTO account_roles (account_id, role) SELECT id, 'coach' FROM coaches ON CONFLICT DO NOTHING;
+INSERT INTO account_roles (account_id, role) SELECT id, 'executor' FROM executors ON CONFLICT DO NOTHING;
+
+-- 4. Учетные данные больше не хранятся в профилях.
+ALTER TABLE customers
+    DROP COLUMN email,
+    DROP COLUMN password_hash,
+    ALTER COLUMN id DROP DEFAULT,
+    ADD CONSTRAINT customers_account_fkey FOREIGN KEY (id) REFERENCES accounts(id) ON DELETE CASCADE;
+
+ALTER TABLE coaches
+    DROP COLUMN email,
+    DROP COLUMN password_hash,
+    ALTER COLUMN id DROP DEFAULT,
+    ADD CONSTRAINT coaches_account_fkey FOREIGN KEY (id) REFERENCES accounts(id) ON DELETE CASCADE;
+
+ALTER TABLE executors
+    DROP COLUMN email,
+    DROP COLUMN password_hash,
+    ALTER COLUMN id DROP DEFAULT,
+    ADD CONSTRAINT executors_account_fkey FOREIGN KEY (id) REFERENCES accounts(id) ON DELETE CASCADE;
+
+-- 5. Refresh токены теперь принадлежат аккаунту. Старые токены ссылались на id разных
+--    таблиц, поэтому они удаляются: пользователям нужно войти заново.
+DELETE FROM refresh_tokens;
+
+ALTER TABLE refresh_tokens DROP CONSTRAINT IF EXISTS refresh_tokens_user_id_fkey;
+ALTER TABLE refresh_tokens RENAME COLUMN user_id TO account_id;
+ALTER TABLE refresh_tokens
+    ADD CONSTRAINT refresh_tokens_account_id_fkey FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE CASCADE;
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_account_id ON refresh_tokens(account_id);
+
+DROP TABLE IF EXISTS users;
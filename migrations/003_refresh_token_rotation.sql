@@ -0,0 +1,15 @@
+-- Ротация refresh токенов: токены хранятся в виде SHA-256 хеша и объединяются в семьи.
+-- Существующие токены хешируются на месте, поэтому активные сессии не теряются.
+
+UPDATE refresh_tokens SET token = encode(digest(token, 'sha256'), 'hex');
+ALTER TABLE refresh_tokens RENAME COLUMN token TO token_hash;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_refresh_tokens_token_hash ON refresh_tokens(token_hash);
+
+-- Каждый существующий токен начинает собственную семью.
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS family_id UUID;
+UPDATE refresh_tokens SET family_id = id::uuid WHERE family_id IS NULL;
+ALTER TABLE refresh_tokens ALTER COLUMN family_id SET NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family_id ON refresh_tokens(family_id);
+
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS used_at TIMESTAMP;
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS revoked_at TIMESTAMP;
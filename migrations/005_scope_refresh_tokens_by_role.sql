@@ -0,0 +1,16 @@
+-- Refresh токены и сессии привязываются к роли, для которой были выданы.
+-- Внешний ключ на account_roles гарантирует, что роль действительно есть у аккаунта,
+-- а при удалении роли ее токены и сессии удаляются вместе с ней.
+
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS role TEXT;
+UPDATE refresh_tokens rt SET role = s.role FROM sessions s WHERE s.id = rt.family_id AND rt.role IS NULL;
+DELETE FROM refresh_tokens WHERE role IS NULL;
+ALTER TABLE refresh_tokens ALTER COLUMN role SET NOT NULL;
+
+ALTER TABLE refresh_tokens
+    ADD CONSTRAINT refresh_tokens_account_role_fkey FOREIGN KEY (account_id, role)
+        REFERENCES account_roles(account_id, role) ON DELETE CASCADE;
+
+ALTER TABLE sessions
+    ADD CONSTRAINT sessions_account_role_fkey FOREIGN KEY (account_id, role)
+        REFERENCES account_roles(account_id, role) ON DELETE CASCADE;
@@ -0,0 +1,19 @@
+-- Подтверждение email. Новые аккаунты создаются неподтвержденными.
+
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMP;
+
+-- Аккаунты, зарегистрированные до появления подтверждения, считаются подтвержденными,
+-- чтобы не заблокировать действующих клиентов и исполнителей.
+UPDATE accounts SET email_verified_at = created_at WHERE email_verified_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS email_verification_tokens (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    email TEXT NOT NULL,
+    token_hash TEXT UNIQUE NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_verification_tokens_account_id ON email_verification_tokens(account_id);
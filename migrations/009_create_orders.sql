@@ -0,0 +1,24 @@
+-- Заказы клиентов. В 001 таблица orders была заготовкой без полей.
+-- Суммы хранятся в тиынах.
+
+DROP TABLE IF EXISTS orders;
+
+CREATE TABLE orders (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    customer_id UUID NOT NULL REFERENCES customers(id) ON DELETE CASCADE,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL,
+    specialization TEXT NOT NULL,
+    payment_mode TEXT NOT NULL CHECK (payment_mode IN ('fixed', 'hourly')),
+    budget BIGINT NOT NULL CHECK (budget > 0),
+    deadline TIMESTAMP,
+    work_format TEXT NOT NULL,
+    city TEXT NOT NULL,
+    status TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_orders_customer_id ON orders(customer_id);
+CREATE INDEX IF NOT EXISTS idx_orders_status ON orders(status);
+CREATE INDEX IF NOT EXISTS idx_orders_specialization ON orders(specialization);
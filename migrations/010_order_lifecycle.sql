@@ -0,0 +1,25 @@
+-- Жизненный цикл заказа: назначенный исполнитель и история переходов статусов.
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS executor_id UUID REFERENCES executors(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_orders_executor_id ON orders(executor_id);
+
+ALTER TABLE orders ADD CONSTRAINT orders_status_check CHECK (
+    status IN ('draft', 'published', 'in_progress', 'on_review', 'completed', 'cancelled', 'disputed')
+);
+
+CREATE TABLE IF NOT EXISTS order_status_changes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    from_status TEXT,
+    to_status TEXT NOT NULL,
+    actor_id UUID,
+    actor_role TEXT NOT NULL,
+    reason TEXT,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_status_changes_order_id ON order_status_changes(order_id);
+
+-- Заказы, созданные до появления истории, получают запись о публикации.
+INSERT INTO order_status_changes (order_id, from_status, to_status, actor_id, actor_role, created_at)
+SELECT id, '', status, customer_id, 'customer', created_at FROM orders;
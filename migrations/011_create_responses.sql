@@ -0,0 +1,21 @@
+-- Отклики исполнителей на заказы. В 001 таблица responses была заготовкой без полей.
+
+DROP TABLE IF EXISTS responses;
+
+CREATE TABLE responses (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    executor_id UUID NOT NULL REFERENCES executors(id) ON DELETE CASCADE,
+    cover_message TEXT NOT NULL,
+    price BIGINT NOT NULL CHECK (price > 0),
+    estimated_days INTEGER NOT NULL CHECK (estimated_days > 0),
+    status TEXT NOT NULL CHECK (status IN ('pending', 'shortlisted', 'rejected', 'accepted', 'declined')),
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_responses_order_executor ON responses(order_id, executor_id);
+CREATE INDEX IF NOT EXISTS idx_responses_executor_id ON responses(executor_id);
+
+-- У заказа может быть только один принятый отклик.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_responses_order_accepted ON responses(order_id) WHERE status = 'accepted';
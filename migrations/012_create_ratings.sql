@@ -0,0 +1,36 @@
+-- Оценки и отзывы по завершенным заказам. В 001 таблица ratings была заготовкой без полей.
+
+DROP TABLE IF EXISTS ratings;
+
+CREATE TABLE ratings (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id UUID NOT NULL UNIQUE REFERENCES orders(id) ON DELETE CASCADE,
+    author_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    target_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    target_role TEXT NOT NULL,
+    score SMALLINT NOT NULL CHECK (score BETWEEN 1 AND 5),
+    quality SMALLINT NOT NULL CHECK (quality BETWEEN 1 AND 5),
+    deadlines SMALLINT NOT NULL CHECK (deadlines BETWEEN 1 AND 5),
+    communication SMALLINT NOT NULL CHECK (communication BETWEEN 1 AND 5),
+    review TEXT,
+    reply TEXT,
+    replied_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_ratings_author_id ON ratings(author_id);
+CREATE INDEX IF NOT EXISTS idx_ratings_target ON ratings(target_id, target_role);
+
+-- Суммы оценок по профилям; средние считаются при чтении как sum / count.
+CREATE TABLE IF NOT EXISTS rating_summaries (
+    target_id UUID NOT NULL,
+    target_role TEXT NOT NULL,
+    count BIGINT NOT NULL DEFAULT 0,
+    sum_score BIGINT NOT NULL DEFAULT 0,
+    sum_quality BIGINT NOT NULL DEFAULT 0,
+    sum_deadlines BIGINT NOT NULL DEFAULT 0,
+    sum_communication BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (target_id, target_role)
+);
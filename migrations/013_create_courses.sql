@@ -0,0 +1,72 @@
+-- Курсы коучей: разделы, уроки с файлами, записи и прогресс. В 001 таблица courses была заготовкой без полей.
+
+DROP TABLE IF EXISTS courses;
+
+CREATE TABLE courses (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    coach_id UUID NOT NULL REFERENCES coaches(id) ON DELETE CASCADE,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL,
+    specialization TEXT NOT NULL,
+    price BIGINT NOT NULL CHECK (price >= 0), -- в тиынах, 0 — бесплатный курс
+    status TEXT NOT NULL,
+    published_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_courses_coach_id ON courses(coach_id);
+CREATE INDEX IF NOT EXISTS idx_courses_specialization ON courses(specialization);
+CREATE INDEX IF NOT EXISTS idx_courses_status ON courses(status);
+
+CREATE TABLE IF NOT EXISTS course_modules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    course_id UUID NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+    title TEXT NOT NULL,
+    position INT NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_course_modules_course_id ON course_modules(course_id);
+
+CREATE TABLE IF NOT EXISTS lessons (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    module_id UUID NOT NULL REFERENCES course_modules(id) ON DELETE CASCADE,
+    course_id UUID NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+    title TEXT NOT NULL,
+    content TEXT,
+    video_url TEXT,
+    position INT NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_lessons_module_id ON lessons(module_id);
+CREATE INDEX IF NOT EXISTS idx_lessons_course_id ON lessons(course_id);
+
+CREATE TABLE IF NOT EXISTS lesson_attachments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    lesson_id UUID NOT NULL REFERENCES lessons(id) ON DELETE CASCADE,
+    file_name TEXT NOT NULL,
+    url TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_lesson_attachments_lesson_id ON lesson_attachments(lesson_id);
+
+CREATE TABLE IF NOT EXISTS course_enrollments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    course_id UUID NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    role TEXT NOT NULL,
+    status TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_course_enrollments_course_account ON course_enrollments(course_id, account_id);
+CREATE INDEX IF NOT EXISTS idx_course_enrollments_account_id ON course_enrollments(account_id);
+
+CREATE TABLE IF NOT EXISTS lesson_progresses (
+    enrollment_id UUID NOT NULL REFERENCES course_enrollments(id) ON DELETE CASCADE,
+    lesson_id UUID NOT NULL REFERENCES lessons(id) ON DELETE CASCADE,
+    completed_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (enrollment_id, lesson_id)
+);
@@ -0,0 +1,41 @@
+-- Платежи за заказы и курсы. В 001 таблица payments была заготовкой без полей.
+-- Суммы в тиынах (1 тенге = 100 тиынов), валюта — KZT.
+
+DROP TABLE IF EXISTS payments;
+
+CREATE TABLE payments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE RESTRICT,
+    purpose TEXT NOT NULL CHECK (purpose IN ('order', 'course')),
+    order_id UUID REFERENCES orders(id) ON DELETE RESTRICT,
+    enrollment_id UUID REFERENCES course_enrollments(id) ON DELETE RESTRICT,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    refunded_amount BIGINT NOT NULL DEFAULT 0 CHECK (refunded_amount >= 0 AND refunded_amount <= amount),
+    currency TEXT NOT NULL DEFAULT 'KZT',
+    status TEXT NOT NULL CHECK (status IN ('pending', 'authorized', 'captured', 'refunded', 'failed')),
+    provider TEXT NOT NULL,
+    provider_payment_id TEXT,
+    checkout_url TEXT,
+    failure_reason TEXT,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    CHECK ((purpose = 'order' AND order_id IS NOT NULL) OR (purpose = 'course' AND enrollment_id IS NOT NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_payments_account_id ON payments(account_id);
+CREATE INDEX IF NOT EXISTS idx_payments_order_id ON payments(order_id);
+CREATE INDEX IF NOT EXISTS idx_payments_enrollment_id ON payments(enrollment_id);
+CREATE INDEX IF NOT EXISTS idx_payments_status ON payments(status);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payments_provider_payment ON payments(provider, provider_payment_id);
+
+-- Обработанные уведомления провайдера: повторная доставка упирается в первичный ключ.
+CREATE TABLE IF NOT EXISTS payment_events (
+    provider TEXT NOT NULL,
+    event_id TEXT NOT NULL,
+    payment_id UUID NOT NULL REFERENCES payments(id) ON DELETE CASCADE,
+    status TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (provider, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_events_payment_id ON payment_events(payment_id);
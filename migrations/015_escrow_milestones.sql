@@ -0,0 +1,29 @@
+-- Этапы заказов с оплатой на эскроу. Платеж за заказ теперь относится к конкретному этапу.
+-- Суммы в тиынах.
+
+CREATE TABLE IF NOT EXISTS milestones (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE RESTRICT,
+    title TEXT NOT NULL,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    position INTEGER NOT NULL DEFAULT 0,
+    status TEXT NOT NULL CHECK (status IN ('pending', 'funded', 'submitted', 'released', 'refunded', 'cancelled')),
+    payment_id UUID REFERENCES payments(id) ON DELETE RESTRICT,
+    commission BIGINT NOT NULL DEFAULT 0 CHECK (commission >= 0),
+    payout BIGINT NOT NULL DEFAULT 0 CHECK (payout >= 0),
+    submitted_at TIMESTAMP,
+    auto_release_at TIMESTAMP,
+    released_at TIMESTAMP,
+    released_by TEXT,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    CHECK (commission + payout = 0 OR commission + payout = amount)
+);
+
+CREATE INDEX IF NOT EXISTS idx_milestones_order_id ON milestones(order_id);
+CREATE INDEX IF NOT EXISTS idx_milestones_status ON milestones(status);
+-- Фоновая автоприемка выбирает только сданные этапы
+CREATE INDEX IF NOT EXISTS idx_milestones_auto_release_at ON milestones(auto_release_at) WHERE status = 'submitted';
+
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS milestone_id UUID REFERENCES milestones(id) ON DELETE RESTRICT;
+CREATE INDEX IF NOT EXISTS idx_payments_milestone_id ON payments(milestone_id);
@@ -0,0 +1,12 @@
+-- Публичный поиск исполнителей: стаж в годах для фильтра и индексы под фильтры и сортировки.
+-- exp_work — свободный текст («5 лет», «более 3»), поэтому берется первое число из него.
+
+ALTER TABLE executors
+    ADD COLUMN IF NOT EXISTS exp_years INT
+    GENERATED ALWAYS AS (COALESCE(substring(exp_work FROM '[0-9]{1,2}')::int, 0)) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_executors_exp_years ON executors(exp_years);
+CREATE INDEX IF NOT EXISTS idx_executors_city ON executors(LOWER(city));
+CREATE INDEX IF NOT EXISTS idx_executors_work_format ON executors(work_format);
+CREATE INDEX IF NOT EXISTS idx_executors_hourly_rate ON executors(hourly_rate, id);
+CREATE INDEX IF NOT EXISTS idx_executors_created_at ON executors(created_at, id);
@@ -0,0 +1,28 @@
+-- Полнотекстовый поиск по профилям исполнителей и коучей.
+-- search_vector — вычисляемая колонка: PostgreSQL сам пересчитывает ее при изменении
+-- текстовых полей, отдельные триггеры и переиндексация не нужны. AutoMigrate ее не трогает.
+-- Русский текст индексируется со стеммингом, казахского стеммера в PostgreSQL нет,
+-- поэтому тот же текст дополнительно индексируется конфигурацией simple с меньшим весом.
+-- Запрос разбирается обеими конфигурациями (repository.searchQueryJoin).
+
+ALTER TABLE executors
+    ADD COLUMN IF NOT EXISTS search_vector TSVECTOR
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', about_executor), 'A') ||
+        setweight(to_tsvector('russian', education), 'B') ||
+        setweight(to_tsvector('simple', about_executor || ' ' || education), 'D')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_executors_search_vector ON executors USING GIN (search_vector);
+
+ALTER TABLE coaches
+    ADD COLUMN IF NOT EXISTS search_vector TSVECTOR
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', about_coach), 'A') ||
+        setweight(to_tsvector('russian', methodology), 'B') ||
+        setweight(to_tsvector('russian', achievements_experience), 'B') ||
+        setweight(to_tsvector('simple', about_coach || ' ' || methodology || ' ' || achievements_experience), 'D')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_coaches_search_vector ON coaches USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_coaches_created_at ON coaches(created_at, id);
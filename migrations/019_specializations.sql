@@ -0,0 +1,108 @@
+-- Справочник специализаций вместо строк в профилях и списков в коде.
+-- Профили ссылаются на справочник через таблицы связей, заказы и курсы — кодом в specialization.
+
+CREATE TABLE IF NOT EXISTS specializations (
+    code TEXT PRIMARY KEY CHECK (code ~ '^[a-z][a-z0-9_]*$'),
+    role TEXT NOT NULL CHECK (role IN ('executor', 'coach')),
+    name_ru TEXT NOT NULL,
+    name_kk TEXT NOT NULL,
+    position INT NOT NULL DEFAULT 0,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_specializations_role ON specializations(role);
+
+INSERT INTO specializations (code, role, name_ru, name_kk, position) VALUES
+    ('accounting', 'executor', 'Бухгалтерский учет', 'Бухгалтерлік есеп', 10),
+    ('tax_consulting', 'executor', 'Налоговое консультирование', 'Салықтық кеңес беру', 20),
+    ('audit', 'executor', 'Аудиторские услуги', 'Аудиторлық қызметтер', 30),
+    ('financial_analysis', 'executor', 'Финансовый анализ', 'Қаржылық талдау', 40),
+    ('reporting', 'executor', 'Подготовка отчетности', 'Есептілікті дайындау', 50),
+    ('accounting_recovery', 'executor', 'Восстановление учета', 'Есепті қалпына келтіру', 60),
+    ('management_accounting', 'executor', 'Управленческий учет', 'Басқарушылық есеп', 70),
+    ('ifrs', 'executor', 'Международные стандарты (МСФО)', 'Халықаралық стандарттар (ҚЕХС)', 80),
+    ('tax_planning', 'executor', 'Налоговое планирование', 'Салықтық жоспарлау', 90),
+    ('hr_records', 'executor', 'Кадровое делопроизводство', 'Кадрлық іс жүргізу', 100),
+    ('business_coaching', 'coach', 'Бизнес-коучинг', 'Бизнес-коучинг', 10),
+    ('career_coaching', 'coach', 'Карьерный коучинг', 'Мансаптық коучинг', 20),
+    ('financial_coaching', 'coach', 'Финансовый коучинг', 'Қаржылық коучинг', 30),
+    ('leadership', 'coach', 'Лидерство', 'Көшбасшылық', 40),
+    ('personal_growth', 'coach', 'Личностный рост', 'Жеке тұлғаның өсуі', 50)
+ON CONFLICT (code) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS executor_specializations (
+    executor_id UUID NOT NULL REFERENCES executors(id) ON DELETE CASCADE,
+    specialization_code TEXT NOT NULL REFERENCES specializations(code) ON UPDATE CASCADE,
+    PRIMARY KEY (executor_id, specialization_code)
+);
+
+CREATE INDEX IF NOT EXISTS idx_executor_specializations_code ON executor_specializations(specialization_code);
+
+CREATE TABLE IF NOT EXISTS coach_specializations (
+    coach_id UUID NOT NULL REFERENCES coaches(id) ON DELETE CASCADE,
+    specialization_code TEXT NOT NULL REFERENCES specializations(code) ON UPDATE CASCADE,
+    PRIMARY KEY (coach_id, specialization_code)
+);
+
+CREATE INDEX IF NOT EXISTS idx_coach_specializations_code ON coach_specializations(specialization_code);
+
+-- Сопоставление свободного текста со справочником: без учета регистра, «ё» и пробелов,
+-- по названию или коду; «МСФО» и «IFRS» — отдельно, так их часто пишут без расшифровки.
+CREATE OR REPLACE FUNCTION pg_temp.specialization_key(value TEXT) RETURNS TEXT AS $$
+    SELECT replace(lower(btrim(value)), 'ё', 'е')
+$$ LANGUAGE SQL IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION pg_temp.matches_specialization(s specializations, value TEXT) RETURNS BOOLEAN AS $$
+    SELECT pg_temp.specialization_key(s.name_ru) = pg_temp.specialization_key(value)
+        OR s.code = pg_temp.specialization_key(value)
+        OR (s.code = 'ifrs' AND pg_temp.specialization_key(value) IN ('мсфо', 'ifrs'))
+$$ LANGUAGE SQL IMMUTABLE;
+
+-- Строки профилей разбиваются по запятым и точкам с запятой. Нераспознанные значения
+-- остаются в specializations_legacy: колонка удаляется, когда их разберут вручную.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'executors' AND column_name = 'specializations') THEN
+        INSERT INTO executor_specializations (executor_id, specialization_code)
+        SELECT DISTINCT e.id, s.code
+        FROM executors e
+        CROSS JOIN LATERAL regexp_split_to_table(e.specializations, '\s*[,;]\s*') AS value
+        JOIN specializations s ON s.role = 'executor' AND pg_temp.matches_specialization(s, value)
+        ON CONFLICT DO NOTHING;
+
+        ALTER TABLE executors RENAME COLUMN specializations TO specializations_legacy;
+        ALTER TABLE executors ALTER COLUMN specializations_legacy DROP NOT NULL;
+    END IF;
+
+    IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'coaches' AND column_name = 'specializations') THEN
+        INSERT INTO coach_specializations (coach_id, specialization_code)
+        SELECT DISTINCT c.id, s.code
+        FROM coaches c
+        CROSS JOIN LATERAL regexp_split_to_table(c.specializations, '\s*[,;]\s*') AS value
+        JOIN specializations s ON s.role = 'coach' AND pg_temp.matches_specialization(s, value)
+        ON CONFLICT DO NOTHING;
+
+        ALTER TABLE coaches RENAME COLUMN specializations TO specializations_legacy;
+        ALTER TABLE coaches ALTER COLUMN specializations_legacy DROP NOT NULL;
+    END IF;
+END $$;
+
+-- Заказы и курсы хранили название из списка в коде, теперь — код.
+UPDATE orders o SET specialization = s.code
+FROM specializations s
+WHERE s.role = 'executor' AND o.specialization <> s.code AND pg_temp.matches_specialization(s, o.specialization);
+
+UPDATE courses c SET specialization = s.code
+FROM specializations s
+WHERE s.role = 'coach' AND c.specialization <> s.code AND pg_temp.matches_specialization(s, c.specialization);
+
+-- NOT VALID: старые строки, не попавшие в справочник, не мешают миграции, новые проверяются.
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_specialization_fkey;
+ALTER TABLE orders ADD CONSTRAINT orders_specialization_fkey
+    FOREIGN KEY (specialization) REFERENCES specializations(code) ON UPDATE CASCADE NOT VALID;
+
+ALTER TABLE courses DROP CONSTRAINT IF EXISTS courses_specialization_fkey;
+ALTER TABLE courses ADD CONSTRAINT courses_specialization_fkey
+    FOREIGN KEY (specialization) REFERENCES specializations(code) ON UPDATE CASCADE NOT VALID;
@@ -0,0 +1,16 @@
+-- ИИН/БИН хранятся строкой из 12 цифр: в DOUBLE PRECISION терялись ведущие нули.
+-- 12-значные числа double хранит точно, поэтому значения восстанавливаются дополнением нулями слева.
+-- Формат проверяется ограничением; контрольный разряд — при регистрации (iin.Parse).
+-- NOT VALID: записи, которые и раньше были некорректными, не мешают миграции, новые проверяются.
+
+ALTER TABLE customers
+    ALTER COLUMN iin TYPE VARCHAR(12) USING lpad(round(iin)::bigint::text, 12, '0');
+
+ALTER TABLE customers DROP CONSTRAINT IF EXISTS customers_iin_format;
+ALTER TABLE customers ADD CONSTRAINT customers_iin_format CHECK (iin ~ '^[0-9]{12}$') NOT VALID;
+
+ALTER TABLE executors
+    ALTER COLUMN iin TYPE VARCHAR(12) USING lpad(round(iin)::bigint::text, 12, '0');
+
+ALTER TABLE executors DROP CONSTRAINT IF EXISTS executors_iin_format;
+ALTER TABLE executors ADD CONSTRAINT executors_iin_format CHECK (iin ~ '^[0-9]{12}$') NOT VALID;
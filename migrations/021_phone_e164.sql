@@ -0,0 +1,27 @@
+-- Телефоны хранятся строкой в E.164 (+77011234567): в DOUBLE PRECISION терялся «+»
+-- и нельзя было отличить код страны. Старые значения — казахстанские номера,
+-- записанные числом: 87011234567, 77011234567 или 7011234567; они переводятся так же,
+-- как phone.Parse. Остальное сохраняется цифрами с «+» и не проходит проверку формата
+-- (NOT VALID): такие номера владельцы исправят при редактировании профиля.
+
+CREATE OR REPLACE FUNCTION pg_temp.phone_e164(value DOUBLE PRECISION) RETURNS TEXT AS $$
+    SELECT CASE
+        WHEN digits ~ '^[78][0-9]{10}$' THEN '+7' || substr(digits, 2)
+        WHEN digits ~ '^[1-9][0-9]{9}$' THEN '+7' || digits
+        ELSE '+' || digits
+    END
+    FROM (SELECT round(value)::bigint::text AS digits) AS number
+$$ LANGUAGE SQL IMMUTABLE;
+
+ALTER TABLE customers ALTER COLUMN phone_number TYPE VARCHAR(16) USING pg_temp.phone_e164(phone_number);
+ALTER TABLE coaches ALTER COLUMN phone_number TYPE VARCHAR(16) USING pg_temp.phone_e164(phone_number);
+ALTER TABLE executors ALTER COLUMN phone_number TYPE VARCHAR(16) USING pg_temp.phone_e164(phone_number);
+
+ALTER TABLE customers DROP CONSTRAINT IF EXISTS customers_phone_e164;
+ALTER TABLE customers ADD CONSTRAINT customers_phone_e164 CHECK (phone_number ~ '^\+[1-9][0-9]{7,14}$') NOT VALID;
+
+ALTER TABLE coaches DROP CONSTRAINT IF EXISTS coaches_phone_e164;
+ALTER TABLE coaches ADD CONSTRAINT coaches_phone_e164 CHECK (phone_number ~ '^\+[1-9][0-9]{7,14}$') NOT VALID;
+
+ALTER TABLE executors DROP CONSTRAINT IF EXISTS executors_phone_e164;
+ALTER TABLE executors ADD CONSTRAINT executors_phone_e164 CHECK (phone_number ~ '^\+[1-9][0-9]{7,14}$') NOT VALID;
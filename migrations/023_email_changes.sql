@@ -0,0 +1,20 @@
+-- Смена email: новый адрес подтверждается ссылкой из письма, после замены старый адрес
+-- получает ссылку отмены. Хранятся только хеши токенов.
+
+CREATE TABLE IF NOT EXISTS email_changes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    role TEXT NOT NULL,
+    old_email TEXT NOT NULL,
+    new_email TEXT NOT NULL,
+    confirm_token_hash TEXT UNIQUE NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    confirmed_at TIMESTAMP,
+    canceled_at TIMESTAMP,
+    revert_token_hash TEXT UNIQUE,
+    revert_expires_at TIMESTAMP,
+    reverted_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_changes_account_id ON email_changes(account_id);
@@ -0,0 +1,6 @@
+-- Токен сброса пароля привязывается к роли, из кабинета которой запрошен сброс.
+-- Ранее выданные токены без роли погашаются: они живут недолго, пользователь запросит новую ссылку.
+
+ALTER TABLE password_reset_tokens ADD COLUMN IF NOT EXISTS role TEXT;
+DELETE FROM password_reset_tokens WHERE role IS NULL;
+ALTER TABLE password_reset_tokens ALTER COLUMN role SET NOT NULL;
@@ -0,0 +1,8 @@
+-- Оценки коучей: ученик оценивает курс по своей записи. У оценки есть ровно один источник —
+-- завершенный заказ (оценка исполнителя) или запись на курс (оценка коуча).
+
+ALTER TABLE ratings ALTER COLUMN order_id DROP NOT NULL;
+ALTER TABLE ratings ADD COLUMN IF NOT EXISTS enrollment_id UUID UNIQUE REFERENCES course_enrollments(id) ON DELETE CASCADE;
+
+ALTER TABLE ratings
+    ADD CONSTRAINT ratings_source_check CHECK (num_nonnulls(order_id, enrollment_id) = 1);